@@ -0,0 +1,369 @@
+// Package aec decodes data compressed with the CCSDS 121.0-B adaptive entropy coder,
+// compatible with the output of libaec.
+package aec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+)
+
+// Flags of the compressed data, same values as libaec
+const (
+	DataSigned     uint8 = 1  // samples are signed
+	Data3Byte      uint8 = 2  // 17 to 24 bits samples are stored in 3 bytes
+	DataMSB        uint8 = 4  // samples are stored with the most significant byte first
+	DataPreprocess uint8 = 8  // samples are preprocessed with a unit delay predictor
+	Restricted     uint8 = 16 // restricted set of code options for samples of up to 4 bits
+	PadRSI         uint8 = 32 // each reference sample interval is padded to a byte boundary
+	NotEnforce     uint8 = 64 // do not enforce the standard block sizes
+)
+
+const (
+	// remainder of segment, a zero block run reaching the end of the segment or interval
+	ros = 5
+
+	// maximum value of a second extension code
+	seMax = 90
+)
+
+var (
+	ErrInvalidParams = errors.New("aec: invalid parameters")
+	ErrMalformed     = errors.New("aec: malformed data")
+)
+
+// Params are the coding parameters, in GRIB2 they are read from data representation template 5.42.
+type Params struct {
+	BitsPerSample int
+	BlockSize     int
+	RSI           int // reference sample interval, in blocks
+	Flags         uint8
+}
+
+func (p Params) validate() error {
+	if p.BitsPerSample < 1 || p.BitsPerSample > 32 {
+		return fmt.Errorf("%w: %d bits per sample", ErrInvalidParams, p.BitsPerSample)
+	}
+
+	if p.Flags&NotEnforce != 0 {
+		if p.BlockSize <= 0 || p.BlockSize%2 != 0 {
+			return fmt.Errorf("%w: block size %d", ErrInvalidParams, p.BlockSize)
+		}
+	} else {
+		switch p.BlockSize {
+		case 8, 16, 32, 64:
+		default:
+			return fmt.Errorf("%w: block size %d", ErrInvalidParams, p.BlockSize)
+		}
+	}
+
+	if p.RSI < 1 || p.RSI > 4096 {
+		return fmt.Errorf("%w: reference sample interval %d", ErrInvalidParams, p.RSI)
+	}
+
+	if p.Flags&Restricted != 0 && p.BitsPerSample > 4 {
+		return fmt.Errorf("%w: restricted coding of %d bits samples", ErrInvalidParams, p.BitsPerSample)
+	}
+
+	return nil
+}
+
+// idLength returns the number of bits of the code option identifiers.
+func (p Params) idLength() int {
+	switch {
+	case p.Flags&Restricted != 0 && p.BitsPerSample <= 2:
+		return 1
+	case p.Flags&Restricted != 0:
+		return 2
+	case p.BitsPerSample > 16:
+		return 5
+	case p.BitsPerSample > 8:
+		return 4
+	default:
+		return 3
+	}
+}
+
+type decoder struct {
+	r      *bitio.Reader
+	p      Params
+	idLen  uint8
+	bits   uint8
+	rsiBuf []uint64
+}
+
+// Decode reads numSamples samples.
+func Decode(r *bitio.Reader, numSamples int, p Params) ([]int64, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	d := &decoder{
+		r:      r,
+		p:      p,
+		idLen:  uint8(p.idLength()),
+		bits:   uint8(p.BitsPerSample),
+		rsiBuf: make([]uint64, 0, p.RSI*p.BlockSize),
+	}
+
+	out := make([]int64, 0, numSamples)
+
+	for len(out) < numSamples {
+		d.rsiBuf = d.rsiBuf[:0]
+
+		for len(d.rsiBuf) < p.RSI*p.BlockSize && len(out)+len(d.rsiBuf) < numSamples {
+			if err := d.block(); err != nil {
+				return nil, fmt.Errorf("sample %d: %w", len(out)+len(d.rsiBuf), err)
+			}
+		}
+
+		out = d.postprocess(out, numSamples)
+
+		if p.Flags&PadRSI != 0 {
+			d.r.Align()
+		}
+	}
+
+	return out, nil
+}
+
+// block decodes a coded data set, which is one block or a run of zero blocks.
+func (d *decoder) block() error {
+	j := d.p.BlockSize
+	ref := d.p.Flags&DataPreprocess != 0 && len(d.rsiBuf) == 0
+	used := len(d.rsiBuf)
+
+	id, err := d.r.ReadBits(d.idLen)
+	if err != nil {
+		return fmt.Errorf("read option identifier: %w", err)
+	}
+
+	switch {
+	case id == 0:
+		// low entropy options
+		se, err := d.r.ReadBool()
+		if err != nil {
+			return err
+		}
+
+		if ref {
+			if err := d.raw(1); err != nil {
+				return err
+			}
+		}
+
+		if se {
+			return d.secondExtension(ref)
+		}
+
+		return d.zeroBlocks(used, ref)
+
+	case id == 1<<d.idLen-1:
+		// no compression, the reference sample is the first sample
+		return d.raw(j)
+
+	default:
+		if ref {
+			if err := d.raw(1); err != nil {
+				return err
+			}
+		}
+
+		return d.split(int(id-1), ref)
+	}
+}
+
+func (d *decoder) raw(n int) error {
+	for i := 0; i < n; i++ {
+		v, err := d.r.ReadBits(d.bits)
+		if err != nil {
+			return fmt.Errorf("read sample: %w", err)
+		}
+
+		d.rsiBuf = append(d.rsiBuf, v)
+	}
+
+	return nil
+}
+
+// fundamentalSequence reads a fundamental sequence codeword, the number of zeros followed by a one.
+func (d *decoder) fundamentalSequence() (uint64, error) {
+	var n uint64
+
+	for {
+		one, err := d.r.ReadBool()
+		if err != nil {
+			return 0, fmt.Errorf("read fundamental sequence: %w", err)
+		}
+
+		if one {
+			return n, nil
+		}
+
+		n++
+	}
+}
+
+// split decodes a sample splitting block: the fundamental sequences of the high bits of all
+// samples followed by the k low bits of all samples.
+func (d *decoder) split(k int, ref bool) error {
+	n := d.p.BlockSize
+	if ref {
+		n--
+	}
+
+	start := len(d.rsiBuf)
+
+	for i := 0; i < n; i++ {
+		fs, err := d.fundamentalSequence()
+		if err != nil {
+			return err
+		}
+
+		d.rsiBuf = append(d.rsiBuf, fs<<k)
+	}
+
+	if k == 0 {
+		return nil
+	}
+
+	for i := start; i < len(d.rsiBuf); i++ {
+		v, err := d.r.ReadBits(uint8(k))
+		if err != nil {
+			return fmt.Errorf("read split bits: %w", err)
+		}
+
+		d.rsiBuf[i] |= v
+	}
+
+	return nil
+}
+
+// secondExtension decodes a block of pairs of samples coded as one codeword.
+func (d *decoder) secondExtension(ref bool) error {
+	i := 0
+	if ref {
+		i = 1
+	}
+
+	for i < d.p.BlockSize {
+		m, err := d.fundamentalSequence()
+		if err != nil {
+			return err
+		}
+
+		if m > seMax {
+			return fmt.Errorf("%w: second extension codeword %d", ErrMalformed, m)
+		}
+
+		// m = beta * (beta + 1) / 2 + d1, with beta = d0 + d1
+		beta := uint64(0)
+		for (beta+1)*(beta+2)/2 <= m {
+			beta++
+		}
+
+		d1 := m - beta*(beta+1)/2
+
+		if i%2 == 0 {
+			d.rsiBuf = append(d.rsiBuf, beta-d1)
+			i++
+		}
+
+		d.rsiBuf = append(d.rsiBuf, d1)
+		i++
+	}
+
+	return nil
+}
+
+// zeroBlocks decodes a run of all zero blocks.
+func (d *decoder) zeroBlocks(used int, ref bool) error {
+	fs, err := d.fundamentalSequence()
+	if err != nil {
+		return err
+	}
+
+	blocks := int(fs) + 1
+
+	switch {
+	case blocks == ros:
+		// up to the end of the segment of 64 blocks or of the reference sample interval
+		b := used / d.p.BlockSize
+		blocks = min(d.p.RSI-b, 64-b%64)
+	case blocks > ros:
+		blocks--
+	}
+
+	n := blocks * d.p.BlockSize
+	if ref {
+		n--
+	}
+
+	if len(d.rsiBuf)+n > d.p.RSI*d.p.BlockSize {
+		return fmt.Errorf("%w: zero block run exceeds reference sample interval", ErrMalformed)
+	}
+
+	for i := 0; i < n; i++ {
+		d.rsiBuf = append(d.rsiBuf, 0)
+	}
+
+	return nil
+}
+
+// postprocess appends the samples of the reference sample interval to out, reverting the
+// predictor and the mapping of the preprocessor.
+func (d *decoder) postprocess(out []int64, numSamples int) []int64 {
+	var (
+		signed = d.p.Flags&DataSigned != 0
+		xmin   = int64(0)
+		xmax   = int64(1)<<d.bits - 1
+		last   int64
+	)
+
+	if signed {
+		xmin, xmax = -(int64(1) << (d.bits - 1)), int64(1)<<(d.bits-1)-1
+	}
+
+	sample := func(v uint64) int64 {
+		if signed && v&(1<<(d.bits-1)) != 0 {
+			return int64(v) - int64(1)<<d.bits
+		}
+
+		return int64(v)
+	}
+
+	for i, v := range d.rsiBuf {
+		if len(out) == numSamples {
+			break
+		}
+
+		var x int64
+
+		switch {
+		case d.p.Flags&DataPreprocess == 0:
+			x = sample(v)
+		case i == 0:
+			x = sample(v)
+		default:
+			delta := int64(v)
+			theta := min(last-xmin, xmax-last)
+
+			switch {
+			case delta <= 2*theta && delta%2 == 0:
+				x = last + delta/2
+			case delta <= 2*theta:
+				x = last - (delta+1)/2
+			case last-xmin < xmax-last:
+				x = xmin + delta
+			default:
+				x = xmax - delta
+			}
+		}
+
+		out = append(out, x)
+		last = x
+	}
+
+	return out
+}
@@ -0,0 +1,160 @@
+package aec_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/scorix/grib-go/internal/pkg/aec"
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bitWriter struct {
+	buf   []byte
+	nbits int
+}
+
+// write appends the n lowest bits of v, most significant first.
+func (w *bitWriter) write(v uint64, n int) *bitWriter {
+	for i := n - 1; i >= 0; i-- {
+		if w.nbits%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+
+		if v>>i&1 == 1 {
+			w.buf[len(w.buf)-1] |= 0x80 >> (w.nbits % 8)
+		}
+
+		w.nbits++
+	}
+
+	return w
+}
+
+// fs appends a fundamental sequence codeword.
+func (w *bitWriter) fs(vs ...uint64) *bitWriter {
+	for _, v := range vs {
+		w.write(1, int(v)+1)
+	}
+
+	return w
+}
+
+func (w *bitWriter) align() *bitWriter {
+	w.nbits = len(w.buf) * 8
+	return w
+}
+
+func repeat(v int64, n int) []int64 {
+	vs := make([]int64, n)
+	for i := range vs {
+		vs[i] = v
+	}
+
+	return vs
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		params     aec.Params
+		numSamples int
+		data       *bitWriter
+		want       []int64
+	}{
+		{
+			name:       "split and zero block with preprocessing",
+			params:     aec.Params{BitsPerSample: 8, BlockSize: 8, RSI: 2, Flags: aec.DataPreprocess},
+			numSamples: 16,
+			data: new(bitWriter).
+				write(1, 3).write(10, 8).fs(4, 1, 0, 0, 0, 0, 0). // k = 0, reference 10
+				write(0, 3).write(0, 1).fs(0),                    // one zero block
+			want: append([]int64{10, 12, 11, 11, 11, 11, 11, 11}, repeat(11, 8)...),
+		},
+		{
+			name:       "split",
+			params:     aec.Params{BitsPerSample: 8, BlockSize: 8, RSI: 1},
+			numSamples: 8,
+			data: new(bitWriter).
+				write(4, 3).fs(1, 0, 2, 0, 1, 0, 1, 8). // k = 3
+				write(1, 3).write(2, 3).write(1, 3).write(0, 3).write(0, 3).write(7, 3).write(7, 3).write(0, 3),
+			want: []int64{9, 2, 17, 0, 8, 7, 15, 64},
+		},
+		{
+			name:       "second extension",
+			params:     aec.Params{BitsPerSample: 4, BlockSize: 8, RSI: 1},
+			numSamples: 8,
+			data:       new(bitWriter).write(0, 3).write(1, 1).fs(2, 3, 4, 0),
+			want:       []int64{0, 1, 2, 0, 1, 1, 0, 0},
+		},
+		{
+			name:       "uncompressed signed with padded intervals",
+			params:     aec.Params{BitsPerSample: 8, BlockSize: 8, RSI: 1, Flags: aec.DataPreprocess | aec.DataSigned | aec.PadRSI},
+			numSamples: 16,
+			data: new(bitWriter).
+				write(7, 3).write(0xfd, 8).write(249, 8).write(255, 8).write(0, 40).align().
+				write(7, 3).write(5, 8).write(0, 56),
+			want: append([]int64{-3, -128, 127, 127, 127, 127, 127, 127}, repeat(5, 8)...),
+		},
+		{
+			name:       "zero blocks to the end of the interval",
+			params:     aec.Params{BitsPerSample: 16, BlockSize: 8, RSI: 4, Flags: aec.DataPreprocess},
+			numSamples: 30,
+			data:       new(bitWriter).write(0, 4).write(0, 1).write(1000, 16).fs(4),
+			want:       repeat(1000, 30),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := aec.Decode(bitio.NewReader(bytes.NewReader(tt.data.buf)), tt.numSamples, tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecode_Libaec(t *testing.T) {
+	t.Parallel()
+
+	// aec -n 8 -j 8 -r 2 of a constant run, a ramp and bytes of noise, encoded by libaec 1.0.2
+	data := []byte{
+		0x06, 0x44, 0x19, 0x26, 0x49, 0x24, 0x92, 0xd8, 0x49, 0x24, 0x92, 0x49, 0x24, 0x93, 0xc1, 0xe7,
+		0xb2, 0xb3, 0x27, 0x3e, 0x9b, 0xb4, 0x7d, 0xfd, 0x4f, 0x6b, 0xde, 0x8d, 0x95, 0xb7, 0x00,
+	}
+
+	want := repeat(100, 24)
+	for i := range 24 {
+		want = append(want, int64(100+i))
+	}
+	want = append(want, 7, 158, 53, 204, 99, 250, 145, 40, 191, 86, 237, 132, 27, 178, 73, 224)
+
+	got, err := aec.Decode(bitio.NewReader(bytes.NewReader(data)), len(want), aec.Params{
+		BitsPerSample: 8,
+		BlockSize:     8,
+		RSI:           2,
+		Flags:         aec.DataPreprocess,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestDecode_Error(t *testing.T) {
+	t.Parallel()
+
+	truncated := new(bitWriter).write(7, 3).write(1, 8)
+
+	_, err := aec.Decode(bitio.NewReader(bytes.NewReader(truncated.buf)), 8, aec.Params{BitsPerSample: 8, BlockSize: 8, RSI: 1})
+	assert.Error(t, err)
+
+	_, err = aec.Decode(bitio.NewReader(bytes.NewReader(nil)), 8, aec.Params{BitsPerSample: 8, BlockSize: 12, RSI: 1})
+	assert.ErrorIs(t, err, aec.ErrInvalidParams)
+
+	_, err = aec.Decode(bitio.NewReader(bytes.NewReader(nil)), 8, aec.Params{BitsPerSample: 8, BlockSize: 8, RSI: 1, Flags: aec.Restricted})
+	assert.ErrorIs(t, err, aec.ErrInvalidParams)
+}
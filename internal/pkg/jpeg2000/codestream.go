@@ -0,0 +1,589 @@
+package jpeg2000
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Marker codes (T.800 Table A.2)
+const (
+	markerSOC = 0xff4f
+	markerSIZ = 0xff51
+	markerCOD = 0xff52
+	markerCOC = 0xff53
+	markerTLM = 0xff55
+	markerPLM = 0xff57
+	markerPLT = 0xff58
+	markerQCD = 0xff5c
+	markerQCC = 0xff5d
+	markerRGN = 0xff5e
+	markerPOC = 0xff5f
+	markerPPM = 0xff60
+	markerPPT = 0xff61
+	markerCRG = 0xff63
+	markerCOM = 0xff64
+	markerSOT = 0xff90
+	markerSOP = 0xff91
+	markerEPH = 0xff92
+	markerSOD = 0xff93
+	markerEOC = 0xffd9
+)
+
+// Progression orders (T.800 Table A.16)
+const (
+	progressionLRCP = iota
+	progressionRLCP
+	progressionRPCL
+	progressionPCRL
+	progressionCPRL
+)
+
+// Code-block styles (T.800 Table A.19)
+const (
+	cblkBypass  = 0x01
+	cblkReset   = 0x02
+	cblkTermAll = 0x04
+	cblkVSC     = 0x08
+	cblkPTerm   = 0x10
+	cblkSegSym  = 0x20
+)
+
+// Quantization styles (T.800 Table A.28)
+const (
+	quantNone      = 0
+	quantDerived   = 1
+	quantExpounded = 2
+)
+
+type component struct {
+	precision int
+	signed    bool
+	dx, dy    int
+}
+
+type imageSize struct {
+	xsiz, ysiz     uint32
+	xosiz, yosiz   uint32
+	xtsiz, ytsiz   uint32
+	xtosiz, ytosiz uint32
+	comps          []component
+}
+
+// codingStyle holds the Scod/SGcod parameters of a COD marker segment.
+type codingStyle struct {
+	sop         bool
+	eph         bool
+	progression uint8
+	layers      int
+}
+
+// componentStyle holds the SPcod/SPcoc parameters shared by COD and COC marker segments.
+type componentStyle struct {
+	levels     int
+	xcb, ycb   int // code-block size exponents
+	cblkStyle  uint8
+	reversible bool
+	ppx, ppy   []int // precinct size exponents, one per resolution level
+}
+
+type quantization struct {
+	style     uint8
+	guard     int
+	exponents []int
+	mantissas []int
+}
+
+// exponentMantissa returns the quantization parameters of the subband with the given index,
+// where subbands are numbered LL, HL, LH, HH of the lowest resolution up to the highest.
+func (q *quantization) exponentMantissa(band int, levels int) (int, int, error) {
+	if q.style == quantDerived {
+		if len(q.exponents) == 0 {
+			return 0, 0, fmt.Errorf("%w: empty QCD", ErrMalformed)
+		}
+
+		if band == 0 {
+			return q.exponents[0], q.mantissas[0], nil
+		}
+
+		// decomposition level of the subband
+		nb := levels - (band-1)/3
+		return q.exponents[0] - levels + nb, q.mantissas[0], nil
+	}
+
+	if band >= len(q.exponents) {
+		return 0, 0, fmt.Errorf("%w: no quantization step for subband %d", ErrMalformed, band)
+	}
+
+	return q.exponents[band], q.mantissas[band], nil
+}
+
+// tileParams holds the marker segments of a header, main or tile.
+type tileParams struct {
+	cod  *codingStyle
+	spc  *componentStyle // from COD
+	coc  *componentStyle // from COC for component 0
+	qcd  *quantization
+	qcc  *quantization // from QCC for component 0
+	seen bool
+}
+
+type tile struct {
+	index  int
+	params tileParams
+	data   []byte
+}
+
+type codestream struct {
+	siz   imageSize
+	main  tileParams
+	tiles []*tile
+}
+
+type byteReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *byteReader) remaining() int {
+	return len(r.data) - r.pos
+}
+
+func (r *byteReader) need(n int) error {
+	if r.remaining() < n {
+		return fmt.Errorf("%w: unexpected end of data at %d", ErrMalformed, r.pos)
+	}
+
+	return nil
+}
+
+func (r *byteReader) u8() uint8 {
+	v := r.data[r.pos]
+	r.pos++
+	return v
+}
+
+func (r *byteReader) u16() uint16 {
+	v := binary.BigEndian.Uint16(r.data[r.pos:])
+	r.pos += 2
+	return v
+}
+
+func (r *byteReader) u32() uint32 {
+	v := binary.BigEndian.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v
+}
+
+// segment reads the length of a marker segment and returns its parameters.
+func (r *byteReader) segment() (*byteReader, error) {
+	if err := r.need(2); err != nil {
+		return nil, err
+	}
+
+	l := int(r.u16())
+	if l < 2 {
+		return nil, fmt.Errorf("%w: marker segment length %d", ErrMalformed, l)
+	}
+
+	if err := r.need(l - 2); err != nil {
+		return nil, err
+	}
+
+	seg := &byteReader{data: r.data[r.pos : r.pos+l-2]}
+	r.pos += l - 2
+
+	return seg, nil
+}
+
+func parseCodestream(data []byte) (*codestream, error) {
+	r := &byteReader{data: data}
+
+	if err := r.need(2); err != nil {
+		return nil, err
+	}
+
+	if m := r.u16(); m != markerSOC {
+		return nil, fmt.Errorf("%w: missing SOC marker, got %#04x", ErrMalformed, m)
+	}
+
+	cs := &codestream{}
+
+	// main header
+	for {
+		if err := r.need(2); err != nil {
+			return nil, err
+		}
+
+		m := r.u16()
+		if m == markerSOT {
+			r.pos -= 2
+			break
+		}
+
+		seg, err := r.segment()
+		if err != nil {
+			return nil, err
+		}
+
+		switch m {
+		case markerSIZ:
+			if err := cs.parseSIZ(seg); err != nil {
+				return nil, err
+			}
+		case markerCOD, markerCOC, markerQCD, markerQCC:
+			if err := cs.parseParam(&cs.main, m, seg); err != nil {
+				return nil, err
+			}
+		case markerCOM, markerTLM, markerPLM, markerCRG:
+			// informative only
+		case markerRGN, markerPOC, markerPPM:
+			return nil, fmt.Errorf("%w: marker %#04x", ErrUnsupported, m)
+		default:
+			if m < 0xff30 {
+				return nil, fmt.Errorf("%w: unexpected marker %#04x in main header", ErrMalformed, m)
+			}
+		}
+	}
+
+	if cs.siz.comps == nil {
+		return nil, fmt.Errorf("%w: missing SIZ marker", ErrMalformed)
+	}
+
+	if cs.main.cod == nil || cs.main.qcd == nil {
+		return nil, fmt.Errorf("%w: missing COD or QCD marker in main header", ErrMalformed)
+	}
+
+	numTiles := cs.numTilesX() * cs.numTilesY()
+	tiles := make([]*tile, numTiles)
+
+	// tile-parts
+	for r.remaining() >= 2 {
+		start := r.pos
+
+		m := r.u16()
+		if m == markerEOC {
+			break
+		}
+
+		if m != markerSOT {
+			return nil, fmt.Errorf("%w: expected SOT marker at %d, got %#04x", ErrMalformed, start, m)
+		}
+
+		seg, err := r.segment()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := seg.need(8); err != nil {
+			return nil, err
+		}
+
+		isot := int(seg.u16())
+		psot := int(seg.u32())
+		tpsot := seg.u8()
+
+		if isot >= numTiles {
+			return nil, fmt.Errorf("%w: tile index %d out of %d tiles", ErrMalformed, isot, numTiles)
+		}
+
+		end := start + psot
+		if psot == 0 {
+			// the last tile-part extends to the EOC marker
+			end = len(data) - 2
+		}
+
+		if end > len(data) || end < r.pos {
+			return nil, fmt.Errorf("%w: tile-part length %d at %d", ErrMalformed, psot, start)
+		}
+
+		t := tiles[isot]
+		if t == nil {
+			t = &tile{index: isot}
+			tiles[isot] = t
+		}
+
+		// tile-part header
+		for {
+			if err := r.need(2); err != nil {
+				return nil, err
+			}
+
+			m := r.u16()
+			if m == markerSOD {
+				break
+			}
+
+			seg, err := r.segment()
+			if err != nil {
+				return nil, err
+			}
+
+			switch m {
+			case markerCOD, markerCOC, markerQCD, markerQCC:
+				if tpsot != 0 {
+					return nil, fmt.Errorf("%w: marker %#04x in tile-part %d", ErrMalformed, m, tpsot)
+				}
+
+				if err := cs.parseParam(&t.params, m, seg); err != nil {
+					return nil, err
+				}
+			case markerCOM, markerPLT:
+				// informative only
+			case markerRGN, markerPOC, markerPPT:
+				return nil, fmt.Errorf("%w: marker %#04x", ErrUnsupported, m)
+			default:
+				return nil, fmt.Errorf("%w: unexpected marker %#04x in tile-part header", ErrMalformed, m)
+			}
+		}
+
+		if r.pos > end {
+			return nil, fmt.Errorf("%w: tile-part header exceeds tile-part length", ErrMalformed)
+		}
+
+		t.data = append(t.data, data[r.pos:end]...)
+		r.pos = end
+	}
+
+	for _, t := range tiles {
+		if t != nil {
+			cs.tiles = append(cs.tiles, t)
+		}
+	}
+
+	return cs, nil
+}
+
+func (cs *codestream) parseSIZ(seg *byteReader) error {
+	if err := seg.need(36); err != nil {
+		return err
+	}
+
+	_ = seg.u16() // Rsiz
+	s := &cs.siz
+	s.xsiz, s.ysiz = seg.u32(), seg.u32()
+	s.xosiz, s.yosiz = seg.u32(), seg.u32()
+	s.xtsiz, s.ytsiz = seg.u32(), seg.u32()
+	s.xtosiz, s.ytosiz = seg.u32(), seg.u32()
+	csiz := int(seg.u16())
+
+	if err := seg.need(3 * csiz); err != nil {
+		return err
+	}
+
+	if csiz != 1 {
+		return fmt.Errorf("%w: %d components", ErrUnsupported, csiz)
+	}
+
+	if s.xsiz <= s.xosiz || s.ysiz <= s.yosiz || s.xtsiz == 0 || s.ytsiz == 0 ||
+		s.xtosiz > s.xosiz || s.ytosiz > s.yosiz ||
+		s.xtosiz+s.xtsiz <= s.xosiz || s.ytosiz+s.ytsiz <= s.yosiz {
+		return fmt.Errorf("%w: invalid image and tile size", ErrMalformed)
+	}
+
+	for i := 0; i < csiz; i++ {
+		ssiz := seg.u8()
+		c := component{
+			precision: int(ssiz&0x7f) + 1,
+			signed:    ssiz&0x80 != 0,
+			dx:        int(seg.u8()),
+			dy:        int(seg.u8()),
+		}
+
+		if c.precision > 38 {
+			return fmt.Errorf("%w: component precision %d", ErrMalformed, c.precision)
+		}
+
+		s.comps = append(s.comps, c)
+	}
+
+	return nil
+}
+
+func (cs *codestream) parseParam(p *tileParams, m uint16, seg *byteReader) error {
+	p.seen = true
+
+	switch m {
+	case markerCOD:
+		if err := seg.need(5); err != nil {
+			return err
+		}
+
+		scod := seg.u8()
+		p.cod = &codingStyle{
+			sop:         scod&0x02 != 0,
+			eph:         scod&0x04 != 0,
+			progression: seg.u8(),
+			layers:      int(seg.u16()),
+		}
+		_ = seg.u8() // multiple component transformation
+
+		if p.cod.progression > progressionCPRL || p.cod.layers == 0 {
+			return fmt.Errorf("%w: invalid COD marker segment", ErrMalformed)
+		}
+
+		spc, err := parseComponentStyle(seg, scod&0x01 != 0)
+		if err != nil {
+			return err
+		}
+
+		p.spc = spc
+	case markerCOC:
+		if err := seg.need(2); err != nil {
+			return err
+		}
+
+		if c := seg.u8(); c != 0 {
+			return nil
+		}
+
+		scoc := seg.u8()
+
+		spc, err := parseComponentStyle(seg, scoc&0x01 != 0)
+		if err != nil {
+			return err
+		}
+
+		p.coc = spc
+	case markerQCD:
+		q, err := parseQuantization(seg)
+		if err != nil {
+			return err
+		}
+
+		p.qcd = q
+	case markerQCC:
+		if err := seg.need(1); err != nil {
+			return err
+		}
+
+		if c := seg.u8(); c != 0 {
+			return nil
+		}
+
+		q, err := parseQuantization(seg)
+		if err != nil {
+			return err
+		}
+
+		p.qcc = q
+	}
+
+	return nil
+}
+
+func parseComponentStyle(seg *byteReader, precincts bool) (*componentStyle, error) {
+	if err := seg.need(5); err != nil {
+		return nil, err
+	}
+
+	s := &componentStyle{
+		levels:     int(seg.u8()),
+		xcb:        int(seg.u8()&0x0f) + 2,
+		ycb:        int(seg.u8()&0x0f) + 2,
+		cblkStyle:  seg.u8(),
+		reversible: seg.u8() == 1,
+	}
+
+	if s.levels > 32 || s.xcb > 10 || s.ycb > 10 || s.xcb+s.ycb > 12 {
+		return nil, fmt.Errorf("%w: invalid coding style", ErrMalformed)
+	}
+
+	s.ppx = make([]int, s.levels+1)
+	s.ppy = make([]int, s.levels+1)
+
+	for r := 0; r <= s.levels; r++ {
+		s.ppx[r], s.ppy[r] = 15, 15
+
+		if precincts {
+			if err := seg.need(1); err != nil {
+				return nil, err
+			}
+
+			b := seg.u8()
+			s.ppx[r], s.ppy[r] = int(b&0x0f), int(b>>4)
+
+			if r > 0 && (s.ppx[r] == 0 || s.ppy[r] == 0) {
+				return nil, fmt.Errorf("%w: precinct size exponent 0 at resolution %d", ErrMalformed, r)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+func parseQuantization(seg *byteReader) (*quantization, error) {
+	if err := seg.need(1); err != nil {
+		return nil, err
+	}
+
+	sq := seg.u8()
+	q := &quantization{
+		style: sq & 0x1f,
+		guard: int(sq >> 5),
+	}
+
+	switch q.style {
+	case quantNone:
+		for seg.remaining() > 0 {
+			q.exponents = append(q.exponents, int(seg.u8()>>3))
+			q.mantissas = append(q.mantissas, 0)
+		}
+	case quantDerived, quantExpounded:
+		for seg.remaining() >= 2 {
+			v := seg.u16()
+			q.exponents = append(q.exponents, int(v>>11))
+			q.mantissas = append(q.mantissas, int(v&0x7ff))
+		}
+	default:
+		return nil, fmt.Errorf("%w: quantization style %d", ErrMalformed, q.style)
+	}
+
+	if len(q.exponents) == 0 {
+		return nil, fmt.Errorf("%w: empty quantization marker segment", ErrMalformed)
+	}
+
+	return q, nil
+}
+
+func (cs *codestream) numTilesX() int {
+	return ceilDiv(int(cs.siz.xsiz-cs.siz.xtosiz), int(cs.siz.xtsiz))
+}
+
+func (cs *codestream) numTilesY() int {
+	return ceilDiv(int(cs.siz.ysiz-cs.siz.ytosiz), int(cs.siz.ytsiz))
+}
+
+// styles returns the coding parameters in effect for a tile (T.800 A.6.1).
+func (cs *codestream) styles(t *tile) (*codingStyle, *componentStyle, *quantization) {
+	cod := cs.main.cod
+	if t.params.cod != nil {
+		cod = t.params.cod
+	}
+
+	var spc *componentStyle
+	for _, s := range []*componentStyle{t.params.coc, t.params.spc, cs.main.coc, cs.main.spc} {
+		if s != nil {
+			spc = s
+			break
+		}
+	}
+
+	var q *quantization
+	for _, s := range []*quantization{t.params.qcc, t.params.qcd, cs.main.qcc, cs.main.qcd} {
+		if s != nil {
+			q = s
+			break
+		}
+	}
+
+	return cod, spc, q
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
+
+// ceilDivPow2 returns ceil(a / 2^n) for a >= 0.
+func ceilDivPow2(a, n int) int {
+	return (a + (1 << n) - 1) >> n
+}
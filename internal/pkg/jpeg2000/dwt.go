@@ -0,0 +1,105 @@
+package jpeg2000
+
+// Lifting parameters of the irreversible 9/7 filter (T.800 Table F.4)
+const (
+	alpha = -1.586134342059924
+	beta  = -0.052980118572961
+	gamma = 0.882911075530934
+	delta = 0.443506852043971
+	kappa = 1.230174104914001
+)
+
+// transform2D applies the 2D_SR procedure of T.800 F.3.2 to the interleaved samples of
+// a resolution: all rows first, then all columns.
+func transform2D[T int64 | float64](a []T, rs *resolution, f func(s []T, i0 int)) {
+	w, h := rs.x1-rs.x0, rs.y1-rs.y0
+
+	for y := 0; y < h; y++ {
+		f(a[y*w:(y+1)*w], rs.x0)
+	}
+
+	col := make([]T, h)
+
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			col[y] = a[y*w+x]
+		}
+
+		f(col, rs.y0)
+
+		for y := 0; y < h; y++ {
+			a[y*w+x] = col[y]
+		}
+	}
+}
+
+// mirror extends a signal of n samples symmetrically (T.800 F.3.7).
+func mirror(i, n int) int {
+	for i < 0 || i >= n {
+		if i < 0 {
+			i = -i
+		}
+
+		if i >= n {
+			i = 2*(n-1) - i
+		}
+	}
+
+	return i
+}
+
+// inverse53 is the 1D_SR procedure with the reversible 5/3 filter, s holds the samples
+// with coordinates i0 to i0+len(s)-1.
+func inverse53(s []int64, i0 int) {
+	n := len(s)
+
+	if n == 1 {
+		if i0&1 == 1 {
+			s[0] /= 2
+		}
+
+		return
+	}
+
+	for i := (i0 & 1); i < n; i += 2 {
+		s[i] -= (s[mirror(i-1, n)] + s[mirror(i+1, n)] + 2) >> 2
+	}
+
+	for i := 1 - (i0 & 1); i < n; i += 2 {
+		s[i] += (s[mirror(i-1, n)] + s[mirror(i+1, n)]) >> 1
+	}
+}
+
+// inverse97 is the 1D_SR procedure with the irreversible 9/7 filter.
+func inverse97(s []float64, i0 int) {
+	n := len(s)
+
+	if n == 1 {
+		if i0&1 == 1 {
+			s[0] /= 2
+		}
+
+		return
+	}
+
+	even, odd := i0&1, 1-i0&1
+
+	lift := func(start int, c float64) {
+		for i := start; i < n; i += 2 {
+			s[i] -= c * (s[mirror(i-1, n)] + s[mirror(i+1, n)])
+		}
+	}
+
+	for i := even; i < n; i += 2 {
+		s[i] *= kappa
+	}
+
+	for i := odd; i < n; i += 2 {
+		s[i] /= kappa
+	}
+
+	lift(even, delta)
+	lift(odd, gamma)
+	lift(even, beta)
+	lift(odd, alpha)
+}
@@ -0,0 +1,57 @@
+// Package jpeg2000 decodes JPEG 2000 (ITU-T T.800 | ISO/IEC 15444-1) code-streams.
+//
+// It implements the subset used by GRIB2 data representation template 5.40: a raw code-stream
+// (no JP2 box structure) holding a single component of 1 to 38 bits, reversible 5/3 or
+// irreversible 9/7 wavelets, any number of tiles, layers and precincts, all code-block styles
+// and all progression orders without POC changes. Packed packet headers (PPM/PPT) and
+// region of interest coding (RGN) are not supported.
+package jpeg2000
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrMalformed   = errors.New("jpeg2000: malformed code-stream")
+	ErrUnsupported = errors.New("jpeg2000: unsupported feature")
+)
+
+// Image is a decoded single component image.
+type Image struct {
+	Width     int
+	Height    int
+	Precision int  // bit depth of the samples
+	Signed    bool // samples are signed
+	Data      []int64
+}
+
+// Decode decodes a JPEG 2000 code-stream.
+func Decode(data []byte) (*Image, error) {
+	cs, err := parseCodestream(data)
+	if err != nil {
+		return nil, err
+	}
+
+	comp := cs.siz.comps[0]
+	img := &Image{
+		Width:     int(cs.siz.xsiz - cs.siz.xosiz),
+		Height:    int(cs.siz.ysiz - cs.siz.yosiz),
+		Precision: comp.precision,
+		Signed:    comp.signed,
+	}
+
+	if comp.dx != 1 || comp.dy != 1 {
+		return nil, fmt.Errorf("%w: component sub-sampling %dx%d", ErrUnsupported, comp.dx, comp.dy)
+	}
+
+	img.Data = make([]int64, img.Width*img.Height)
+
+	for _, t := range cs.tiles {
+		if err := cs.decodeTile(t, img); err != nil {
+			return nil, fmt.Errorf("tile %d: %w", t.index, err)
+		}
+	}
+
+	return img, nil
+}
@@ -0,0 +1,96 @@
+package jpeg2000_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/scorix/grib-go/internal/pkg/jpeg2000"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func marker(code uint16, params ...any) []byte {
+	var body bytes.Buffer
+
+	for _, p := range params {
+		_ = binary.Write(&body, binary.BigEndian, p)
+	}
+
+	var buf bytes.Buffer
+
+	_ = binary.Write(&buf, binary.BigEndian, code)
+	_ = binary.Write(&buf, binary.BigEndian, uint16(2+body.Len()))
+	buf.Write(body.Bytes())
+
+	return buf.Bytes()
+}
+
+// emptyCodestream builds a code-stream of 2x2 tiles with one decomposition level whose packets
+// are all empty, every sample decodes to the DC level shift.
+func emptyCodestream(width, height uint32, precision uint8) []byte {
+	var buf bytes.Buffer
+
+	buf.Write([]byte{0xff, 0x4f})
+	buf.Write(marker(0xff51,
+		uint16(0), width, height, uint32(0), uint32(0),
+		(width+1)/2, (height+1)/2, uint32(0), uint32(0),
+		uint16(1), precision-1, uint8(1), uint8(1),
+	))
+	buf.Write(marker(0xff52, uint8(0), uint8(0), uint16(1), uint8(0), uint8(1), uint8(4), uint8(4), uint8(0), uint8(1)))
+	buf.Write(marker(0xff5c, uint8(2<<5), []uint8{(precision) << 3, (precision + 1) << 3, (precision + 1) << 3, (precision + 2) << 3}))
+
+	for i := uint16(0); i < 4; i++ {
+		packets := []byte{0x00, 0x00}
+
+		buf.Write(marker(0xff90, i, uint32(12+2+len(packets)), uint8(0), uint8(1)))
+		buf.Write([]byte{0xff, 0x93})
+		buf.Write(packets)
+	}
+
+	buf.Write([]byte{0xff, 0xd9})
+
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	img, err := jpeg2000.Decode(emptyCodestream(5, 3, 12))
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, img.Width)
+	assert.Equal(t, 3, img.Height)
+	assert.Equal(t, 12, img.Precision)
+	assert.False(t, img.Signed)
+	require.Len(t, img.Data, 15)
+
+	for i, v := range img.Data {
+		assert.Equal(t, int64(1<<11), v, "sample %d", i)
+	}
+}
+
+func TestDecode_Malformed(t *testing.T) {
+	t.Parallel()
+
+	data := emptyCodestream(5, 3, 8)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "missing SOC", data: data[2:]},
+		{name: "truncated main header", data: data[:20]},
+		{name: "truncated tile-part", data: data[:len(data)-6]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := jpeg2000.Decode(tt.data)
+			assert.ErrorIs(t, err, jpeg2000.ErrMalformed)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package jpeg2000
+
+// qeEntry is a row of the MQ-coder probability estimation table (T.800 Table C.2).
+type qeEntry struct {
+	qe   uint32
+	nmps uint8
+	nlps uint8
+	swap bool
+}
+
+var qeTable = [47]qeEntry{
+	{0x5601, 1, 1, true},
+	{0x3401, 2, 6, false},
+	{0x1801, 3, 9, false},
+	{0x0ac1, 4, 12, false},
+	{0x0521, 5, 29, false},
+	{0x0221, 38, 33, false},
+	{0x5601, 7, 6, true},
+	{0x5401, 8, 14, false},
+	{0x4801, 9, 14, false},
+	{0x3801, 10, 14, false},
+	{0x3001, 11, 17, false},
+	{0x2401, 12, 18, false},
+	{0x1c01, 13, 20, false},
+	{0x1601, 29, 21, false},
+	{0x5601, 15, 14, true},
+	{0x5401, 16, 14, false},
+	{0x5101, 17, 15, false},
+	{0x4801, 18, 16, false},
+	{0x3801, 19, 17, false},
+	{0x3401, 20, 18, false},
+	{0x3001, 21, 19, false},
+	{0x2801, 22, 19, false},
+	{0x2401, 23, 20, false},
+	{0x2201, 24, 21, false},
+	{0x1c01, 25, 22, false},
+	{0x1801, 26, 23, false},
+	{0x1601, 27, 24, false},
+	{0x1401, 28, 25, false},
+	{0x1201, 29, 26, false},
+	{0x1101, 30, 27, false},
+	{0x0ac1, 31, 28, false},
+	{0x09c1, 32, 29, false},
+	{0x08a1, 33, 30, false},
+	{0x0521, 34, 31, false},
+	{0x0441, 35, 32, false},
+	{0x02a1, 36, 33, false},
+	{0x0221, 37, 34, false},
+	{0x0141, 38, 35, false},
+	{0x0111, 39, 36, false},
+	{0x0085, 40, 37, false},
+	{0x0049, 41, 38, false},
+	{0x0025, 42, 39, false},
+	{0x0015, 43, 40, false},
+	{0x0009, 44, 41, false},
+	{0x0005, 45, 42, false},
+	{0x0001, 45, 43, false},
+	{0x5601, 46, 46, false},
+}
+
+// context is the adaptive state of an MQ-coder context.
+type context struct {
+	index uint8
+	mps   uint8
+}
+
+// mqDecoder is the MQ arithmetic decoder of T.800 Annex C.
+type mqDecoder struct {
+	data []byte
+	pos  int
+	a    uint32
+	c    uint32
+	ct   int
+}
+
+// byteAt returns the byte at position i, data beyond the end of the segment reads as 0xff.
+func (d *mqDecoder) byteAt(i int) byte {
+	if i < len(d.data) {
+		return d.data[i]
+	}
+
+	return 0xff
+}
+
+func (d *mqDecoder) init(data []byte) {
+	d.data = data
+	d.pos = 0
+	d.c = uint32(d.byteAt(0)) << 16
+	d.byteIn()
+	d.c <<= 7
+	d.ct -= 7
+	d.a = 0x8000
+}
+
+func (d *mqDecoder) byteIn() {
+	if d.byteAt(d.pos) == 0xff {
+		if d.byteAt(d.pos+1) > 0x8f {
+			d.c += 0xff00
+			d.ct = 8
+		} else {
+			d.pos++
+			d.c += uint32(d.byteAt(d.pos)) << 9
+			d.ct = 7
+		}
+	} else {
+		d.pos++
+		d.c += uint32(d.byteAt(d.pos)) << 8
+		d.ct = 8
+	}
+}
+
+func (d *mqDecoder) renorm() {
+	for {
+		if d.ct == 0 {
+			d.byteIn()
+		}
+
+		d.a <<= 1
+		d.c <<= 1
+		d.ct--
+
+		if d.a&0x8000 != 0 {
+			return
+		}
+	}
+}
+
+func (d *mqDecoder) decode(cx *context) int {
+	e := &qeTable[cx.index]
+	d.a -= e.qe
+
+	var bit uint8
+
+	if d.c>>16 < e.qe {
+		// LPS exchange
+		if d.a < e.qe {
+			bit = cx.mps
+			cx.index = e.nmps
+		} else {
+			bit = 1 - cx.mps
+			if e.swap {
+				cx.mps = 1 - cx.mps
+			}
+			cx.index = e.nlps
+		}
+
+		d.a = e.qe
+	} else {
+		d.c -= e.qe << 16
+
+		if d.a&0x8000 != 0 {
+			return int(cx.mps)
+		}
+
+		// MPS exchange
+		if d.a < e.qe {
+			bit = 1 - cx.mps
+			if e.swap {
+				cx.mps = 1 - cx.mps
+			}
+			cx.index = e.nlps
+		} else {
+			bit = cx.mps
+			cx.index = e.nmps
+		}
+	}
+
+	d.renorm()
+
+	return int(bit)
+}
+
+// rawDecoder reads the raw (bypassed) coding passes of a code-block.
+type rawDecoder struct {
+	data []byte
+	pos  int
+	c    byte
+	ct   int
+}
+
+func (d *rawDecoder) init(data []byte) {
+	*d = rawDecoder{data: data}
+}
+
+func (d *rawDecoder) decode() int {
+	if d.ct == 0 {
+		next := byte(0xff)
+		if d.pos < len(d.data) {
+			next = d.data[d.pos]
+		}
+
+		if d.c == 0xff {
+			if next > 0x8f {
+				d.c = 0xff
+				d.ct = 8
+			} else {
+				d.c = next
+				d.pos++
+				d.ct = 7
+			}
+		} else {
+			d.c = next
+			d.pos++
+			d.ct = 8
+		}
+	}
+
+	d.ct--
+
+	return int(d.c>>d.ct) & 1
+}
@@ -0,0 +1,397 @@
+package jpeg2000
+
+import "fmt"
+
+// Coefficient states of the code-block decoder
+const (
+	flagSig uint8 = 1 << iota
+	flagNeg
+	flagVisited
+	flagRefined
+)
+
+// Context labels (T.800 D.3)
+const (
+	ctxSC      = 9
+	ctxMR      = 14
+	ctxRL      = 17
+	ctxUniform = 18
+	numCtx     = 19
+)
+
+// Coding pass types
+const (
+	passSigProp = iota
+	passMagRef
+	passCleanup
+)
+
+// t1Decoder decodes the coding passes of code-blocks (T.800 Annex D).
+type t1Decoder struct {
+	w, h   int
+	stride int
+	orient int
+	vsc    bool
+
+	flags []uint8
+	mag   []int64
+	last  []int8
+
+	ctx [numCtx]context
+	mq  mqDecoder
+	raw rawDecoder
+}
+
+func (d *t1Decoder) resetContexts() {
+	d.ctx = [numCtx]context{}
+	d.ctx[0].index = 4
+	d.ctx[ctxRL].index = 3
+	d.ctx[ctxUniform].index = 46
+}
+
+func passType(i int) int {
+	if i == 0 {
+		return passCleanup
+	}
+
+	return (i - 1) % 3
+}
+
+// decode decodes the coding passes of a code-block and writes the coefficients, with an extra
+// fractional bit, to the subband.
+func (d *t1Decoder) decode(cb *codeBlock, b *band, style uint8) error {
+	numPasses := cb.numPasses()
+	if numPasses == 0 {
+		return nil
+	}
+
+	top := b.numBitplanes - 1 - cb.zeroBitplanes
+	if top < 0 {
+		return fmt.Errorf("%w: %d zero bit-planes of %d", ErrMalformed, cb.zeroBitplanes, b.numBitplanes)
+	}
+
+	d.w, d.h = cb.x1-cb.x0, cb.y1-cb.y0
+	d.stride = d.w + 2
+	d.orient = b.orient
+	d.vsc = style&cblkVSC != 0
+
+	n := d.stride * (d.h + 2)
+	d.flags = resize(d.flags, n)
+	d.mag = resize(d.mag, n)
+	d.last = resize(d.last, n)
+	d.resetContexts()
+
+	pass := 0
+
+segments:
+	for _, s := range cb.segments {
+		raw := style&cblkBypass != 0 && pass >= 10 && passType(pass) != passCleanup
+		if raw {
+			d.raw.init(s.data)
+		} else {
+			d.mq.init(s.data)
+		}
+
+		for k := 0; k < s.numPasses; k++ {
+			bp := top - (pass+2)/3
+			if bp < 0 {
+				break segments
+			}
+
+			switch passType(pass) {
+			case passSigProp:
+				d.sigProp(bp, raw)
+			case passMagRef:
+				d.magRef(bp, raw)
+			case passCleanup:
+				d.cleanup(bp)
+
+				if style&cblkSegSym != 0 {
+					for i := 0; i < 4; i++ {
+						d.mq.decode(&d.ctx[ctxUniform])
+					}
+				}
+			}
+
+			if style&cblkReset != 0 {
+				d.resetContexts()
+			}
+
+			pass++
+		}
+	}
+
+	bw := b.width()
+
+	for y := 0; y < d.h; y++ {
+		for x := 0; x < d.w; x++ {
+			i := d.index(x, y)
+			if d.mag[i] == 0 {
+				continue
+			}
+
+			v := 2*d.mag[i] + int64(1)<<d.last[i]
+			if d.flags[i]&flagNeg != 0 {
+				v = -v
+			}
+
+			b.coeffs[(cb.y0-b.y0+y)*bw+cb.x0-b.x0+x] = v
+		}
+	}
+
+	return nil
+}
+
+func resize[T any](s []T, n int) []T {
+	if cap(s) < n {
+		return make([]T, n)
+	}
+
+	s = s[:n]
+	clear(s)
+
+	return s
+}
+
+func (d *t1Decoder) index(x, y int) int {
+	return (y+1)*d.stride + x + 1
+}
+
+// neighbours returns the significance of the horizontal, vertical and diagonal neighbours.
+func (d *t1Decoder) neighbours(x, y int) (h, v, diag int) {
+	i := d.index(x, y)
+	sig := func(j int) int {
+		return int(d.flags[j] & flagSig)
+	}
+
+	h = sig(i-1) + sig(i+1)
+	v = sig(i - d.stride)
+	diag = sig(i-d.stride-1) + sig(i-d.stride+1)
+
+	// in vertically causal mode, the next stripe is considered insignificant
+	if !d.vsc || y%4 != 3 {
+		v += sig(i + d.stride)
+		diag += sig(i+d.stride-1) + sig(i+d.stride+1)
+	}
+
+	return h, v, diag
+}
+
+// zeroCodingContext returns the context of the significance coding (T.800 Table D.1).
+func (d *t1Decoder) zeroCodingContext(x, y int) int {
+	h, v, diag := d.neighbours(x, y)
+
+	switch d.orient {
+	case orientHH:
+		hv := h + v
+
+		switch {
+		case diag >= 3:
+			return 8
+		case diag == 2:
+			if hv >= 1 {
+				return 7
+			}
+			return 6
+		case diag == 1:
+			return 3 + min(hv, 2)
+		default:
+			return min(hv, 2)
+		}
+	case orientLH:
+		h, v = v, h
+	}
+
+	switch h {
+	case 2:
+		return 8
+	case 1:
+		switch {
+		case v >= 1:
+			return 7
+		case diag >= 1:
+			return 6
+		default:
+			return 5
+		}
+	default:
+		switch {
+		case v == 2:
+			return 4
+		case v == 1:
+			return 3
+		default:
+			return min(diag, 2)
+		}
+	}
+}
+
+// signContext returns the context of the sign coding and the bit to XOR the decoded bit with
+// (T.800 Table D.3).
+func (d *t1Decoder) signContext(x, y int) (int, int) {
+	i := d.index(x, y)
+	sign := func(j int) int {
+		switch {
+		case d.flags[j]&flagSig == 0:
+			return 0
+		case d.flags[j]&flagNeg != 0:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	h := max(-1, min(1, sign(i-1)+sign(i+1)))
+
+	v := sign(i - d.stride)
+	if !d.vsc || y%4 != 3 {
+		v += sign(i + d.stride)
+	}
+
+	v = max(-1, min(1, v))
+
+	xor := 0
+	if h < 0 || (h == 0 && v < 0) {
+		h, v, xor = -h, -v, 1
+	}
+
+	if h == 0 {
+		return ctxSC + v, xor
+	}
+
+	return ctxSC + 3 + v, xor
+}
+
+// setSignificant decodes the sign of a coefficient that becomes significant in bit-plane bp.
+func (d *t1Decoder) setSignificant(x, y, bp int, raw bool) {
+	i := d.index(x, y)
+
+	var neg int
+	if raw {
+		neg = d.raw.decode()
+	} else {
+		cx, xor := d.signContext(x, y)
+		neg = d.mq.decode(&d.ctx[cx]) ^ xor
+	}
+
+	d.flags[i] |= flagSig
+	if neg == 1 {
+		d.flags[i] |= flagNeg
+	}
+
+	d.mag[i] = int64(1) << bp
+	d.last[i] = int8(bp)
+}
+
+// stripes calls f for each coefficient in the scan order of T.800 D.1.
+func (d *t1Decoder) stripes(f func(x, y int)) {
+	for y0 := 0; y0 < d.h; y0 += 4 {
+		for x := 0; x < d.w; x++ {
+			for y := y0; y < min(y0+4, d.h); y++ {
+				f(x, y)
+			}
+		}
+	}
+}
+
+func (d *t1Decoder) sigProp(bp int, raw bool) {
+	d.stripes(func(x, y int) {
+		i := d.index(x, y)
+		if d.flags[i]&flagSig != 0 {
+			return
+		}
+
+		cx := d.zeroCodingContext(x, y)
+		if cx == 0 {
+			return
+		}
+
+		var bit int
+		if raw {
+			bit = d.raw.decode()
+		} else {
+			bit = d.mq.decode(&d.ctx[cx])
+		}
+
+		if bit == 1 {
+			d.setSignificant(x, y, bp, raw)
+		}
+
+		d.flags[i] |= flagVisited
+	})
+}
+
+func (d *t1Decoder) magRef(bp int, raw bool) {
+	d.stripes(func(x, y int) {
+		i := d.index(x, y)
+		if d.flags[i]&(flagSig|flagVisited) != flagSig {
+			return
+		}
+
+		var bit int
+		if raw {
+			bit = d.raw.decode()
+		} else {
+			cx := ctxMR + 2
+			if d.flags[i]&flagRefined == 0 {
+				if h, v, diag := d.neighbours(x, y); h+v+diag > 0 {
+					cx = ctxMR + 1
+				} else {
+					cx = ctxMR
+				}
+			}
+
+			bit = d.mq.decode(&d.ctx[cx])
+		}
+
+		d.mag[i] |= int64(bit) << bp
+		d.last[i] = int8(bp)
+		d.flags[i] |= flagRefined
+	})
+}
+
+func (d *t1Decoder) cleanup(bp int) {
+	for y0 := 0; y0 < d.h; y0 += 4 {
+		for x := 0; x < d.w; x++ {
+			y := y0
+
+			if y0+4 <= d.h && d.runLength(x, y0) {
+				if d.mq.decode(&d.ctx[ctxRL]) == 0 {
+					continue
+				}
+
+				u := &d.ctx[ctxUniform]
+				y += d.mq.decode(u)<<1 | d.mq.decode(u)
+				d.setSignificant(x, y, bp, false)
+				y++
+			}
+
+			for ; y < min(y0+4, d.h); y++ {
+				i := d.index(x, y)
+				if d.flags[i]&(flagSig|flagVisited) != 0 {
+					continue
+				}
+
+				if d.mq.decode(&d.ctx[d.zeroCodingContext(x, y)]) == 1 {
+					d.setSignificant(x, y, bp, false)
+				}
+			}
+		}
+	}
+
+	for i := range d.flags {
+		d.flags[i] &^= flagVisited
+	}
+}
+
+// runLength reports whether the four coefficients of a stripe column are decoded in run-length
+// mode: none is significant, visited or has a significant neighbour.
+func (d *t1Decoder) runLength(x, y0 int) bool {
+	for y := y0; y < y0+4; y++ {
+		if d.flags[d.index(x, y)]&(flagSig|flagVisited) != 0 || d.zeroCodingContext(x, y) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
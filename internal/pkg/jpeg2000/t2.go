@@ -0,0 +1,403 @@
+package jpeg2000
+
+import (
+	"fmt"
+	"sort"
+)
+
+// packetReader reads the bits of a packet header (T.800 B.10.1), a zero bit is stuffed after
+// every 0xff byte.
+type packetReader struct {
+	data []byte
+	pos  int
+	buf  byte
+	ct   int
+}
+
+func (r *packetReader) bit() (int, error) {
+	if r.ct == 0 {
+		if r.pos >= len(r.data) {
+			return 0, fmt.Errorf("%w: packet header exceeds tile data", ErrMalformed)
+		}
+
+		if r.buf == 0xff {
+			r.ct = 7
+		} else {
+			r.ct = 8
+		}
+
+		r.buf = r.data[r.pos]
+		r.pos++
+	}
+
+	r.ct--
+
+	return int(r.buf>>r.ct) & 1, nil
+}
+
+func (r *packetReader) bits(n int) (int, error) {
+	v := 0
+
+	for i := 0; i < n; i++ {
+		b, err := r.bit()
+		if err != nil {
+			return 0, err
+		}
+
+		v = v<<1 | b
+	}
+
+	return v, nil
+}
+
+// align skips the remaining bits of the packet header.
+func (r *packetReader) align() {
+	if r.buf == 0xff {
+		r.pos++
+	}
+
+	r.buf, r.ct = 0, 0
+}
+
+// numPasses decodes the number of new coding passes (T.800 Table B.4).
+func (r *packetReader) numPasses() (int, error) {
+	codes := []struct{ bits, escape, offset int }{
+		{1, 1, 1},
+		{1, 1, 2},
+		{2, 3, 3},
+		{5, 31, 6},
+		{7, 1 << 7, 37},
+	}
+
+	for _, c := range codes {
+		v, err := r.bits(c.bits)
+		if err != nil {
+			return 0, err
+		}
+
+		if v != c.escape {
+			return c.offset + v, nil
+		}
+	}
+
+	panic("unreachable")
+}
+
+// codeBlockSegment is a codeword segment of a code-block, terminated coding passes whose data
+// is decoded by a single MQ or raw decoder.
+type codeBlockSegment struct {
+	data      []byte
+	numPasses int
+	maxPasses int
+
+	// contribution of the current packet
+	newPasses int
+	newLength int
+}
+
+type codeBlock struct {
+	x0, y0, x1, y1 int
+
+	included      bool
+	zeroBitplanes int
+	lblock        int
+	segments      []*codeBlockSegment
+	contributes   bool
+}
+
+func (cb *codeBlock) numPasses() int {
+	n := 0
+	for _, s := range cb.segments {
+		n += s.numPasses
+	}
+
+	return n
+}
+
+// newSegment appends a codeword segment, the maximum number of passes of a segment depends on
+// the code-block style (T.800 D.4.1).
+func (cb *codeBlock) newSegment(style uint8) *codeBlockSegment {
+	s := &codeBlockSegment{maxPasses: 109}
+
+	switch {
+	case style&cblkTermAll != 0:
+		s.maxPasses = 1
+	case style&cblkBypass != 0:
+		if len(cb.segments) == 0 {
+			s.maxPasses = 10
+		} else if prev := cb.segments[len(cb.segments)-1].maxPasses; prev == 1 || prev == 10 {
+			s.maxPasses = 2
+		} else {
+			s.maxPasses = 1
+		}
+	}
+
+	cb.segments = append(cb.segments, s)
+
+	return s
+}
+
+type precinctBand struct {
+	numW, numH int
+	blocks     []*codeBlock
+	inclusion  *tagTree
+	zeroBP     *tagTree
+}
+
+type precinct struct {
+	bands []*precinctBand
+}
+
+// decodePacket reads the packet of a precinct in a quality layer and appends the contributions
+// to the code-blocks, it returns the position of the next packet.
+func (td *tileDecoder) decodePacket(pos int, p *precinct, layer int) (int, error) {
+	data := td.tile.data
+
+	if td.cod.sop && pos+6 <= len(data) && data[pos] == 0xff && data[pos+1] == byte(markerSOP&0xff) {
+		pos += 6
+	}
+
+	r := &packetReader{data: data, pos: pos}
+
+	present, err := r.bit()
+	if err != nil {
+		return 0, err
+	}
+
+	var contributions []*codeBlock
+
+	if present == 1 {
+		for _, pb := range p.bands {
+			for i, cb := range pb.blocks {
+				cb.contributes = false
+
+				var included bool
+
+				if !cb.included {
+					included, err = pb.inclusion.decode(r, i, layer+1)
+				} else {
+					var bit int
+					bit, err = r.bit()
+					included = bit == 1
+				}
+
+				if err != nil {
+					return 0, err
+				}
+
+				if !included {
+					continue
+				}
+
+				if !cb.included {
+					zbp, err := pb.zeroBP.value(r, i)
+					if err != nil {
+						return 0, err
+					}
+
+					cb.included = true
+					cb.zeroBitplanes = zbp
+				}
+
+				n, err := r.numPasses()
+				if err != nil {
+					return 0, err
+				}
+
+				for {
+					bit, err := r.bit()
+					if err != nil {
+						return 0, err
+					}
+
+					if bit == 0 {
+						break
+					}
+
+					cb.lblock++
+				}
+
+				if err := td.readLengths(r, cb, n); err != nil {
+					return 0, err
+				}
+
+				cb.contributes = true
+				contributions = append(contributions, cb)
+			}
+		}
+	}
+
+	r.align()
+	pos = r.pos
+
+	if td.cod.eph && pos+2 <= len(data) && data[pos] == 0xff && data[pos+1] == byte(markerEPH&0xff) {
+		pos += 2
+	}
+
+	for _, cb := range contributions {
+		for _, s := range cb.segments {
+			if s.newPasses == 0 {
+				continue
+			}
+
+			end := pos + s.newLength
+			if end > len(data) {
+				return 0, fmt.Errorf("%w: code-block data exceeds tile data", ErrMalformed)
+			}
+
+			s.data = append(s.data, data[pos:end]...)
+			s.numPasses += s.newPasses
+			s.newPasses, s.newLength = 0, 0
+			pos = end
+		}
+	}
+
+	return pos, nil
+}
+
+// readLengths distributes the new coding passes to codeword segments and reads their lengths.
+func (td *tileDecoder) readLengths(r *packetReader, cb *codeBlock, n int) error {
+	var s *codeBlockSegment
+	if len(cb.segments) > 0 {
+		s = cb.segments[len(cb.segments)-1]
+	}
+
+	for n > 0 {
+		if s == nil || s.numPasses+s.newPasses == s.maxPasses {
+			s = cb.newSegment(td.spc.cblkStyle)
+		}
+
+		take := min(n, s.maxPasses-s.numPasses)
+
+		l, err := r.bits(cb.lblock + floorLog2(take))
+		if err != nil {
+			return err
+		}
+
+		s.newPasses, s.newLength = take, l
+		n -= take
+	}
+
+	return nil
+}
+
+func floorLog2(n int) int {
+	l := 0
+	for n > 1 {
+		n >>= 1
+		l++
+	}
+
+	return l
+}
+
+type packetID struct {
+	res      int
+	precinct int
+	x, y     int // position of the precinct on the reference grid
+}
+
+// packets lists the packets of the tile in progression order (T.800 B.12).
+func (td *tileDecoder) packets() []packetID {
+	var ids []packetID
+
+	nl := td.spc.levels
+
+	for r, rs := range td.res {
+		for py := 0; py < rs.numPrecH; py++ {
+			for px := 0; px < rs.numPrecW; px++ {
+				shift := nl - r
+				ids = append(ids, packetID{
+					res:      r,
+					precinct: py*rs.numPrecW + px,
+					x:        max(td.x0, ((rs.x0>>rs.ppx)+px)<<(rs.ppx+shift)),
+					y:        max(td.y0, ((rs.y0>>rs.ppy)+py)<<(rs.ppy+shift)),
+				})
+			}
+		}
+	}
+
+	switch td.cod.progression {
+	case progressionRPCL:
+		sort.SliceStable(ids, func(i, j int) bool {
+			a, b := ids[i], ids[j]
+			if a.res != b.res {
+				return a.res < b.res
+			}
+
+			if a.y != b.y {
+				return a.y < b.y
+			}
+
+			return a.x < b.x
+		})
+	case progressionPCRL, progressionCPRL:
+		sort.SliceStable(ids, func(i, j int) bool {
+			a, b := ids[i], ids[j]
+			if a.y != b.y {
+				return a.y < b.y
+			}
+
+			if a.x != b.x {
+				return a.x < b.x
+			}
+
+			return a.res < b.res
+		})
+	}
+
+	return ids
+}
+
+// decodePackets reads all packets of the tile.
+func (td *tileDecoder) decodePackets() error {
+	ids := td.packets()
+	layers := td.cod.layers
+	pos := 0
+
+	visit := func(id packetID, l int) error {
+		var err error
+
+		pos, err = td.decodePacket(pos, td.res[id.res].precincts[id.precinct], l)
+		if err != nil {
+			return fmt.Errorf("packet of layer %d, resolution %d, precinct %d: %w", l, id.res, id.precinct, err)
+		}
+
+		return nil
+	}
+
+	switch td.cod.progression {
+	case progressionLRCP:
+		for l := 0; l < layers; l++ {
+			for _, id := range ids {
+				if err := visit(id, l); err != nil {
+					return err
+				}
+			}
+		}
+	case progressionRLCP:
+		for r := range td.res {
+			for l := 0; l < layers; l++ {
+				for _, id := range ids {
+					if id.res != r {
+						continue
+					}
+
+					if err := visit(id, l); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	default:
+		for _, id := range ids {
+			for l := 0; l < layers; l++ {
+				if err := visit(id, l); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package jpeg2000
+
+const tagTreeUnknown = 1 << 30
+
+type tagTreeNode struct {
+	parent *tagTreeNode
+	value  int
+	low    int
+}
+
+// tagTree is the tag tree of T.800 B.10.2 used to code code-block inclusion and zero bit-planes.
+type tagTree struct {
+	leaves []*tagTreeNode
+}
+
+func newTagTree(w, h int) *tagTree {
+	t := &tagTree{}
+
+	level := make([]*tagTreeNode, w*h)
+	for i := range level {
+		level[i] = &tagTreeNode{value: tagTreeUnknown}
+	}
+
+	t.leaves = level
+
+	for w > 1 || h > 1 {
+		pw, ph := (w+1)/2, (h+1)/2
+		parents := make([]*tagTreeNode, pw*ph)
+
+		for i := range parents {
+			parents[i] = &tagTreeNode{value: tagTreeUnknown}
+		}
+
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				level[y*w+x].parent = parents[(y/2)*pw+x/2]
+			}
+		}
+
+		level, w, h = parents, pw, ph
+	}
+
+	return t
+}
+
+// decode reads bits until the value of the leaf is known to be at least threshold or is known
+// exactly, it reports whether the value is lower than threshold.
+func (t *tagTree) decode(r *packetReader, leaf int, threshold int) (bool, error) {
+	var path []*tagTreeNode
+	for n := t.leaves[leaf]; n != nil; n = n.parent {
+		path = append(path, n)
+	}
+
+	low := 0
+
+	for i := len(path) - 1; i >= 0; i-- {
+		n := path[i]
+
+		if low > n.low {
+			n.low = low
+		} else {
+			low = n.low
+		}
+
+		for low < threshold && low < n.value {
+			bit, err := r.bit()
+			if err != nil {
+				return false, err
+			}
+
+			if bit == 1 {
+				n.value = low
+			} else {
+				low++
+			}
+		}
+
+		n.low = low
+	}
+
+	return t.leaves[leaf].value < threshold, nil
+}
+
+// value decodes the exact value of the leaf.
+func (t *tagTree) value(r *packetReader, leaf int) (int, error) {
+	for threshold := 1; ; threshold++ {
+		ok, err := t.decode(r, leaf, threshold)
+		if err != nil {
+			return 0, err
+		}
+
+		if ok {
+			return t.leaves[leaf].value, nil
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package jpeg2000
+
+import (
+	"fmt"
+	"math"
+)
+
+// Subband orientations, as xob | yob<<1 (T.800 Table B.1)
+const (
+	orientLL = iota
+	orientHL
+	orientLH
+	orientHH
+)
+
+type band struct {
+	orient         int
+	x0, y0, x1, y1 int
+	numBitplanes   int
+	step           float64
+
+	// coefficients with an extra fractional bit, written by the code-block decoder
+	coeffs []int64
+}
+
+func (b *band) width() int {
+	return b.x1 - b.x0
+}
+
+type resolution struct {
+	x0, y0, x1, y1     int
+	ppx, ppy           int
+	numPrecW, numPrecH int
+	bands              []*band
+	precincts          []*precinct
+}
+
+type tileDecoder struct {
+	tile *tile
+	cod  *codingStyle
+	spc  *componentStyle
+	q    *quantization
+	comp component
+
+	x0, y0, x1, y1 int
+	res            []*resolution
+}
+
+func (cs *codestream) decodeTile(t *tile, img *Image) error {
+	cod, spc, q := cs.styles(t)
+	s := &cs.siz
+
+	p, qy := t.index%cs.numTilesX(), t.index/cs.numTilesX()
+
+	td := &tileDecoder{
+		tile: t,
+		cod:  cod,
+		spc:  spc,
+		q:    q,
+		comp: s.comps[0],
+		x0:   max(int(s.xtosiz)+p*int(s.xtsiz), int(s.xosiz)),
+		y0:   max(int(s.ytosiz)+qy*int(s.ytsiz), int(s.yosiz)),
+		x1:   min(int(s.xtosiz)+(p+1)*int(s.xtsiz), int(s.xsiz)),
+		y1:   min(int(s.ytosiz)+(qy+1)*int(s.ytsiz), int(s.ysiz)),
+	}
+
+	if err := td.init(); err != nil {
+		return err
+	}
+
+	if err := td.decodePackets(); err != nil {
+		return err
+	}
+
+	if err := td.decodeCodeBlocks(); err != nil {
+		return err
+	}
+
+	td.reconstruct(img, int(s.xosiz), int(s.yosiz))
+
+	return nil
+}
+
+// init builds the resolutions, subbands, precincts and code-blocks of the tile (T.800 B.5 - B.7).
+func (td *tileDecoder) init() error {
+	nl := td.spc.levels
+	td.res = make([]*resolution, nl+1)
+
+	for r := 0; r <= nl; r++ {
+		shift := nl - r
+		rs := &resolution{
+			x0:  ceilDivPow2(td.x0, shift),
+			y0:  ceilDivPow2(td.y0, shift),
+			x1:  ceilDivPow2(td.x1, shift),
+			y1:  ceilDivPow2(td.y1, shift),
+			ppx: td.spc.ppx[r],
+			ppy: td.spc.ppy[r],
+		}
+
+		if rs.x1 > rs.x0 && rs.y1 > rs.y0 {
+			rs.numPrecW = ceilDivPow2(rs.x1, rs.ppx) - rs.x0>>rs.ppx
+			rs.numPrecH = ceilDivPow2(rs.y1, rs.ppy) - rs.y0>>rs.ppy
+		}
+
+		orients := []int{orientHL, orientLH, orientHH}
+		if r == 0 {
+			orients = []int{orientLL}
+		}
+
+		for i, o := range orients {
+			b, err := td.newBand(r, o, i)
+			if err != nil {
+				return err
+			}
+
+			rs.bands = append(rs.bands, b)
+		}
+
+		td.initPrecincts(r, rs)
+		td.res[r] = rs
+	}
+
+	return nil
+}
+
+func (td *tileDecoder) newBand(r, orient, i int) (*band, error) {
+	nl := td.spc.levels
+	b := &band{orient: orient}
+
+	if r == 0 {
+		b.x0, b.y0 = ceilDivPow2(td.x0, nl), ceilDivPow2(td.y0, nl)
+		b.x1, b.y1 = ceilDivPow2(td.x1, nl), ceilDivPow2(td.y1, nl)
+	} else {
+		nb := nl - r + 1
+		xo, yo := (orient&1)<<(nb-1), (orient>>1)<<(nb-1)
+		b.x0, b.y0 = ceilDivPow2(td.x0-xo, nb), ceilDivPow2(td.y0-yo, nb)
+		b.x1, b.y1 = ceilDivPow2(td.x1-xo, nb), ceilDivPow2(td.y1-yo, nb)
+	}
+
+	index := 0
+	if r > 0 {
+		index = 3*(r-1) + i + 1
+	}
+
+	exponent, mantissa, err := td.q.exponentMantissa(index, nl)
+	if err != nil {
+		return nil, err
+	}
+
+	b.numBitplanes = td.q.guard + exponent - 1
+
+	if !td.spc.reversible {
+		gain := [4]int{0, 1, 1, 2}[orient]
+		b.step = math.Ldexp(1+float64(mantissa)/2048, td.comp.precision+gain-exponent)
+	}
+
+	b.coeffs = make([]int64, (b.x1-b.x0)*(b.y1-b.y0))
+
+	return b, nil
+}
+
+func (td *tileDecoder) initPrecincts(r int, rs *resolution) {
+	// precinct and code-block size exponents in subband coordinates
+	pbx, pby := rs.ppx, rs.ppy
+	if r > 0 {
+		pbx, pby = pbx-1, pby-1
+	}
+
+	xcb, ycb := min(td.spc.xcb, pbx), min(td.spc.ycb, pby)
+
+	rs.precincts = make([]*precinct, rs.numPrecW*rs.numPrecH)
+
+	for py := 0; py < rs.numPrecH; py++ {
+		for px := 0; px < rs.numPrecW; px++ {
+			p := &precinct{}
+
+			for _, b := range rs.bands {
+				x0 := max(((rs.x0>>rs.ppx)+px)<<pbx, b.x0)
+				y0 := max(((rs.y0>>rs.ppy)+py)<<pby, b.y0)
+				x1 := min(((rs.x0>>rs.ppx)+px+1)<<pbx, b.x1)
+				y1 := min(((rs.y0>>rs.ppy)+py+1)<<pby, b.y1)
+
+				pb := &precinctBand{}
+
+				if x0 < x1 && y0 < y1 {
+					cx0, cy0 := x0>>xcb, y0>>ycb
+					pb.numW = ceilDivPow2(x1, xcb) - cx0
+					pb.numH = ceilDivPow2(y1, ycb) - cy0
+
+					for cy := cy0; cy < cy0+pb.numH; cy++ {
+						for cx := cx0; cx < cx0+pb.numW; cx++ {
+							pb.blocks = append(pb.blocks, &codeBlock{
+								x0:     max(cx<<xcb, x0),
+								y0:     max(cy<<ycb, y0),
+								x1:     min((cx+1)<<xcb, x1),
+								y1:     min((cy+1)<<ycb, y1),
+								lblock: 3,
+							})
+						}
+					}
+
+					pb.inclusion = newTagTree(pb.numW, pb.numH)
+					pb.zeroBP = newTagTree(pb.numW, pb.numH)
+				}
+
+				p.bands = append(p.bands, pb)
+			}
+
+			rs.precincts[py*rs.numPrecW+px] = p
+		}
+	}
+}
+
+func (td *tileDecoder) decodeCodeBlocks() error {
+	d := &t1Decoder{}
+
+	for _, rs := range td.res {
+		for _, p := range rs.precincts {
+			for i, pb := range p.bands {
+				b := rs.bands[i]
+
+				for _, cb := range pb.blocks {
+					if err := d.decode(cb, b, td.spc.cblkStyle); err != nil {
+						return fmt.Errorf("code-block (%d, %d): %w", cb.x0, cb.y0, err)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// reconstruct applies the inverse wavelet transformation and the DC level shift and writes the
+// tile samples to the image.
+func (td *tileDecoder) reconstruct(img *Image, xo, yo int) {
+	w, h := td.x1-td.x0, td.y1-td.y0
+
+	var samples []float64
+
+	if td.spc.reversible {
+		ints := td.inverseReversible()
+		samples = make([]float64, len(ints))
+
+		for i, v := range ints {
+			samples[i] = float64(v)
+		}
+	} else {
+		samples = td.inverseIrreversible()
+	}
+
+	lo, hi := int64(0), int64(1)<<td.comp.precision-1
+	shift := int64(1) << (td.comp.precision - 1)
+
+	if td.comp.signed {
+		lo, hi, shift = -shift, shift-1, 0
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := int64(math.Round(samples[y*w+x])) + shift
+			img.Data[(td.y0-yo+y)*img.Width+td.x0-xo+x] = min(max(v, lo), hi)
+		}
+	}
+}
+
+// interleave returns the samples of resolution r arranged by the 2D_INTERLEAVE procedure
+// of T.800 F.3.3, lower resolution samples are taken from ll.
+func interleave[T int64 | float64](rs, prev *resolution, ll []T, bands [3][]T, hb [3]*band) []T {
+	w, h := rs.x1-rs.x0, rs.y1-rs.y0
+	out := make([]T, w*h)
+
+	for y := rs.y0; y < rs.y1; y++ {
+		for x := rs.x0; x < rs.x1; x++ {
+			var v T
+
+			switch o := x&1 | (y&1)<<1; o {
+			case orientLL:
+				v = ll[(y>>1-prev.y0)*(prev.x1-prev.x0)+x>>1-prev.x0]
+			default:
+				b := hb[o-1]
+				v = bands[o-1][(y>>1-b.y0)*b.width()+x>>1-b.x0]
+			}
+
+			out[(y-rs.y0)*w+x-rs.x0] = v
+		}
+	}
+
+	return out
+}
+
+func (td *tileDecoder) inverseReversible() []int64 {
+	ll := make([]int64, len(td.res[0].bands[0].coeffs))
+	for i, v := range td.res[0].bands[0].coeffs {
+		ll[i] = v / 2
+	}
+
+	for r := 1; r < len(td.res); r++ {
+		rs := td.res[r]
+
+		var (
+			bands [3][]int64
+			hb    [3]*band
+		)
+
+		for i, b := range rs.bands {
+			hb[i] = b
+			bands[i] = make([]int64, len(b.coeffs))
+
+			for j, v := range b.coeffs {
+				bands[i][j] = v / 2
+			}
+		}
+
+		ll = interleave(rs, td.res[r-1], ll, bands, hb)
+		transform2D(ll, rs, inverse53)
+	}
+
+	return ll
+}
+
+func (td *tileDecoder) inverseIrreversible() []float64 {
+	dequantize := func(b *band) []float64 {
+		out := make([]float64, len(b.coeffs))
+		for i, v := range b.coeffs {
+			out[i] = float64(v) * b.step / 2
+		}
+
+		return out
+	}
+
+	ll := dequantize(td.res[0].bands[0])
+
+	for r := 1; r < len(td.res); r++ {
+		rs := td.res[r]
+
+		var (
+			bands [3][]float64
+			hb    [3]*band
+		)
+
+		for i, b := range rs.bands {
+			hb[i] = b
+			bands[i] = dequantize(b)
+		}
+
+		ll = interleave(rs, td.res[r-1], ll, bands, hb)
+		transform2D(ll, rs, inverse97)
+	}
+
+	return ll
+}
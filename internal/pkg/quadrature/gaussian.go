@@ -0,0 +1,56 @@
+// Package quadrature computes the nodes of the Gaussian quadrature, which are the parallels of
+// Gaussian grids and of the synthesis of spherical harmonics.
+package quadrature
+
+import (
+	"math"
+	"sync"
+)
+
+// latitudes caches the Gaussian latitudes by number of parallels between a pole and the equator.
+var latitudes sync.Map
+
+// GaussianLatitudes returns the 2n Gaussian latitudes in degrees from north to south, the
+// arcsines of the roots of the Legendre polynomial of degree 2n. The latitudes are computed once
+// for each n, the returned slice is shared and must not be modified.
+func GaussianLatitudes(n int) []float64 {
+	if lats, ok := latitudes.Load(n); ok {
+		return lats.([]float64)
+	}
+
+	lats, _ := latitudes.LoadOrStore(n, gaussianLatitudes(n))
+
+	return lats.([]float64)
+}
+
+func gaussianLatitudes(n int) []float64 {
+	var (
+		nlat = 2 * n
+		lats = make([]float64, nlat)
+	)
+
+	for i := 0; i < n; i++ {
+		// Newton's method from the asymptotic approximation of the root
+		mu := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(nlat) + 0.5))
+
+		for iter := 0; iter < 100; iter++ {
+			p0, p1 := 1.0, mu
+			for k := 2; k <= nlat; k++ {
+				p0, p1 = p1, (float64(2*k-1)*mu*p1-float64(k-1)*p0)/float64(k)
+			}
+
+			dp := float64(nlat) * (p0 - mu*p1) / (1 - mu*mu)
+			delta := p1 / dp
+			mu -= delta
+
+			if math.Abs(delta) < 1e-15 {
+				break
+			}
+		}
+
+		lat := math.Asin(mu) * 180 / math.Pi
+		lats[i], lats[nlat-1-i] = lat, -lat
+	}
+
+	return lats
+}
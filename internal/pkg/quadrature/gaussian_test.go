@@ -0,0 +1,32 @@
+package quadrature_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/scorix/grib-go/internal/pkg/quadrature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaussianLatitudes(t *testing.T) {
+	t.Parallel()
+
+	// roots of the Legendre polynomial of degree 4
+	want := []float64{0.8611363115940526, 0.3399810435848563, -0.3399810435848563, -0.8611363115940526}
+
+	lats := quadrature.GaussianLatitudes(2)
+	require.Len(t, lats, 4)
+
+	for i, lat := range lats {
+		assert.InDelta(t, want[i], math.Sin(lat*math.Pi/180), 1e-12)
+	}
+
+	lats = quadrature.GaussianLatitudes(320)
+	require.Len(t, lats, 640)
+	assert.InDelta(t, 89.784877, lats[0], 1e-6)
+	assert.InDelta(t, -lats[319], lats[320], 1e-12)
+
+	// computed once
+	assert.Same(t, &lats[0], &quadrature.GaussianLatitudes(320)[0])
+}
@@ -0,0 +1,155 @@
+package grib2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+
+	"github.com/scorix/grib-go/pkg/grib2/cache"
+)
+
+// Bitmap is the bit-map of section 6. A bit set to 1 means that a data value is present
+// for the corresponding grid point, a bit set to 0 that the grid point is missing.
+type Bitmap struct {
+	bits      []byte
+	numPoints int
+	ranks     []int // number of bits set before each block of 8 bytes
+	count     int
+}
+
+func NewBitmap(bs []byte, numPoints int) (*Bitmap, error) {
+	if len(bs)*8 < numPoints {
+		return nil, fmt.Errorf("bit-map of %d bytes is too short for %d grid points", len(bs), numPoints)
+	}
+
+	b := &Bitmap{
+		bits:      bs,
+		numPoints: numPoints,
+	}
+	b.buildRanks()
+
+	return b, nil
+}
+
+func (b *Bitmap) buildRanks() {
+	b.ranks = make([]int, 0, b.numPoints/64+1)
+	b.count = 0
+
+	for n := 0; n < b.numPoints; n++ {
+		if n%64 == 0 {
+			b.ranks = append(b.ranks, b.count)
+		}
+
+		if b.IsSet(n) {
+			b.count++
+		}
+	}
+}
+
+// Len returns the number of grid points covered by the bit-map.
+func (b *Bitmap) Len() int {
+	return b.numPoints
+}
+
+// Count returns the number of grid points with a data value.
+func (b *Bitmap) Count() int {
+	return b.count
+}
+
+func (b *Bitmap) IsSet(n int) bool {
+	if n < 0 || n >= b.numPoints {
+		return false
+	}
+
+	return b.bits[n/8]&(0x80>>(n%8)) != 0
+}
+
+// ValueIndex maps grid point n to the index of its value in the packed data.
+// It returns false if the grid point is missing.
+func (b *Bitmap) ValueIndex(n int) (int, bool) {
+	if !b.IsSet(n) {
+		return 0, false
+	}
+
+	idx := b.ranks[n/64]
+	for i := n / 64 * 8; i < n/8; i++ {
+		idx += bits.OnesCount8(b.bits[i])
+	}
+
+	idx += bits.OnesCount8(b.bits[n/8] >> (8 - n%8))
+
+	return idx, true
+}
+
+// Expand places the packed values on the grid points of the bit-map, grid points
+// without a value are set to missing.
+func (b *Bitmap) Expand(values []float32, missing float32) ([]float32, error) {
+	if len(values) != b.count {
+		return nil, fmt.Errorf("bit-map expects %d values, got %d", b.count, len(values))
+	}
+
+	expanded := make([]float32, b.numPoints)
+	idx := 0
+
+	for n := range expanded {
+		if b.IsSet(n) {
+			expanded[n] = values[idx]
+			idx++
+		} else {
+			expanded[n] = missing
+		}
+	}
+
+	return expanded, nil
+}
+
+type bitmap struct {
+	Points int    `json:"points"`
+	Bits   []byte `json:"bits"`
+}
+
+func (b *Bitmap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bitmap{
+		Points: b.numPoints,
+		Bits:   b.bits,
+	})
+}
+
+func (b *Bitmap) UnmarshalJSON(data []byte) error {
+	var temp bitmap
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if len(temp.Bits)*8 < temp.Points {
+		return fmt.Errorf("bit-map of %d bytes is too short for %d grid points", len(temp.Bits), temp.Points)
+	}
+
+	b.bits = temp.Bits
+	b.numPoints = temp.Points
+	b.buildRanks()
+
+	return nil
+}
+
+// bitmapDataSource resolves grid points through a bit-map before reading the packed value.
+type bitmapDataSource struct {
+	bitmap     *Bitmap
+	datasource cache.GridDataSource
+	missing    float32
+}
+
+func (ds *bitmapDataSource) ReadGridAt(ctx context.Context, grid int) (float32, error) {
+	if grid < 0 || grid >= ds.bitmap.Len() {
+		return 0, fmt.Errorf("grid point %d is out of range[0-%d]", grid, ds.bitmap.Len())
+	}
+
+	idx, ok := ds.bitmap.ValueIndex(grid)
+	if !ok {
+		return ds.missing, nil
+	}
+
+	return ds.datasource.ReadGridAt(ctx, idx)
+}
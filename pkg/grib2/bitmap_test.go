@@ -0,0 +1,163 @@
+package grib2_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitmap_ValueIndex(t *testing.T) {
+	t.Parallel()
+
+	const numPoints = 1000
+
+	bs := make([]byte, (numPoints+7)/8)
+	rand.New(rand.NewSource(1)).Read(bs)
+
+	bm, err := grib2.NewBitmap(bs, numPoints)
+	require.NoError(t, err)
+
+	idx := 0
+	for n := 0; n < numPoints; n++ {
+		set := bs[n/8]&(0x80>>(n%8)) != 0
+		require.Equal(t, set, bm.IsSet(n), "grid point %d", n)
+
+		got, ok := bm.ValueIndex(n)
+		require.Equal(t, set, ok, "grid point %d", n)
+
+		if set {
+			require.Equal(t, idx, got, "grid point %d", n)
+			idx++
+		}
+	}
+
+	assert.Equal(t, idx, bm.Count())
+	assert.Equal(t, numPoints, bm.Len())
+
+	_, err = grib2.NewBitmap(bs, numPoints+8)
+	assert.Error(t, err)
+}
+
+func TestBitmap_JSON(t *testing.T) {
+	t.Parallel()
+
+	bm, err := grib2.NewBitmap([]byte{0b1011_0000}, 6)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(bm)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"points":6,"bits":"sA=="}`, string(data))
+
+	var got grib2.Bitmap
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, 3, got.Count())
+
+	idx, ok := got.ValueIndex(3)
+	assert.True(t, ok)
+	assert.Equal(t, 2, idx)
+}
+
+func TestMessage_ReadData_Bitmap(t *testing.T) {
+	t.Parallel()
+
+	data := testMessage(testField{
+		ni:     3,
+		nj:     2,
+		bitmap: []byte{0b1011_0000},
+		values: []uint8{1, 2, 3},
+	})
+
+	t.Run("nan", func(t *testing.T) {
+		t.Parallel()
+
+		g := grib2.NewGrib2(bytes.NewReader(data))
+
+		msg, err := g.ReadMessageAt(0, 0)
+		require.NoError(t, err)
+
+		values, err := msg.ReadData()
+		require.NoError(t, err)
+		require.Len(t, values, 6)
+
+		assert.Equal(t, []float32{1, 2, 3}, []float32{values[0], values[2], values[3]})
+		assert.True(t, math.IsNaN(float64(values[1])))
+		assert.True(t, math.IsNaN(float64(values[4])))
+		assert.True(t, math.IsNaN(float64(values[5])))
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		t.Parallel()
+
+		g := grib2.NewGrib2(bytes.NewReader(data), grib2.WithMissingValue(9999))
+
+		msg, err := g.ReadMessageAt(0, 0)
+		require.NoError(t, err)
+
+		values, err := msg.ReadData()
+		require.NoError(t, err)
+		assert.Equal(t, []float32{1, 9999, 2, 3, 9999, 9999}, values)
+	})
+}
+
+func TestMessageReader_ReadLL_Bitmap(t *testing.T) {
+	t.Parallel()
+
+	data := testMessage(testField{
+		ni:     3,
+		nj:     2,
+		bitmap: []byte{0b1011_0000},
+		values: []uint8{1, 2, 3},
+	})
+	r := bytes.NewReader(data)
+
+	msg, err := grib2.NewGrib2(r).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+
+	mi, err := msg.DumpMessageIndex()
+	require.NoError(t, err)
+	require.NotNil(t, mi.Bitmap)
+
+	bs, err := json.Marshal(mi)
+	require.NoError(t, err)
+
+	var restored grib2.MessageIndex
+	require.NoError(t, json.Unmarshal(bs, &restored))
+
+	fromMessage, err := grib2.NewSimplePackingMessageReaderFromMessage(r, msg)
+	require.NoError(t, err)
+
+	fromIndex, err := grib2.NewSimplePackingMessageReaderFromMessageIndex(r, &restored, grib2.WithReaderMissingValue(-1))
+	require.NoError(t, err)
+
+	tests := []struct {
+		lat, lon float32
+		want     float32
+	}{
+		{lat: 1, lon: 0, want: 1},
+		{lat: 1, lon: 2, want: 2},
+		{lat: 0, lon: 0, want: 3},
+		{lat: 0, lon: 1, want: -1},
+	}
+
+	for _, tt := range tests {
+		_, _, v, err := fromIndex.ReadLL(context.TODO(), tt.lat, tt.lon)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, v, "lat: %f, lon: %f", tt.lat, tt.lon)
+
+		_, _, v, err = fromMessage.ReadLL(context.TODO(), tt.lat, tt.lon)
+		require.NoError(t, err)
+
+		if tt.want < 0 {
+			assert.True(t, math.IsNaN(float64(v)))
+		} else {
+			assert.Equal(t, tt.want, v, "lat: %f, lon: %f", tt.lat, tt.lon)
+		}
+	}
+}
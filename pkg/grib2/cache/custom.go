@@ -33,7 +33,7 @@ func (c *custom) ReadGridAt(ctx context.Context, grid int, lat, lon float32) (fl
 		if !ok {
 			vFromSource, err := c.datasource.ReadGridAt(ctx, grid)
 			if err != nil {
-				return 0, err
+				return float32(0), err
 			}
 
 			c.cache.Set(ctx, grid, vFromSource)
@@ -2,9 +2,12 @@ package cache_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/scorix/grib-go/pkg/grib2/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // 实现一个简单的 GridDataSource
@@ -35,3 +38,25 @@ func BenchmarkCustom_ReadGridAt_Parallel(b *testing.B) {
 		}
 	})
 }
+
+var errReadFailed = errors.New("read failed")
+
+type errDataSource struct{}
+
+func (t *errDataSource) ReadGridAt(ctx context.Context, index int) (float32, error) {
+	return 0, errReadFailed
+}
+
+func TestCustom_ReadGridAt_Error(t *testing.T) {
+	c := cache.NewCustom(
+		func(lat, lon float32) bool {
+			return true
+		},
+		&errDataSource{},
+		cache.NewMapStore(),
+	)
+
+	v, err := c.ReadGridAt(context.Background(), 1, 1, 1)
+	require.ErrorIs(t, err, errReadFailed)
+	assert.Equal(t, float32(0), v)
+}
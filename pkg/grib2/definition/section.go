@@ -139,11 +139,27 @@ type Section5FixedPart struct {
 }
 
 type Section6 struct {
+	Section6FixedPart
+	Bitmap []byte // 7-N Bit-map, only present when the bit-map indicator is 0
+}
+
+// don't edit
+type Section6FixedPart struct {
 	Section6Length  uint32 // Length of the section in octets (N)
 	NumberOfSection uint8  // 6 - Number of the section
-	BitMapIndicator uint8
+	BitMapIndicator BitMapIndicator
 }
 
+// https://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_doc/grib2_table6-0.shtml
+type BitMapIndicator uint8
+
+const (
+	BitMapIndicatorSpecified BitMapIndicator = 0
+	// 1-253 A bit map predetermined by the originating/generating center applies
+	BitMapIndicatorPreviouslyDefined BitMapIndicator = 254
+	BitMapIndicatorMissing           BitMapIndicator = 255
+)
+
 type Section7 struct {
 	Section7FixedPart
 	Data []byte
@@ -45,3 +45,147 @@ Octets	Key	Type	Content
 21	typeOfOriginalFieldValues	codetable	Type of original field values (see Code Table 5.1)
 */
 type PNG SimplePacking
+
+/*
+Section 5 - Template 4 : Grid point data - IEEE floating point data
+
+Octets	Key	Type	Content
+12	precision	codetable	Precision (see Code Table 5.7)
+*/
+type IEEEFloat struct {
+	Precision uint8 `json:"precision"` // Precision: https://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_doc/grib2_table5-7.shtml
+}
+
+/*
+Section 5 - Template 40 : Grid point data - JPEG 2000 code stream format
+
+Octets	Key	Type	Content
+12-15	referenceValue	ieeefloat	Reference value (R) (IEEE 32-bit floating-point value)
+16-17	binaryScaleFactor	signed	Binary scale factor (E)
+18-19	decimalScaleFactor	signed	Decimal scale factor (D)
+20	bitsPerValue	unsigned	Number of bits required to hold the resulting scaled and referenced data values. (i.e. The depth of the grayscale image.) (see Note 2)
+21	typeOfOriginalFieldValues	codetable	Type of original field values (see Code Table 5.1)
+22	typeOfCompressionUsed	codetable	Type of Compression used. (see Code Table 5.40)
+23	targetCompressionRatio	unsigned	Target compression ratio, M:1 (with respect to the bit-depth specified in octet 20), when octet 22 indicates Lossy Compression. Otherwise, set to missing (see Note 3)
+*/
+// don't edit
+type JPEG2000 struct {
+	SimplePacking
+
+	CompressionType        uint8 `json:"compressionType"`        // Type of compression used: https://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_doc/grib2_table5-40.shtml
+	TargetCompressionRatio uint8 `json:"targetCompressionRatio"` // Target compression ratio M:1, when lossy compression is used
+}
+
+/*
+Section 5 - Template 42 : Grid point and spectral data - CCSDS recommended lossless compression
+
+Octets	Key	Type	Content
+12-15	referenceValue	ieeefloat	Reference value (R) (IEEE 32-bit floating-point value)
+16-17	binaryScaleFactor	signed	Binary scale factor (E)
+18-19	decimalScaleFactor	signed	Decimal scale factor (D)
+20	bitsPerValue	unsigned	Number of bits required to hold the resulting scaled and referenced data values. (see Note 2)
+21	typeOfOriginalFieldValues	codetable	Type of original field values (see Code Table 5.1)
+22	ccsdsFlags	flagtable	CCSDS compression options mask (see Note 3)
+23	ccsdsBlockSize	unsigned	Block size
+24-25	ccsdsRsi	unsigned	Reference sample interval
+*/
+// don't edit
+type CCSDS struct {
+	SimplePacking
+
+	Flags                   uint8  `json:"flags"`                   // CCSDS compression options mask
+	BlockSize               uint8  `json:"blockSize"`               // Block size
+	ReferenceSampleInterval uint16 `json:"referenceSampleInterval"` // Reference sample interval
+}
+
+/*
+Section 5 - Template 61 : Grid point data - Simple packing with logarithm pre-processing
+
+Octets	Key	Type	Content
+12-15	referenceValue	ieeefloat	Reference value (R) (IEEE 32-bit floating-point value)
+16-17	binaryScaleFactor	signed	Binary scale factor (E)
+18-19	decimalScaleFactor	signed	Decimal scale factor (D)
+20	bitsPerValue	unsigned	Number of bits used for each packed value
+21-24	preProcessingParameter	ieeefloat	Pre-processing parameter (B) (IEEE 32-bit floating-point value)
+*/
+// don't edit
+type SimplePackingWithLogPreProcessing struct {
+	R                      float32 `json:"r"`                      // Reference value (R) (IEEE 32-bit floating-point value)
+	B                      uint16  `json:"b"`                      // Binary scale factor
+	D                      uint16  `json:"d"`                      // Decimal scale factor
+	L                      uint8   `json:"l"`                      // Number of bits used for each packed value
+	PreProcessingParameter float32 `json:"preProcessingParameter"` // Pre-processing parameter (B)
+}
+
+/*
+Section 5 - Template 50 : Spectral data - simple packing
+
+Octets	Key	Type	Content
+12-15	referenceValue	ieeefloat	Reference value (R) (IEEE 32-bit floating-point value)
+16-17	binaryScaleFactor	signed	Binary scale factor (E)
+18-19	decimalScaleFactor	signed	Decimal scale factor (D)
+20	bitsPerValue	unsigned	Number of bits used for each packed value
+21-24	realPartOf00	ieeefloat	Real part of (0,0) coefficient (IEEE 32-bit floating-point value)
+*/
+// don't edit
+type SpectralSimplePacking struct {
+	R            float32 `json:"r"`            // Reference value (R) (IEEE 32-bit floating-point value)
+	B            uint16  `json:"b"`            // Binary scale factor
+	D            uint16  `json:"d"`            // Decimal scale factor
+	L            uint8   `json:"l"`            // Number of bits used for each packed value
+	RealPartOf00 float32 `json:"realPartOf00"` // Real part of (0,0) coefficient
+}
+
+/*
+Section 5 - Template 51 : Spectral data - complex packing
+
+Octets	Key	Type	Content
+12-15	referenceValue	ieeefloat	Reference value (R) (IEEE 32-bit floating-point value)
+16-17	binaryScaleFactor	signed	Binary scale factor (E)
+18-19	decimalScaleFactor	signed	Decimal scale factor (D)
+20	bitsPerValue	unsigned	Number of bits used for each packed value
+21-24	laplacianScalingFactor	signed	P - Laplacian scaling factor (expressed in 10^-6 units)
+25-26	JS	unsigned	JS - pentagonal resolution parameter of the unpacked subset
+27-28	KS	unsigned	KS - pentagonal resolution parameter of the unpacked subset
+29-30	MS	unsigned	MS - pentagonal resolution parameter of the unpacked subset
+31-34	TS	unsigned	TS - total number of values in the unpacked subset
+35	unpackedSubsetPrecision	codetable	Precision of the unpacked subset (see Code Table 5.7)
+*/
+// don't edit
+type SpectralComplexPacking struct {
+	R                       float32 `json:"r"`                       // Reference value (R) (IEEE 32-bit floating-point value)
+	B                       uint16  `json:"b"`                       // Binary scale factor
+	D                       uint16  `json:"d"`                       // Decimal scale factor
+	L                       uint8   `json:"l"`                       // Number of bits used for each packed value
+	LaplacianScalingFactor  uint32  `json:"laplacianScalingFactor"`  // Laplacian scaling factor, in 10^-6 units
+	JS                      uint16  `json:"js"`                      // Pentagonal resolution parameter J of the unpacked subset
+	KS                      uint16  `json:"ks"`                      // Pentagonal resolution parameter K of the unpacked subset
+	MS                      uint16  `json:"ms"`                      // Pentagonal resolution parameter M of the unpacked subset
+	TS                      uint32  `json:"ts"`                      // Total number of values in the unpacked subset
+	UnpackedSubsetPrecision uint8   `json:"unpackedSubsetPrecision"` // Precision of the unpacked subset: https://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_doc/grib2_table5-7.shtml
+}
+
+/*
+Section 5 - Template 200 : Run length packing with level values
+
+Octets	Key	Type	Content
+12	bitsPerValue	unsigned	Number of bits used for each packed value in the run length packing with level value
+13-14	maxLevelValue	unsigned	MV - maximum value within the levels that are used in the packing
+15-16	numberOfLevelValues	unsigned	MVL - maximum value of level (predefined)
+17	decimalScaleFactor	unsigned	Decimal scale factor of representative value of each level
+18-nn	levelValues	unsigned	List of scaled representative values of each level from 1 to MVL
+*/
+// don't edit
+type RunLengthPacking struct {
+	RunLengthPackingFixedPart
+
+	LevelValues []uint16 `json:"levelValues"` // Scaled representative values of each level from 1 to MVL
+}
+
+// don't edit
+type RunLengthPackingFixedPart struct {
+	L                   uint8  `json:"l"`                   // Number of bits used for each packed value
+	MaxLevelValue       uint16 `json:"maxLevelValue"`       // MV - maximum value within the levels that are used in the packing
+	NumberOfLevelValues uint16 `json:"numberOfLevelValues"` // MVL - maximum value of level
+	D                   uint8  `json:"d"`                   // Decimal scale factor of representative value of each level
+}
@@ -0,0 +1,91 @@
+package gridpoint
+
+import (
+	"fmt"
+
+	"github.com/scorix/grib-go/internal/pkg/aec"
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/datapacking"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+type CCSDS struct {
+	ReferenceValue          float32
+	BinaryScaleFactor       int16
+	DecimalScaleFactor      int16
+	Bits                    uint8
+	Type                    int8
+	Flags                   uint8
+	BlockSize               uint8
+	ReferenceSampleInterval uint16
+	NumVals                 int
+}
+
+func NewCCSDS(def definition.CCSDS, numVals int) *CCSDS {
+	return &CCSDS{
+		ReferenceValue:          def.R,
+		BinaryScaleFactor:       regulation.ToInt16(def.B),
+		DecimalScaleFactor:      regulation.ToInt16(def.D),
+		Bits:                    def.L,
+		Type:                    regulation.ToInt8(def.T),
+		Flags:                   def.Flags,
+		BlockSize:               def.BlockSize,
+		ReferenceSampleInterval: def.ReferenceSampleInterval,
+		NumVals:                 numVals,
+	}
+}
+
+func (c *CCSDS) Definition() any {
+	return definition.CCSDS{
+		SimplePacking: definition.SimplePacking{
+			R: c.ReferenceValue,
+			B: regulation.ToUint16(c.BinaryScaleFactor),
+			D: regulation.ToUint16(c.DecimalScaleFactor),
+			L: c.Bits,
+			T: regulation.ToUint8(c.Type),
+		},
+		Flags:                   c.Flags,
+		BlockSize:               c.BlockSize,
+		ReferenceSampleInterval: c.ReferenceSampleInterval,
+	}
+}
+
+func (c *CCSDS) GetNumVals() int {
+	return c.NumVals
+}
+
+func (c *CCSDS) ScaleFunc() func(uint32) float32 {
+	return datapacking.SimpleScaleFunc(c.BinaryScaleFactor, c.DecimalScaleFactor, c.ReferenceValue)
+}
+
+func (c *CCSDS) ReadAllData(r *bitio.Reader) ([]float32, error) {
+	var (
+		values    = make([]float32, c.NumVals)
+		scaleFunc = c.ScaleFunc()
+	)
+
+	// Special case: if bits per value is 0, all values are equal to the scaled reference value
+	if c.Bits == 0 {
+		for i := range values {
+			values[i] = scaleFunc(0)
+		}
+		return values, nil
+	}
+
+	samples, err := aec.Decode(r, c.NumVals, aec.Params{
+		BitsPerSample: int(c.Bits),
+		BlockSize:     int(c.BlockSize),
+		RSI:           int(c.ReferenceSampleInterval),
+		Flags:         c.Flags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode CCSDS: %w", err)
+	}
+
+	for i, v := range samples {
+		values[i] = scaleFunc(uint32(v))
+	}
+
+	return values, nil
+}
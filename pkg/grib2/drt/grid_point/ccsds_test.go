@@ -0,0 +1,23 @@
+package gridpoint_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCCSDS_ReadAllData_ConstantField(t *testing.T) {
+	// no coded data follows when all values are equal to the reference value
+	c := gridpoint.NewCCSDS(definition.CCSDS{
+		SimplePacking: definition.SimplePacking{R: 101325, D: 2},
+	}, 3)
+
+	values, err := c.ReadAllData(bitio.NewReader(bytes.NewReader(nil)))
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1013.25, 1013.25, 1013.25}, values)
+}
@@ -73,46 +73,10 @@ func (cp *ComplexPacking) unpackData(r *bitio.Reader, groups []Group, f scaleGro
 			return nil, fmt.Errorf("got more than %d values", cp.NumVals)
 		}
 
-		missingValueBits := g.width
-		if missingValueBits == 0 {
-			missingValueBits = cp.Bits
-		}
-
-		missingValues := []uint32{1<<missingValueBits - 1, 1<<missingValueBits - 2}
-
-		switch cp.MissingValueManagementUsed {
-		case 0:
-			miss = append(miss, make([]uint32, len(groupData))...)
-			for i := range groupData {
-				groupData[i] += g.ref
-			}
-
-		case 1:
-			for i := range groupData {
-				if g.ref == missingValues[0] {
-					groupData[i] = math.MaxUint32
-					miss = append(miss, 1)
-				} else {
-					groupData[i] += g.ref
-					miss = append(miss, 0)
-				}
-			}
-
-		case 2:
-			for i := range groupData {
-				if g.ref == missingValues[0] || g.ref == missingValues[1] {
-					groupData[i] = math.MaxUint32
-
-					if g.ref == missingValues[0] {
-						miss = append(miss, 1)
-					} else {
-						miss = append(miss, 2)
-					}
-				} else {
-					groupData[i] += g.ref
-					miss = append(miss, 0)
-				}
-			}
+		for i, x := range groupData {
+			v, m := cp.unpackValue(g, x)
+			groupData[i] = v
+			miss = append(miss, m)
 		}
 
 		idx += copy(data[idx:], groupData)
@@ -134,33 +98,53 @@ func (cp *ComplexPacking) ReadAllData(r *bitio.Reader) ([]float32, error) {
 	return cp.unpackData(r, groups, cp.scaleValues)
 }
 
-func (cp *ComplexPacking) scaleValues(data []uint32, miss []uint32, primary float32, secondary float32, scaleFunc func(uint32) float32) ([]float32, error) {
-	values := make([]float32, len(data))
+// unpackValue adds the reference of group g to its packed value x, it returns the missing value
+// indicator instead of the value when the group is missing: 1 for primary, 2 for secondary.
+func (cp *ComplexPacking) unpackValue(g Group, x uint32) (uint32, uint32) {
+	missingValueBits := g.width
+	if missingValueBits == 0 {
+		missingValueBits = cp.Bits
+	}
 
 	switch cp.MissingValueManagementUsed {
-	case 0:
-		// no missing values
-		for n, dataValue := range data {
-			values[n] = scaleFunc(dataValue)
+	case 1:
+		if g.ref == 1<<missingValueBits-1 {
+			return math.MaxUint32, 1
+		}
+	case 2:
+		if g.ref == 1<<missingValueBits-1 {
+			return math.MaxUint32, 1
 		}
 
-	case 1, 2:
-		// missing values included
-		for n, dataValue := range data {
-			switch miss[n] {
-			case 0:
-				values[n] = scaleFunc(dataValue)
-			case 1:
-				values[n] = primary
-			case 2:
-				values[n] = secondary
-			}
+		if g.ref == 1<<missingValueBits-2 {
+			return math.MaxUint32, 2
 		}
 	}
 
+	return g.ref + x, 0
+}
+
+func (cp *ComplexPacking) scaleValues(data []uint32, miss []uint32, primary float32, secondary float32, scaleFunc func(uint32) float32) ([]float32, error) {
+	values := make([]float32, len(data))
+
+	for n, dataValue := range data {
+		values[n] = scaleValue(dataValue, miss[n], primary, secondary, scaleFunc)
+	}
+
 	return values, nil
 }
 
+func scaleValue(v uint32, miss uint32, primary float32, secondary float32, scaleFunc func(uint32) float32) float32 {
+	switch miss {
+	case 1:
+		return primary
+	case 2:
+		return secondary
+	}
+
+	return scaleFunc(v)
+}
+
 func (cp *ComplexPacking) Definition() any {
 	return definition.ComplexPacking{
 		SimplePacking:              cp.SimplePacking.Definition().(definition.SimplePacking),
@@ -0,0 +1,269 @@
+package gridpoint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+)
+
+// GroupDirectory locates the packed data of each group of a complex packing, so that a grid point
+// can be read without unpacking the groups before it.
+type GroupDirectory struct {
+	References []uint32 `json:"references"`
+	Widths     []uint8  `json:"widths"`
+	Lengths    []uint32 `json:"lengths"`
+	Offsets    []uint64 `json:"offsets"` // bit offset of the packed data of each group in section 7
+
+	// spatial differencing
+	Order       int8     `json:"order,omitempty"`
+	Initial     []uint32 `json:"initial,omitempty"`     // first values of the original field
+	Min         uint32   `json:"min,omitempty"`         // overall minimum of the differences
+	Checkpoints []uint32 `json:"checkpoints,omitempty"` // the Order values preceding each group, the closest first
+}
+
+func (d *GroupDirectory) group(k int) Group {
+	return Group{ref: d.References[k], width: d.Widths[k], length: uint64(d.Lengths[k])}
+}
+
+// undifference reverts the spatial differencing of value n, prev holds the Order values preceding n,
+// the closest first.
+func (d *GroupDirectory) undifference(n int, v uint32, prev []uint32) uint32 {
+	if n < len(d.Initial) {
+		return d.Initial[n]
+	}
+
+	switch d.Order {
+	case 1:
+		return v + prev[0] + d.Min
+	case 2:
+		return v + 2*prev[0] - prev[1] + d.Min
+	}
+
+	return v
+}
+
+// newGroupDirectory lists groups whose packed data start at bit offset.
+func newGroupDirectory(groups []Group, offset uint64) *GroupDirectory {
+	d := &GroupDirectory{
+		References: make([]uint32, len(groups)),
+		Widths:     make([]uint8, len(groups)),
+		Lengths:    make([]uint32, len(groups)),
+		Offsets:    make([]uint64, len(groups)),
+	}
+
+	for k, g := range groups {
+		d.References[k] = g.ref
+		d.Widths[k] = g.width
+		d.Lengths[k] = uint32(g.length)
+		d.Offsets[k] = offset
+
+		offset += uint64(g.width) * g.length
+	}
+
+	return d
+}
+
+// headerBits returns the size of the group references, widths and lengths, each list is padded
+// to a full octet.
+func (cp *ComplexPacking) headerBits() uint64 {
+	octets := func(bits uint8) uint64 {
+		return (uint64(cp.NumberOfGroups)*uint64(bits) + 7) / 8 * 8
+	}
+
+	return octets(cp.Bits) + octets(cp.WidthsBits) + octets(cp.ScaledLengthsBits)
+}
+
+// GroupDirectory reads the group descriptors of section 7.
+func (cp *ComplexPacking) GroupDirectory(r *bitio.Reader) (*GroupDirectory, error) {
+	groups, err := cp.ReadGroups(r, cp.Bits)
+	if err != nil {
+		return nil, fmt.Errorf("read groups: %w", err)
+	}
+
+	return newGroupDirectory(groups, cp.headerBits()), nil
+}
+
+// GroupDirectory reads the group descriptors of section 7 and unpacks all groups once to record
+// the values preceding each group.
+func (cpsd *ComplexPackingAndSpatialDifferencing) GroupDirectory(r *bitio.Reader) (*GroupDirectory, error) {
+	sd, err := cpsd.ReadSpacingDifferential(r)
+	if err != nil {
+		return nil, fmt.Errorf("read spacing differential value: %w", err)
+	}
+
+	if sd == nil {
+		return nil, fmt.Errorf("spatial differencing without extra descriptors")
+	}
+
+	groups, err := cpsd.ReadGroups(r, cpsd.Bits)
+	if err != nil {
+		return nil, fmt.Errorf("read groups: %w", err)
+	}
+
+	d := newGroupDirectory(groups, uint64(cpsd.OctetsNumber)*8*uint64(len(sd.vals)+1)+cpsd.headerBits())
+	d.Order = int8(len(sd.vals))
+	d.Initial = sd.vals
+	d.Min = sd.min
+	d.Checkpoints = make([]uint32, 0, len(groups)*len(sd.vals))
+
+	var (
+		prev = make([]uint32, d.Order)
+		n    int
+	)
+
+	for _, g := range groups {
+		d.Checkpoints = append(d.Checkpoints, prev...)
+
+		data, err := g.ReadData(r)
+		if err != nil {
+			return nil, fmt.Errorf("read group data at value %d: %w", n, err)
+		}
+
+		for _, x := range data {
+			v, _ := cpsd.unpackValue(g, x)
+			v = d.undifference(n, v, prev)
+
+			copy(prev[1:], prev)
+			prev[0] = v
+			n++
+		}
+	}
+
+	return d, nil
+}
+
+// ComplexPackingReader reads grid points of complex packing, with or without spatial differencing,
+// by unpacking only the group of the grid point.
+type ComplexPackingReader struct {
+	r       io.ReaderAt
+	cp      *ComplexPacking
+	dir     *GroupDirectory
+	starts  []int
+	sf      func(uint32) float32
+	primary float32
+	second  float32
+}
+
+// NewComplexPackingReader returns a reader of the section 7 data between start and end, cp is the
+// complex packing of the data, the one embedded in ComplexPackingAndSpatialDifferencing for spatial
+// differencing.
+func NewComplexPackingReader(r io.ReaderAt, start, end int64, cp *ComplexPacking, dir *GroupDirectory) (*ComplexPackingReader, error) {
+	if len(dir.Widths) != len(dir.References) || len(dir.Lengths) != len(dir.References) || len(dir.Offsets) != len(dir.References) {
+		return nil, fmt.Errorf("invalid group directory")
+	}
+
+	if len(dir.Checkpoints) != int(dir.Order)*len(dir.References) {
+		return nil, fmt.Errorf("group directory has %d checkpoints, expected %d", len(dir.Checkpoints), int(dir.Order)*len(dir.References))
+	}
+
+	primary, secondary, err := cp.missingValueSubstitute()
+	if err != nil {
+		return nil, err
+	}
+
+	starts := make([]int, len(dir.Lengths))
+	n := 0
+
+	for k, l := range dir.Lengths {
+		starts[k] = n
+		n += int(l)
+	}
+
+	if n < cp.NumVals {
+		return nil, fmt.Errorf("groups hold %d values, expected %d", n, cp.NumVals)
+	}
+
+	return &ComplexPackingReader{
+		r:       io.NewSectionReader(r, start, end-start),
+		cp:      cp,
+		dir:     dir,
+		starts:  starts,
+		sf:      cp.ScaleFunc(),
+		primary: primary,
+		second:  secondary,
+	}, nil
+}
+
+// readGroup reads the packed values of group k from the ith to the jth.
+func (r *ComplexPackingReader) readGroup(k int, i int, j int) ([]uint32, error) {
+	var (
+		width = uint64(r.dir.Widths[k])
+		data  = make([]uint32, j-i+1)
+	)
+
+	if width == 0 {
+		return data, nil
+	}
+
+	var (
+		first = r.dir.Offsets[k] + uint64(i)*width
+		last  = r.dir.Offsets[k] + uint64(j+1)*width
+		bs    = make([]byte, (last+7)/8-first/8)
+	)
+
+	if _, err := r.r.ReadAt(bs, int64(first/8)); err != nil {
+		return nil, fmt.Errorf("read %d bytes at offset %d: %w", len(bs), first/8, err)
+	}
+
+	br := bitio.NewReader(bytes.NewReader(bs))
+	if _, err := br.ReadBits(uint8(first % 8)); err != nil {
+		return nil, err
+	}
+
+	for n := range data {
+		x, err := br.ReadBits(uint8(width))
+		if err != nil {
+			return nil, fmt.Errorf("read %d bits: %w", width, err)
+		}
+
+		data[n] = uint32(x)
+	}
+
+	return data, nil
+}
+
+func (r *ComplexPackingReader) ReadGridAt(ctx context.Context, n int) (float32, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if n < 0 || n >= r.cp.NumVals {
+		return 0, fmt.Errorf("grid point %d is out of range[0-%d]", n, r.cp.NumVals)
+	}
+
+	k := sort.SearchInts(r.starts, n+1) - 1
+	g := r.dir.group(k)
+
+	// without spatial differencing only the value itself is needed
+	i := n - r.starts[k]
+	if r.dir.Order > 0 {
+		i = 0
+	}
+
+	data, err := r.readGroup(k, i, n-r.starts[k])
+	if err != nil {
+		return 0, fmt.Errorf("read group %d: %w", k, err)
+	}
+
+	var (
+		v, miss uint32
+		prev    = append([]uint32(nil), r.dir.Checkpoints[k*int(r.dir.Order):(k+1)*int(r.dir.Order)]...)
+	)
+
+	for m, x := range data {
+		v, miss = r.cp.unpackValue(g, x)
+
+		if r.dir.Order > 0 {
+			v = r.dir.undifference(r.starts[k]+m, v, prev)
+
+			copy(prev[1:], prev)
+			prev[0] = v
+		}
+	}
+
+	return scaleValue(v, miss, r.primary, r.second, r.sf), nil
+}
@@ -0,0 +1,120 @@
+package gridpoint
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+)
+
+// https://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_doc/grib2_table5-7.shtml
+const (
+	IEEEPrecision32  uint8 = 1
+	IEEEPrecision64  uint8 = 2
+	IEEEPrecision128 uint8 = 3
+)
+
+type IEEEFloat struct {
+	Precision uint8 // 12
+	NumVals   int
+}
+
+func NewIEEEFloat(def definition.IEEEFloat, numVals int) *IEEEFloat {
+	return &IEEEFloat{
+		Precision: def.Precision,
+		NumVals:   numVals,
+	}
+}
+
+// Size returns the number of octets used for each value.
+func (f *IEEEFloat) Size() (int, error) {
+	switch f.Precision {
+	case IEEEPrecision32:
+		return 4, nil
+	case IEEEPrecision64:
+		return 8, nil
+	}
+
+	return 0, fmt.Errorf("unsupported IEEE floating point precision: %d", f.Precision)
+}
+
+func (f *IEEEFloat) decode(bs []byte) float32 {
+	if f.Precision == IEEEPrecision64 {
+		return float32(math.Float64frombits(binary.BigEndian.Uint64(bs)))
+	}
+
+	return math.Float32frombits(binary.BigEndian.Uint32(bs))
+}
+
+func (f *IEEEFloat) ReadAllData(r *bitio.Reader) ([]float32, error) {
+	size, err := f.Size()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]float32, f.NumVals)
+	bs := make([]byte, size)
+
+	for i := range values {
+		if _, err := io.ReadFull(r, bs); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("expected %d values, got %d", f.NumVals, i)
+			}
+
+			return nil, err
+		}
+
+		values[i] = f.decode(bs)
+	}
+
+	return values, nil
+}
+
+func (f *IEEEFloat) GetNumVals() int {
+	return f.NumVals
+}
+
+func (f *IEEEFloat) Definition() any {
+	return definition.IEEEFloat{
+		Precision: f.Precision,
+	}
+}
+
+type IEEEFloatReader struct {
+	r io.ReaderAt
+	f *IEEEFloat
+}
+
+func NewIEEEFloatReader(r io.ReaderAt, start, end int64, f *IEEEFloat) *IEEEFloatReader {
+	return &IEEEFloatReader{
+		r: io.NewSectionReader(r, start, end-start),
+		f: f,
+	}
+}
+
+func (r *IEEEFloatReader) ReadGridAt(ctx context.Context, n int) (float32, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if n < 0 || n >= r.f.NumVals {
+		return 0, fmt.Errorf("grid point %d is out of range[0-%d]", n, r.f.NumVals)
+	}
+
+	size, err := r.f.Size()
+	if err != nil {
+		return 0, err
+	}
+
+	bs := make([]byte, size)
+	if _, err := r.r.ReadAt(bs, int64(n*size)); err != nil {
+		return 0, fmt.Errorf("read %d bytes at offset %d: %w", size, n*size, err)
+	}
+
+	return r.f.decode(bs), nil
+}
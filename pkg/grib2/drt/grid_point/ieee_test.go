@@ -0,0 +1,56 @@
+package gridpoint_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIEEEFloat(t *testing.T) {
+	want := []float32{296.117, -1.5, 0, 1e-7}
+
+	tests := []struct {
+		name      string
+		precision uint8
+		encode    func(v float32) any
+	}{
+		{name: "32 bits", precision: 1, encode: func(v float32) any { return v }},
+		{name: "64 bits", precision: 2, encode: func(v float32) any { return float64(v) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			for _, v := range want {
+				require.NoError(t, binary.Write(&buf, binary.BigEndian, tt.encode(v)))
+			}
+
+			f := gridpoint.NewIEEEFloat(definition.IEEEFloat{Precision: tt.precision}, len(want))
+
+			values, err := f.ReadAllData(bitio.NewReader(bytes.NewReader(buf.Bytes())))
+			require.NoError(t, err)
+			assert.Equal(t, want, values)
+
+			r := gridpoint.NewIEEEFloatReader(bytes.NewReader(buf.Bytes()), 0, int64(buf.Len()), f)
+			for n, v := range want {
+				got, err := r.ReadGridAt(context.TODO(), n)
+				require.NoError(t, err)
+				assert.Equal(t, v, got)
+			}
+
+			_, err = r.ReadGridAt(context.TODO(), len(want))
+			assert.Error(t, err)
+
+			short := gridpoint.NewIEEEFloat(definition.IEEEFloat{Precision: tt.precision}, len(want)+1)
+			_, err = short.ReadAllData(bitio.NewReader(bytes.NewReader(buf.Bytes())))
+			assert.Error(t, err)
+		})
+	}
+}
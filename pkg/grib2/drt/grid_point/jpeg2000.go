@@ -0,0 +1,99 @@
+package gridpoint
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/internal/pkg/jpeg2000"
+	"github.com/scorix/grib-go/pkg/grib2/drt/datapacking"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+// https://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_doc/grib2_table5-40.shtml
+const (
+	JPEG2000Lossless uint8 = 0
+	JPEG2000Lossy    uint8 = 1
+)
+
+type JPEG2000 struct {
+	ReferenceValue         float32
+	BinaryScaleFactor      int16
+	DecimalScaleFactor     int16
+	Bits                   uint8
+	Type                   int8
+	CompressionType        uint8
+	TargetCompressionRatio uint8
+	NumVals                int
+}
+
+func NewJPEG2000(def definition.JPEG2000, numVals int) *JPEG2000 {
+	return &JPEG2000{
+		ReferenceValue:         def.R,
+		BinaryScaleFactor:      regulation.ToInt16(def.B),
+		DecimalScaleFactor:     regulation.ToInt16(def.D),
+		Bits:                   def.L,
+		Type:                   regulation.ToInt8(def.T),
+		CompressionType:        def.CompressionType,
+		TargetCompressionRatio: def.TargetCompressionRatio,
+		NumVals:                numVals,
+	}
+}
+
+func (j *JPEG2000) Definition() any {
+	return definition.JPEG2000{
+		SimplePacking: definition.SimplePacking{
+			R: j.ReferenceValue,
+			B: regulation.ToUint16(j.BinaryScaleFactor),
+			D: regulation.ToUint16(j.DecimalScaleFactor),
+			L: j.Bits,
+			T: regulation.ToUint8(j.Type),
+		},
+		CompressionType:        j.CompressionType,
+		TargetCompressionRatio: j.TargetCompressionRatio,
+	}
+}
+
+func (j *JPEG2000) GetNumVals() int {
+	return j.NumVals
+}
+
+func (j *JPEG2000) ScaleFunc() func(uint32) float32 {
+	return datapacking.SimpleScaleFunc(j.BinaryScaleFactor, j.DecimalScaleFactor, j.ReferenceValue)
+}
+
+func (j *JPEG2000) ReadAllData(r *bitio.Reader) ([]float32, error) {
+	var (
+		values    = make([]float32, j.NumVals)
+		scaleFunc = j.ScaleFunc()
+	)
+
+	// Special case: if bits per value is 0, all values are equal to the scaled reference value
+	if j.Bits == 0 {
+		for i := range values {
+			values[i] = scaleFunc(0)
+		}
+		return values, nil
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read code-stream: %w", err)
+	}
+
+	img, err := jpeg2000.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JPEG 2000: %w", err)
+	}
+
+	if len(img.Data) != j.NumVals {
+		return nil, fmt.Errorf("expected %d values, got %d", j.NumVals, len(img.Data))
+	}
+
+	for i, v := range img.Data {
+		values[i] = scaleFunc(uint32(v))
+	}
+
+	return values, nil
+}
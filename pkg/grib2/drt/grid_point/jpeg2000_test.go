@@ -0,0 +1,23 @@
+package gridpoint_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJPEG2000_ReadAllData_ConstantField(t *testing.T) {
+	// no code-stream follows when all values are equal to the reference value
+	j := gridpoint.NewJPEG2000(definition.JPEG2000{
+		SimplePacking: definition.SimplePacking{R: 2735, D: 1},
+	}, 4)
+
+	values, err := j.ReadAllData(bitio.NewReader(bytes.NewReader(nil)))
+	require.NoError(t, err)
+	assert.Equal(t, []float32{273.5, 273.5, 273.5, 273.5}, values)
+}
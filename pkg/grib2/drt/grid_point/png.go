@@ -1,10 +1,12 @@
 package gridpoint
 
 import (
+	"context"
 	"fmt"
 	"image"
 	"image/png"
 	"io"
+	"sync"
 
 	"github.com/scorix/grib-go/internal/pkg/bitio"
 	"github.com/scorix/grib-go/pkg/grib2/drt/datapacking"
@@ -116,12 +118,18 @@ func (p *PortableNetworkGraphics) Image(r datapacking.BitReader) (image.Image, e
 	return img, nil
 }
 
+// PortableNetworkGraphicsReader reads grid points of PNG packing, the image is decoded once at the
+// first read and kept for the following reads.
 type PortableNetworkGraphicsReader struct {
 	r      io.ReaderAt
 	p      *PortableNetworkGraphics
 	sf     func(uint32) float32
 	offset int64
 	length int64
+
+	once sync.Once
+	img  image.Image
+	err  error
 }
 
 func NewPortableNetworkGraphicsReader(r io.ReaderAt, start, end int64, p *PortableNetworkGraphics) *PortableNetworkGraphicsReader {
@@ -133,3 +141,46 @@ func NewPortableNetworkGraphicsReader(r io.ReaderAt, start, end int64, p *Portab
 		length: end - start,
 	}
 }
+
+func (r *PortableNetworkGraphicsReader) image() (image.Image, error) {
+	r.once.Do(func() {
+		img, err := png.Decode(io.NewSectionReader(r.r, r.offset, r.length))
+		if err != nil {
+			r.err = fmt.Errorf("failed to decode PNG: %w", err)
+			return
+		}
+
+		if bounds := img.Bounds(); bounds.Dx()*bounds.Dy() < r.p.NumVals {
+			r.err = fmt.Errorf("expected %d values, image has %d", r.p.NumVals, bounds.Dx()*bounds.Dy())
+			return
+		}
+
+		r.img = img
+	})
+
+	return r.img, r.err
+}
+
+func (r *PortableNetworkGraphicsReader) ReadGridAt(ctx context.Context, n int) (float32, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if n < 0 || n >= r.p.NumVals {
+		return 0, fmt.Errorf("grid point %d is out of range[0-%d]", n, r.p.NumVals)
+	}
+
+	if r.p.Bits == 0 {
+		return r.p.ReferenceValue, nil
+	}
+
+	img, err := r.image()
+	if err != nil {
+		return 0, err
+	}
+
+	bounds := img.Bounds()
+	cr, cg, cb, ca := img.At(bounds.Min.X+n%bounds.Dx(), bounds.Min.Y+n/bounds.Dx()).RGBA()
+
+	return r.sf(r.p.rgbaToUint32(cr, cg, cb, ca)), nil
+}
@@ -0,0 +1,141 @@
+package gridpoint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/datapacking"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+// RunLengthPacking packs the level of each grid point, a level is followed by the digits of the
+// length of its run when the run is longer than one. Levels range from 1 to MaxLevelValue, level 0
+// is a missing value and values above MaxLevelValue are run length digits in base 2^Bits-1-MaxLevelValue,
+// least significant digit first.
+type RunLengthPacking struct {
+	Bits               uint8    // 12
+	MaxLevelValue      uint16   // 13-14
+	DecimalScaleFactor int8     // 17
+	LevelValues        []uint16 // 18-nn
+	NumVals            int
+}
+
+func NewRunLengthPacking(def definition.RunLengthPacking, numVals int) *RunLengthPacking {
+	return &RunLengthPacking{
+		Bits:               def.L,
+		MaxLevelValue:      def.MaxLevelValue,
+		DecimalScaleFactor: regulation.ToInt8(def.D),
+		LevelValues:        def.LevelValues,
+		NumVals:            numVals,
+	}
+}
+
+// Levels returns the representative value of each level, NaN for the missing level 0.
+func (rl *RunLengthPacking) Levels() []float32 {
+	var (
+		dec    = datapacking.DecimalScaleFactor(int16(rl.DecimalScaleFactor))
+		levels = make([]float32, len(rl.LevelValues)+1)
+	)
+
+	levels[0] = float32(math.NaN())
+	for i, v := range rl.LevelValues {
+		levels[i+1] = float32(float64(v) / dec)
+	}
+
+	return levels
+}
+
+func (rl *RunLengthPacking) ReadAllData(r *bitio.Reader) ([]float32, error) {
+	var (
+		mv     = uint64(rl.MaxLevelValue)
+		levels = rl.Levels()
+		values = make([]float32, 0, rl.NumVals)
+	)
+
+	if rl.Bits == 0 || rl.Bits > 32 || mv >= 1<<rl.Bits-1 {
+		return nil, fmt.Errorf("invalid run length packing: %d bits with maximum level %d", rl.Bits, mv)
+	}
+
+	if int(mv) >= len(levels) {
+		return nil, fmt.Errorf("maximum level %d exceeds the %d level values", mv, len(rl.LevelValues))
+	}
+
+	base := 1<<rl.Bits - 1 - mv
+
+	next := func() (uint64, bool, error) {
+		v, err := r.ReadBits(rl.Bits)
+		if errors.Is(err, io.EOF) {
+			return 0, false, nil
+		}
+
+		if err != nil {
+			return 0, false, err
+		}
+
+		return v, true, nil
+	}
+
+	v, ok, err := next()
+	if err != nil {
+		return nil, err
+	}
+
+	for ok && len(values) < rl.NumVals {
+		if v > mv {
+			return nil, fmt.Errorf("run length without level at value %d", len(values))
+		}
+
+		var (
+			level  = levels[v]
+			run    = uint64(1)
+			factor = uint64(1)
+		)
+
+		for {
+			if v, ok, err = next(); err != nil {
+				return nil, err
+			}
+
+			if !ok || v <= mv {
+				break
+			}
+
+			run += factor * (v - mv - 1)
+			factor *= base
+		}
+
+		if run > uint64(rl.NumVals-len(values)) {
+			return nil, fmt.Errorf("run of %d values at value %d exceeds %d values", run, len(values), rl.NumVals)
+		}
+
+		for range run {
+			values = append(values, level)
+		}
+	}
+
+	if len(values) != rl.NumVals {
+		return nil, fmt.Errorf("expected %d values, got %d", rl.NumVals, len(values))
+	}
+
+	return values, nil
+}
+
+func (rl *RunLengthPacking) GetNumVals() int {
+	return rl.NumVals
+}
+
+func (rl *RunLengthPacking) Definition() any {
+	return definition.RunLengthPacking{
+		RunLengthPackingFixedPart: definition.RunLengthPackingFixedPart{
+			L:                   rl.Bits,
+			MaxLevelValue:       rl.MaxLevelValue,
+			NumberOfLevelValues: uint16(len(rl.LevelValues)),
+			D:                   regulation.ToUint8(rl.DecimalScaleFactor),
+		},
+		LevelValues: rl.LevelValues,
+	}
+}
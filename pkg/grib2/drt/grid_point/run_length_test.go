@@ -0,0 +1,80 @@
+package gridpoint_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRunLengthPacking(numVals int) *gridpoint.RunLengthPacking {
+	// 4 bits values, levels 1 to 3, run length digits in base 12
+	return gridpoint.NewRunLengthPacking(definition.RunLengthPacking{
+		RunLengthPackingFixedPart: definition.RunLengthPackingFixedPart{
+			L:                   4,
+			MaxLevelValue:       3,
+			NumberOfLevelValues: 3,
+			D:                   1,
+		},
+		LevelValues: []uint16{10, 20, 30},
+	}, numVals)
+}
+
+func TestRunLengthPacking_ReadAllData(t *testing.T) {
+	t.Parallel()
+
+	// level 2, level 1 run 5, level 3 run 14 = 1 + 1 + 1*12, missing level run 2
+	data := []byte{0x21, 0x83, 0x55, 0x05}
+
+	values, err := newRunLengthPacking(22).ReadAllData(bitio.NewReader(bytes.NewReader(data)))
+	require.NoError(t, err)
+	require.Len(t, values, 22)
+
+	assert.Equal(t, float32(2), values[0])
+	assert.Equal(t, []float32{1, 1, 1, 1, 1}, values[1:6])
+
+	for _, v := range values[6:20] {
+		assert.Equal(t, float32(3), v)
+	}
+
+	assert.True(t, math.IsNaN(float64(values[20])))
+	assert.True(t, math.IsNaN(float64(values[21])))
+}
+
+func TestRunLengthPacking_ReadAllData_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tpl  *gridpoint.RunLengthPacking
+		data []byte
+	}{
+		{name: "run exceeds values", tpl: newRunLengthPacking(5), data: []byte{0x21, 0x83}},
+		{name: "run without level", tpl: newRunLengthPacking(5), data: []byte{0x81}},
+		{name: "missing values", tpl: newRunLengthPacking(8), data: []byte{0x21, 0x83}},
+		{
+			name: "no run length digits",
+			tpl: &gridpoint.RunLengthPacking{
+				Bits:          2,
+				MaxLevelValue: 3,
+				LevelValues:   []uint16{1, 2, 3},
+				NumVals:       1,
+			},
+			data: []byte{0x40},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tt.tpl.ReadAllData(bitio.NewReader(bytes.NewReader(tt.data)))
+			assert.Error(t, err)
+		})
+	}
+}
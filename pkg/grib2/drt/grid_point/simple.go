@@ -38,10 +38,11 @@ func (sp *SimplePacking) ScaleFunc() func(uint32) float32 {
 }
 
 func (sp *SimplePacking) ReadAllData(r *bitio.Reader) ([]float32, error) {
-	var (
-		values    []float32
-		scaleFunc = sp.ScaleFunc()
-	)
+	return sp.readAllData(r, sp.ScaleFunc())
+}
+
+func (sp *SimplePacking) readAllData(r *bitio.Reader, scaleFunc func(uint32) float32) ([]float32, error) {
+	var values []float32
 
 	if sp.Bits == 0 {
 		for range sp.NumVals {
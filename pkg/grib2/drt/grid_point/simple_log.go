@@ -0,0 +1,61 @@
+package gridpoint
+
+import (
+	"io"
+	"math"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+)
+
+// SimplePackingWithLogPreProcessing is simple packing of the natural logarithm of the values,
+// the original value is Y = exp(X) - B where X is the simple packing value.
+type SimplePackingWithLogPreProcessing struct {
+	SimplePacking
+	PreProcessingParameter float32 // 21-24
+}
+
+func NewSimplePackingWithLogPreProcessing(def definition.SimplePackingWithLogPreProcessing, numVals int) *SimplePackingWithLogPreProcessing {
+	// template 5.61 has no type of original field values
+	sp := definition.SimplePacking{R: def.R, B: def.B, D: def.D, L: def.L}
+
+	return &SimplePackingWithLogPreProcessing{
+		SimplePacking:          *NewSimplePacking(sp, numVals),
+		PreProcessingParameter: def.PreProcessingParameter,
+	}
+}
+
+func (sp *SimplePackingWithLogPreProcessing) ScaleFunc() func(uint32) float32 {
+	var (
+		scaleFunc = sp.SimplePacking.ScaleFunc()
+		b         = float64(sp.PreProcessingParameter)
+	)
+
+	return func(x uint32) float32 {
+		return float32(math.Exp(float64(scaleFunc(x))) - b)
+	}
+}
+
+func (sp *SimplePackingWithLogPreProcessing) ReadAllData(r *bitio.Reader) ([]float32, error) {
+	return sp.readAllData(r, sp.ScaleFunc())
+}
+
+func (sp *SimplePackingWithLogPreProcessing) Definition() any {
+	def := sp.SimplePacking.Definition().(definition.SimplePacking)
+
+	return definition.SimplePackingWithLogPreProcessing{
+		R:                      def.R,
+		B:                      def.B,
+		D:                      def.D,
+		L:                      def.L,
+		PreProcessingParameter: sp.PreProcessingParameter,
+	}
+}
+
+func NewSimplePackingWithLogPreProcessingReader(r io.ReaderAt, start, end int64, sp *SimplePackingWithLogPreProcessing) *SimplePackingReader {
+	return &SimplePackingReader{
+		r:  io.NewSectionReader(r, start, end-start),
+		sp: &sp.SimplePacking,
+		sf: sp.ScaleFunc(),
+	}
+}
@@ -0,0 +1,156 @@
+package spectral
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/datapacking"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+// ComplexPacking stores the coefficients of the subset truncated at JS as IEEE floating point
+// values, the remaining coefficients are multiplied by (n*(n+1))^P and packed with simple packing.
+type ComplexPacking struct {
+	ReferenceValue          float32 // 12-15
+	BinaryScaleFactor       int16   // 16-17
+	DecimalScaleFactor      int16   // 18-19
+	Bits                    uint8   // 20
+	LaplacianScalingFactor  int32   // 21-24, in 10^-6 units
+	JS                      uint16  // 25-26
+	KS                      uint16  // 27-28
+	MS                      uint16  // 29-30
+	TS                      uint32  // 31-34
+	UnpackedSubsetPrecision uint8   // 35
+	NumVals                 int
+}
+
+func NewComplexPacking(def definition.SpectralComplexPacking, numVals int) *ComplexPacking {
+	return &ComplexPacking{
+		ReferenceValue:          def.R,
+		BinaryScaleFactor:       regulation.ToInt16(def.B),
+		DecimalScaleFactor:      regulation.ToInt16(def.D),
+		Bits:                    def.L,
+		LaplacianScalingFactor:  regulation.ToInt32(def.LaplacianScalingFactor),
+		JS:                      def.JS,
+		KS:                      def.KS,
+		MS:                      def.MS,
+		TS:                      def.TS,
+		UnpackedSubsetPrecision: def.UnpackedSubsetPrecision,
+		NumVals:                 numVals,
+	}
+}
+
+func (cp *ComplexPacking) ScaleFunc() func(uint32) float32 {
+	return datapacking.SimpleScaleFunc(cp.BinaryScaleFactor, cp.DecimalScaleFactor, cp.ReferenceValue)
+}
+
+// laplacianScales returns the factors (n*(n+1))^-P that revert the scaling of the packed values.
+func (cp *ComplexPacking) laplacianScales(j int) []float64 {
+	var (
+		p      = float64(cp.LaplacianScalingFactor) * 1e-6
+		scales = make([]float64, j+1)
+	)
+
+	for n := 1; n <= j; n++ {
+		scales[n] = math.Pow(float64(n*(n+1)), -p)
+	}
+
+	return scales
+}
+
+func (cp *ComplexPacking) ReadAllData(r *bitio.Reader) ([]float32, error) {
+	j, err := Truncation(cp.NumVals)
+	if err != nil {
+		return nil, err
+	}
+
+	if cp.JS != cp.KS || cp.JS != cp.MS {
+		return nil, fmt.Errorf("unsupported unpacked subset truncation: JS=%d, KS=%d, MS=%d", cp.JS, cp.KS, cp.MS)
+	}
+
+	js := int(cp.JS)
+	if js > j || int(cp.TS) != (js+1)*(js+2) {
+		return nil, fmt.Errorf("unpacked subset of truncation %d with %d values does not fit truncation %d", js, cp.TS, j)
+	}
+
+	unpacked, err := gridpoint.NewIEEEFloat(definition.IEEEFloat{Precision: cp.UnpackedSubsetPrecision}, int(cp.TS)).ReadAllData(r)
+	if err != nil {
+		return nil, fmt.Errorf("read unpacked subset: %w", err)
+	}
+
+	var (
+		values    = make([]float32, cp.NumVals)
+		scaleFunc = cp.ScaleFunc()
+		scales    = cp.laplacianScales(j)
+		u         int
+	)
+
+	packed := func() (float64, error) {
+		var x uint64
+
+		if cp.Bits > 0 {
+			var err error
+
+			x, err = r.ReadBits(cp.Bits)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		return float64(scaleFunc(uint32(x))), nil
+	}
+
+	for m := 0; m <= j; m++ {
+		for n := m; n <= j; n++ {
+			i := 2 * index(j, m, n)
+
+			if n <= js {
+				values[i], values[i+1] = unpacked[u], unpacked[u+1]
+				u += 2
+
+				continue
+			}
+
+			re, err := packed()
+			if err != nil {
+				return nil, fmt.Errorf("read packed coefficient (%d,%d): %w", m, n, err)
+			}
+
+			im, err := packed()
+			if err != nil {
+				return nil, fmt.Errorf("read packed coefficient (%d,%d): %w", m, n, err)
+			}
+
+			values[i] = float32(re * scales[n])
+
+			// the imaginary parts of the zonal coefficients are packed but always zero
+			if m > 0 {
+				values[i+1] = float32(im * scales[n])
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func (cp *ComplexPacking) GetNumVals() int {
+	return cp.NumVals
+}
+
+func (cp *ComplexPacking) Definition() any {
+	return definition.SpectralComplexPacking{
+		R:                       cp.ReferenceValue,
+		B:                       regulation.ToUint16(cp.BinaryScaleFactor),
+		D:                       regulation.ToUint16(cp.DecimalScaleFactor),
+		L:                       cp.Bits,
+		LaplacianScalingFactor:  regulation.ToUint32(cp.LaplacianScalingFactor),
+		JS:                      cp.JS,
+		KS:                      cp.KS,
+		MS:                      cp.MS,
+		TS:                      cp.TS,
+		UnpackedSubsetPrecision: cp.UnpackedSubsetPrecision,
+	}
+}
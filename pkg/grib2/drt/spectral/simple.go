@@ -0,0 +1,80 @@
+package spectral
+
+import (
+	"fmt"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/datapacking"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+// SimplePacking stores the real part of the (0,0) coefficient apart, all the other values are
+// packed with simple packing.
+type SimplePacking struct {
+	ReferenceValue     float32 // 12-15
+	BinaryScaleFactor  int16   // 16-17
+	DecimalScaleFactor int16   // 18-19
+	Bits               uint8   // 20
+	RealPartOf00       float32 // 21-24
+	NumVals            int
+}
+
+func NewSimplePacking(def definition.SpectralSimplePacking, numVals int) *SimplePacking {
+	return &SimplePacking{
+		ReferenceValue:     def.R,
+		BinaryScaleFactor:  regulation.ToInt16(def.B),
+		DecimalScaleFactor: regulation.ToInt16(def.D),
+		Bits:               def.L,
+		RealPartOf00:       def.RealPartOf00,
+		NumVals:            numVals,
+	}
+}
+
+func (sp *SimplePacking) ScaleFunc() func(uint32) float32 {
+	return datapacking.SimpleScaleFunc(sp.BinaryScaleFactor, sp.DecimalScaleFactor, sp.ReferenceValue)
+}
+
+func (sp *SimplePacking) ReadAllData(r *bitio.Reader) ([]float32, error) {
+	if sp.NumVals == 0 {
+		return nil, nil
+	}
+
+	var (
+		values    = make([]float32, sp.NumVals)
+		scaleFunc = sp.ScaleFunc()
+	)
+
+	values[0] = sp.RealPartOf00
+
+	for i := 1; i < sp.NumVals; i++ {
+		var x uint64
+
+		if sp.Bits > 0 {
+			var err error
+
+			x, err = r.ReadBits(sp.Bits)
+			if err != nil {
+				return nil, fmt.Errorf("expected %d values, got %d: %w", sp.NumVals, i, err)
+			}
+		}
+
+		values[i] = scaleFunc(uint32(x))
+	}
+
+	return values, nil
+}
+
+func (sp *SimplePacking) GetNumVals() int {
+	return sp.NumVals
+}
+
+func (sp *SimplePacking) Definition() any {
+	return definition.SpectralSimplePacking{
+		R:            sp.ReferenceValue,
+		B:            regulation.ToUint16(sp.BinaryScaleFactor),
+		D:            regulation.ToUint16(sp.DecimalScaleFactor),
+		L:            sp.Bits,
+		RealPartOf00: sp.RealPartOf00,
+	}
+}
@@ -0,0 +1,29 @@
+// Package spectral decodes spherical harmonic coefficients packed with data representation
+// templates 5.50 and 5.51, and synthesizes them onto global grids.
+//
+// Only triangular truncations are supported. The coefficients are ordered by zonal wavenumber m,
+// then by total wavenumber n from m to the truncation J, each coefficient is stored as its real
+// part followed by its imaginary part.
+package spectral
+
+import (
+	"fmt"
+	"math"
+)
+
+// Truncation returns the triangular truncation J of numVals coefficient values, numVals must
+// be (J+1)*(J+2).
+func Truncation(numVals int) (int, error) {
+	j := int(math.Sqrt(float64(numVals))) - 1
+	if j < 0 || (j+1)*(j+2) != numVals {
+		return 0, fmt.Errorf("%d values are not the coefficients of a triangular truncation", numVals)
+	}
+
+	return j, nil
+}
+
+// index returns the position of coefficient (m, n) of truncation j, the real part is at
+// 2*index and the imaginary part at 2*index+1.
+func index(j, m, n int) int {
+	return m*(j+1) - m*(m-1)/2 + n - m
+}
@@ -0,0 +1,152 @@
+package spectral_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/scorix/grib-go/internal/pkg/bitio"
+	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
+	"github.com/scorix/grib-go/pkg/grib2/drt/spectral"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		numVals int
+		want    int
+		wantErr bool
+	}{
+		{numVals: 2, want: 0},
+		{numVals: 6, want: 1},
+		{numVals: 640 * 641, want: 639},
+		{numVals: 7, wantErr: true},
+		{numVals: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := spectral.Truncation(tt.numVals)
+		if tt.wantErr {
+			assert.Error(t, err, "%d values", tt.numVals)
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got, "%d values", tt.numVals)
+		}
+	}
+}
+
+func TestSynthesize(t *testing.T) {
+	t.Parallel()
+
+	grid := spectral.RegularLatLon(8, 5)
+	require.Equal(t, []float64{90, 45, 0, -45, -90}, grid.Latitudes)
+
+	// triangular truncation 2: (0,0) (0,1) (0,2) (1,1) (1,2) (2,2)
+	tests := []struct {
+		name   string
+		coeffs map[int]float32
+		want   func(lat, lon float64) float64
+	}{
+		{
+			name:   "constant",
+			coeffs: map[int]float32{0: 5},
+			want:   func(lat, lon float64) float64 { return 5 },
+		},
+		{
+			name:   "zonal",
+			coeffs: map[int]float32{2: 1},
+			want:   func(lat, lon float64) float64 { return math.Sqrt(3) * math.Sin(lat) },
+		},
+		{
+			name:   "real part of (1,1)",
+			coeffs: map[int]float32{6: 1},
+			want:   func(lat, lon float64) float64 { return math.Sqrt(6) * math.Cos(lat) * math.Cos(lon) },
+		},
+		{
+			name:   "imaginary part of (1,1)",
+			coeffs: map[int]float32{7: 1},
+			want:   func(lat, lon float64) float64 { return -math.Sqrt(6) * math.Cos(lat) * math.Sin(lon) },
+		},
+		{
+			name:   "real part of (2,2)",
+			coeffs: map[int]float32{10: 1},
+			want: func(lat, lon float64) float64 {
+				return math.Sqrt(15.0/8) * 2 * math.Pow(math.Cos(lat), 2) * math.Cos(2*lon)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coeffs := make([]float32, 12)
+			for i, v := range tt.coeffs {
+				coeffs[i] = v
+			}
+
+			values, err := spectral.Synthesize(coeffs, grid)
+			require.NoError(t, err)
+			require.Len(t, values, grid.Len())
+
+			for n, v := range values {
+				lat, lon := grid.Point(n)
+				assert.InDelta(t, tt.want(lat*math.Pi/180, lon*math.Pi/180), v, 1e-5, "lat: %f, lon: %f", lat, lon)
+			}
+		})
+	}
+
+	_, err := spectral.Synthesize(make([]float32, 7), grid)
+	assert.Error(t, err)
+}
+
+func TestSimplePacking_ReadAllData(t *testing.T) {
+	t.Parallel()
+
+	sp := spectral.NewSimplePacking(definition.SpectralSimplePacking{
+		R:            -2,
+		B:            0x8001, // E = -1
+		L:            4,
+		RealPartOf00: 11.5,
+	}, 6)
+
+	values, err := sp.ReadAllData(bitio.NewReader(bytes.NewReader([]byte{0x04, 0x8f, 0x30})))
+	require.NoError(t, err)
+	assert.Equal(t, []float32{11.5, -2, 0, 2, 5.5, -0.5}, values)
+
+	_, err = sp.ReadAllData(bitio.NewReader(bytes.NewReader([]byte{0x04})))
+	assert.Error(t, err)
+}
+
+func TestComplexPacking_ReadAllData(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	// unpacked subset of truncation 1: (0,0) (0,1) (1,1)
+	require.NoError(t, binary.Write(&buf, binary.BigEndian, []float32{11.5, 0, 0.25, 0, 0.5, -0.5}))
+	// packed (0,2) (1,2) (2,2), scaled by (2*3)^P with P = 1
+	buf.Write([]byte{12, 99, 6, 18, 24, 30})
+
+	cp := spectral.NewComplexPacking(definition.SpectralComplexPacking{
+		L:                       8,
+		LaplacianScalingFactor:  1000000,
+		JS:                      1,
+		KS:                      1,
+		MS:                      1,
+		TS:                      6,
+		UnpackedSubsetPrecision: 1,
+	}, 12)
+
+	values, err := cp.ReadAllData(bitio.NewReader(&buf))
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float32{11.5, 0, 0.25, 0, 2, 0, 0.5, -0.5, 1, 3, 4, 5}, values, 1e-6)
+
+	cp.TS = 4
+	_, err = cp.ReadAllData(bitio.NewReader(bytes.NewReader(nil)))
+	assert.Error(t, err)
+}
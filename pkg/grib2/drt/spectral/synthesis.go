@@ -0,0 +1,143 @@
+package spectral
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/scorix/grib-go/internal/pkg/quadrature"
+)
+
+// Grid is a global grid whose rows of points are evenly spaced in longitude from the Greenwich
+// meridian eastward. Grid point values are ordered row by row, as listed in Latitudes.
+type Grid struct {
+	Latitudes []float64 // latitude of each row, in degrees
+	Ni        int       // number of points along each row
+}
+
+// RegularLatLon returns the grid of ni x nj points, its rows go from the north pole to the south pole.
+func RegularLatLon(ni, nj int) Grid {
+	lats := make([]float64, nj)
+
+	for i := range lats {
+		if nj == 1 {
+			break
+		}
+
+		lats[i] = 90 - 180*float64(i)/float64(nj-1)
+	}
+
+	return Grid{Latitudes: lats, Ni: ni}
+}
+
+// RegularGaussian returns the grid of 4n x 2n points whose rows are at the latitudes of the
+// Gaussian grid with n parallels between a pole and the equator, from north to south.
+func RegularGaussian(n int) Grid {
+	return Grid{Latitudes: slices.Clone(quadrature.GaussianLatitudes(n)), Ni: 4 * n}
+}
+
+// DefaultGrid returns the linear regular Gaussian grid of truncation j, whose 2n parallels
+// resolve the j+1 total wavenumbers.
+func DefaultGrid(j int) Grid {
+	return RegularGaussian((j + 2) / 2)
+}
+
+// Len returns the number of grid points.
+func (g Grid) Len() int {
+	return len(g.Latitudes) * g.Ni
+}
+
+// Point returns the latitude and longitude of the nth grid point.
+func (g Grid) Point(n int) (float64, float64) {
+	return g.Latitudes[n/g.Ni], 360 * float64(n%g.Ni) / float64(g.Ni)
+}
+
+// legendre fills p with the associated Legendre functions at mu of truncation j, at the
+// positions of the coefficients. The functions are normalized so that their mean square
+// over the sphere is 1, P(0,0) = 1, without the Condon-Shortley phase.
+func legendre(p []float64, j int, mu float64) {
+	var (
+		sin = math.Sqrt(1 - mu*mu)
+		pmm = 1.0
+	)
+
+	for m := 0; m <= j; m++ {
+		if m > 0 {
+			pmm *= math.Sqrt(float64(2*m+1)/float64(2*m)) * sin
+		}
+
+		p[index(j, m, m)] = pmm
+		if m == j {
+			break
+		}
+
+		p[index(j, m, m+1)] = math.Sqrt(float64(2*m+3)) * mu * pmm
+
+		for n := m + 2; n <= j; n++ {
+			var (
+				nn = float64(n * n)
+				mm = float64(m * m)
+				a  = math.Sqrt((4*nn - 1) / (nn - mm))
+				b  = math.Sqrt((float64((n-1)*(n-1)) - mm) / (4*float64((n-1)*(n-1)) - 1))
+			)
+
+			p[index(j, m, n)] = a * (mu*p[index(j, m, n-1)] - b*p[index(j, m, n-2)])
+		}
+	}
+}
+
+// Synthesize computes the values of the field at the points of g from its spherical harmonic
+// coefficients, as ordered by the data representation templates:
+//
+//	f(lat, lon) = sum(n, m) P(m,n)(sin(lat)) * (Re(m,n) * cos(m*lon) - Im(m,n) * sin(m*lon)) * (m > 0 ? 2 : 1)
+func Synthesize(coefficients []float32, g Grid) ([]float32, error) {
+	j, err := Truncation(len(coefficients))
+	if err != nil {
+		return nil, err
+	}
+
+	if g.Ni <= 0 {
+		return nil, fmt.Errorf("invalid number of points along a row: %d", g.Ni)
+	}
+
+	var (
+		values = make([]float32, g.Len())
+		p      = make([]float64, len(coefficients)/2)
+		re     = make([]float64, j+1)
+		im     = make([]float64, j+1)
+		cos    = make([]float64, g.Ni)
+		sin    = make([]float64, g.Ni)
+	)
+
+	for i := range cos {
+		cos[i], sin[i] = math.Cos(2*math.Pi*float64(i)/float64(g.Ni)), math.Sin(2*math.Pi*float64(i)/float64(g.Ni))
+	}
+
+	for row, lat := range g.Latitudes {
+		legendre(p, j, math.Sin(lat*math.Pi/180))
+
+		// Fourier coefficients of the row
+		for m := 0; m <= j; m++ {
+			re[m], im[m] = 0, 0
+
+			for n := m; n <= j; n++ {
+				k := index(j, m, n)
+				re[m] += float64(coefficients[2*k]) * p[k]
+				im[m] += float64(coefficients[2*k+1]) * p[k]
+			}
+		}
+
+		for i := 0; i < g.Ni; i++ {
+			v := re[0]
+
+			for m := 1; m <= j; m++ {
+				k := m * i % g.Ni
+				v += 2 * (re[m]*cos[k] - im[m]*sin[k])
+			}
+
+			values[row*g.Ni+i] = float32(v)
+		}
+	}
+
+	return values, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/scorix/grib-go/pkg/grib2/drt/datapacking"
 	"github.com/scorix/grib-go/pkg/grib2/drt/definition"
 	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
+	"github.com/scorix/grib-go/pkg/grib2/drt/spectral"
 )
 
 type TemplateNumber uint16
@@ -72,6 +73,24 @@ func ReadTemplate(r datapacking.BitReader, n TemplateNumber, numVals int) (Templ
 
 		return gridpoint.NewComplexPackingAndSpatialDifferencing(tplDef, numVals), nil
 
+	case GridPointDataIEEEFloatingPointData:
+		var tplDef definition.IEEEFloat
+
+		if err := binary.Read(r, binary.BigEndian, &tplDef); err != nil {
+			return nil, err
+		}
+
+		return gridpoint.NewIEEEFloat(tplDef, numVals), nil
+
+	case GridPointDataJPEG2000CodeStreamFormat:
+		var tplDef definition.JPEG2000
+
+		if err := binary.Read(r, binary.BigEndian, &tplDef); err != nil {
+			return nil, err
+		}
+
+		return gridpoint.NewJPEG2000(tplDef, numVals), nil
+
 	case GridPointDataPNG:
 		var tplDef definition.PNG
 
@@ -80,6 +99,56 @@ func ReadTemplate(r datapacking.BitReader, n TemplateNumber, numVals int) (Templ
 		}
 
 		return gridpoint.NewPortableNetworkGraphics(tplDef, numVals), nil
+
+	case GridPointDataCCSDS:
+		var tplDef definition.CCSDS
+
+		if err := binary.Read(r, binary.BigEndian, &tplDef); err != nil {
+			return nil, err
+		}
+
+		return gridpoint.NewCCSDS(tplDef, numVals), nil
+
+	case SpectralDataSimplePacking:
+		var tplDef definition.SpectralSimplePacking
+
+		if err := binary.Read(r, binary.BigEndian, &tplDef); err != nil {
+			return nil, err
+		}
+
+		return spectral.NewSimplePacking(tplDef, numVals), nil
+
+	case SpectralDataComplexPacking:
+		var tplDef definition.SpectralComplexPacking
+
+		if err := binary.Read(r, binary.BigEndian, &tplDef); err != nil {
+			return nil, err
+		}
+
+		return spectral.NewComplexPacking(tplDef, numVals), nil
+
+	case GridPointDataSimplePackingWithLogarithmPreProcessing:
+		var tplDef definition.SimplePackingWithLogPreProcessing
+
+		if err := binary.Read(r, binary.BigEndian, &tplDef); err != nil {
+			return nil, err
+		}
+
+		return gridpoint.NewSimplePackingWithLogPreProcessing(tplDef, numVals), nil
+
+	case RunLengthPackingWithLevelValues:
+		var tplDef definition.RunLengthPacking
+
+		if err := binary.Read(r, binary.BigEndian, &tplDef.RunLengthPackingFixedPart); err != nil {
+			return nil, err
+		}
+
+		tplDef.LevelValues = make([]uint16, tplDef.NumberOfLevelValues)
+		if err := binary.Read(r, binary.BigEndian, tplDef.LevelValues); err != nil {
+			return nil, fmt.Errorf("read %d level values: %w", tplDef.NumberOfLevelValues, err)
+		}
+
+		return gridpoint.NewRunLengthPacking(tplDef, numVals), nil
 	}
 
 	return nil, fmt.Errorf("data template not implemented: %d", n)
@@ -110,8 +179,22 @@ func (tm TemplateMarshaler) MarshalJSON() ([]byte, error) {
 		tplNum = GridPointDataComplexPacking
 	case *gridpoint.ComplexPackingAndSpatialDifferencing:
 		tplNum = GridPointDataComplexPackingAndSpatialDifferencing
+	case *gridpoint.IEEEFloat:
+		tplNum = GridPointDataIEEEFloatingPointData
+	case *gridpoint.JPEG2000:
+		tplNum = GridPointDataJPEG2000CodeStreamFormat
 	case *gridpoint.PortableNetworkGraphics:
 		tplNum = GridPointDataPNG
+	case *gridpoint.CCSDS:
+		tplNum = GridPointDataCCSDS
+	case *spectral.SimplePacking:
+		tplNum = SpectralDataSimplePacking
+	case *spectral.ComplexPacking:
+		tplNum = SpectralDataComplexPacking
+	case *gridpoint.SimplePackingWithLogPreProcessing:
+		tplNum = GridPointDataSimplePackingWithLogarithmPreProcessing
+	case *gridpoint.RunLengthPacking:
+		tplNum = RunLengthPackingWithLevelValues
 	}
 
 	return json.Marshal(templateMarshaler{
@@ -159,6 +242,26 @@ func (tm *TemplateMarshaler) UnmarshalJSON(data []byte) error {
 		tm.Template = gridpoint.NewComplexPackingAndSpatialDifferencing(tplDef, t.Vals)
 		return nil
 
+	case GridPointDataIEEEFloatingPointData:
+		var tplDef definition.IEEEFloat
+
+		if err := json.Unmarshal(t.Content, &tplDef); err != nil {
+			return err
+		}
+
+		tm.Template = gridpoint.NewIEEEFloat(tplDef, t.Vals)
+		return nil
+
+	case GridPointDataJPEG2000CodeStreamFormat:
+		var tplDef definition.JPEG2000
+
+		if err := json.Unmarshal(t.Content, &tplDef); err != nil {
+			return err
+		}
+
+		tm.Template = gridpoint.NewJPEG2000(tplDef, t.Vals)
+		return nil
+
 	case GridPointDataPNG:
 		var tplDef definition.PNG
 
@@ -168,6 +271,56 @@ func (tm *TemplateMarshaler) UnmarshalJSON(data []byte) error {
 
 		tm.Template = gridpoint.NewPortableNetworkGraphics(tplDef, t.Vals)
 		return nil
+
+	case GridPointDataCCSDS:
+		var tplDef definition.CCSDS
+
+		if err := json.Unmarshal(t.Content, &tplDef); err != nil {
+			return err
+		}
+
+		tm.Template = gridpoint.NewCCSDS(tplDef, t.Vals)
+		return nil
+
+	case SpectralDataSimplePacking:
+		var tplDef definition.SpectralSimplePacking
+
+		if err := json.Unmarshal(t.Content, &tplDef); err != nil {
+			return err
+		}
+
+		tm.Template = spectral.NewSimplePacking(tplDef, t.Vals)
+		return nil
+
+	case SpectralDataComplexPacking:
+		var tplDef definition.SpectralComplexPacking
+
+		if err := json.Unmarshal(t.Content, &tplDef); err != nil {
+			return err
+		}
+
+		tm.Template = spectral.NewComplexPacking(tplDef, t.Vals)
+		return nil
+
+	case GridPointDataSimplePackingWithLogarithmPreProcessing:
+		var tplDef definition.SimplePackingWithLogPreProcessing
+
+		if err := json.Unmarshal(t.Content, &tplDef); err != nil {
+			return err
+		}
+
+		tm.Template = gridpoint.NewSimplePackingWithLogPreProcessing(tplDef, t.Vals)
+		return nil
+
+	case RunLengthPackingWithLevelValues:
+		var tplDef definition.RunLengthPacking
+
+		if err := json.Unmarshal(t.Content, &tplDef); err != nil {
+			return err
+		}
+
+		tm.Template = gridpoint.NewRunLengthPacking(tplDef, t.Vals)
+		return nil
 	}
 
 	return fmt.Errorf("data template not implemented: %d", t.Number)
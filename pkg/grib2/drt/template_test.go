@@ -1,10 +1,13 @@
 package drt_test
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/scorix/grib-go/internal/pkg/bitio"
 	"github.com/scorix/grib-go/pkg/grib2/drt"
 	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
+	"github.com/scorix/grib-go/pkg/grib2/drt/spectral"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,6 +34,104 @@ func TestTemplateMarshaler_MarshalJSON(t *testing.T) {
 			},
 			want: `{"number":0,"content":{"r":1.5,"b":2,"d":3,"l":16,"t":0},"vals":1038240}`,
 		},
+		{
+			name: "ieee float",
+			fields: drt.TemplateMarshaler{
+				Template: &gridpoint.IEEEFloat{
+					Precision: 2,
+					NumVals:   65160,
+				},
+			},
+			want: `{"number":4,"content":{"precision":2},"vals":65160}`,
+		},
+		{
+			name: "jpeg2000",
+			fields: drt.TemplateMarshaler{
+				Template: &gridpoint.JPEG2000{
+					DecimalScaleFactor:     2,
+					Bits:                   16,
+					CompressionType:        gridpoint.JPEG2000Lossless,
+					TargetCompressionRatio: 255,
+					NumVals:                574159,
+				},
+			},
+			want: `{"number":40,"content":{"r":0,"b":0,"d":2,"l":16,"t":0,"compressionType":0,"targetCompressionRatio":255},"vals":574159}`,
+		},
+		{
+			name: "ccsds",
+			fields: drt.TemplateMarshaler{
+				Template: &gridpoint.CCSDS{
+					ReferenceValue:          101325,
+					BinaryScaleFactor:       -3,
+					Bits:                    16,
+					Flags:                   14,
+					BlockSize:               32,
+					ReferenceSampleInterval: 128,
+					NumVals:                 1038240,
+				},
+			},
+			want: `{"number":42,"content":{"r":101325,"b":32771,"d":0,"l":16,"t":0,"flags":14,"blockSize":32,"referenceSampleInterval":128},"vals":1038240}`,
+		},
+		{
+			name: "simple packing with log pre-processing",
+			fields: drt.TemplateMarshaler{
+				Template: &gridpoint.SimplePackingWithLogPreProcessing{
+					SimplePacking: gridpoint.SimplePacking{
+						ReferenceValue:    -2.5,
+						BinaryScaleFactor: -10,
+						Bits:              12,
+						NumVals:           1038240,
+					},
+					PreProcessingParameter: 0.5,
+				},
+			},
+			want: `{"number":61,"content":{"r":-2.5,"b":32778,"d":0,"l":12,"preProcessingParameter":0.5},"vals":1038240}`,
+		},
+		{
+			name: "spectral simple packing",
+			fields: drt.TemplateMarshaler{
+				Template: &spectral.SimplePacking{
+					ReferenceValue:     -0.25,
+					BinaryScaleFactor:  -20,
+					DecimalScaleFactor: 0,
+					Bits:               16,
+					RealPartOf00:       11.5,
+					NumVals:            2162,
+				},
+			},
+			want: `{"number":50,"content":{"r":-0.25,"b":32788,"d":0,"l":16,"realPartOf00":11.5},"vals":2162}`,
+		},
+		{
+			name: "spectral complex packing",
+			fields: drt.TemplateMarshaler{
+				Template: &spectral.ComplexPacking{
+					ReferenceValue:          -0.25,
+					BinaryScaleFactor:       -20,
+					Bits:                    16,
+					LaplacianScalingFactor:  -500000,
+					JS:                      20,
+					KS:                      20,
+					MS:                      20,
+					TS:                      462,
+					UnpackedSubsetPrecision: 1,
+					NumVals:                 411522,
+				},
+			},
+			want: `{"number":51,"content":{"r":-0.25,"b":32788,"d":0,"l":16,"laplacianScalingFactor":2147983648,"js":20,"ks":20,"ms":20,"ts":462,"unpackedSubsetPrecision":1},"vals":411522}`,
+		},
+		{
+			name: "run length packing",
+			fields: drt.TemplateMarshaler{
+				Template: &gridpoint.RunLengthPacking{
+					Bits:               4,
+					MaxLevelValue:      3,
+					DecimalScaleFactor: 1,
+					LevelValues:        []uint16{10, 20, 30},
+					NumVals:            22,
+				},
+			},
+			want: `{"number":200,"content":{"l":4,"maxLevelValue":3,"numberOfLevelValues":3,"d":1,"levelValues":[10,20,30]},"vals":22}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -68,6 +169,89 @@ func TestTemplateMarshaler_UnmarshalJSON(t *testing.T) {
 				NumVals:            1038240,
 			},
 		},
+		{
+			name: "ieee float",
+			json: `{"number":4,"content":{"precision":2},"vals":65160}`,
+			want: &gridpoint.IEEEFloat{
+				Precision: 2,
+				NumVals:   65160,
+			},
+		},
+		{
+			name: "jpeg2000",
+			json: `{"number":40,"content":{"r":0,"b":0,"d":2,"l":16,"t":0,"compressionType":0,"targetCompressionRatio":255},"vals":574159}`,
+			want: &gridpoint.JPEG2000{
+				DecimalScaleFactor:     2,
+				Bits:                   16,
+				CompressionType:        gridpoint.JPEG2000Lossless,
+				TargetCompressionRatio: 255,
+				NumVals:                574159,
+			},
+		},
+		{
+			name: "ccsds",
+			json: `{"number":42,"content":{"r":101325,"b":32771,"d":0,"l":16,"t":0,"flags":14,"blockSize":32,"referenceSampleInterval":128},"vals":1038240}`,
+			want: &gridpoint.CCSDS{
+				ReferenceValue:          101325,
+				BinaryScaleFactor:       -3,
+				Bits:                    16,
+				Flags:                   14,
+				BlockSize:               32,
+				ReferenceSampleInterval: 128,
+				NumVals:                 1038240,
+			},
+		},
+		{
+			name: "simple packing with log pre-processing",
+			json: `{"number":61,"content":{"r":-2.5,"b":32778,"d":0,"l":12,"preProcessingParameter":0.5},"vals":1038240}`,
+			want: &gridpoint.SimplePackingWithLogPreProcessing{
+				SimplePacking: gridpoint.SimplePacking{
+					ReferenceValue:    -2.5,
+					BinaryScaleFactor: -10,
+					Bits:              12,
+					NumVals:           1038240,
+				},
+				PreProcessingParameter: 0.5,
+			},
+		},
+		{
+			name: "spectral simple packing",
+			json: `{"number":50,"content":{"r":-0.25,"b":32788,"d":0,"l":16,"realPartOf00":11.5},"vals":2162}`,
+			want: &spectral.SimplePacking{
+				ReferenceValue:    -0.25,
+				BinaryScaleFactor: -20,
+				Bits:              16,
+				RealPartOf00:      11.5,
+				NumVals:           2162,
+			},
+		},
+		{
+			name: "spectral complex packing",
+			json: `{"number":51,"content":{"r":-0.25,"b":32788,"d":0,"l":16,"laplacianScalingFactor":2147983648,"js":20,"ks":20,"ms":20,"ts":462,"unpackedSubsetPrecision":1},"vals":411522}`,
+			want: &spectral.ComplexPacking{
+				ReferenceValue:          -0.25,
+				BinaryScaleFactor:       -20,
+				Bits:                    16,
+				LaplacianScalingFactor:  -500000,
+				JS:                      20,
+				KS:                      20,
+				MS:                      20,
+				TS:                      462,
+				UnpackedSubsetPrecision: 1,
+				NumVals:                 411522,
+			},
+		},
+		{
+			name: "run length packing",
+			json: `{"number":200,"content":{"l":4,"maxLevelValue":3,"numberOfLevelValues":3,"d":1,"levelValues":[10,20,30]},"vals":22}`,
+			want: &gridpoint.RunLengthPacking{
+				Bits:               4,
+				MaxLevelValue:      3,
+				DecimalScaleFactor: 1,
+				LevelValues:        []uint16{10, 20, 30},
+				NumVals:            22,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -86,3 +270,26 @@ func TestTemplateMarshaler_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestReadTemplate_LogPreProcessing(t *testing.T) {
+	// octets 12-24 of template 5.61, the pre-processing parameter follows the number of bits
+	octets := []byte{
+		0xc0, 0x20, 0x00, 0x00, // R = -2.5
+		0x80, 0x0a, // E = -10
+		0x00, 0x00, // D = 0
+		0x0c,                   // 12 bits
+		0x3f, 0x00, 0x00, 0x00, // B = 0.5
+	}
+
+	tpl, err := drt.ReadTemplate(bitio.NewReader(bytes.NewReader(octets)), 61, 1038240)
+	require.NoError(t, err)
+	assert.Equal(t, &gridpoint.SimplePackingWithLogPreProcessing{
+		SimplePacking: gridpoint.SimplePacking{
+			ReferenceValue:    -2.5,
+			BinaryScaleFactor: -10,
+			Bits:              12,
+			NumVals:           1038240,
+		},
+		PreProcessingParameter: 0.5,
+	}, tpl)
+}
@@ -0,0 +1,116 @@
+package gdt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// angleUnit returns the unit in degrees of the extreme latitudes and longitudes, and direction
+// increments of latitude/longitude and Gaussian grids: the basic angle of the initial production
+// domain divided by its subdivisions, zero and missing values stand for 1 and 10^6 respectively.
+func angleUnit(basicAngle, subdivisions int32) (float64, float64) {
+	if basicAngle <= 0 {
+		basicAngle = 1
+	}
+
+	if subdivisions <= 0 {
+		subdivisions = 1e6
+	}
+
+	return float64(basicAngle), float64(subdivisions)
+}
+
+// degrees returns v in units of basicAngle/subdivisions degrees.
+func degrees(v, basicAngle, subdivisions int32) float64 {
+	multiplier, divisor := angleUnit(basicAngle, subdivisions)
+
+	return float64(v) * multiplier / divisor
+}
+
+// ErrNoGridPoints is reported by the validation of grids whose points are not given by the grid
+// definition but by other fields or files, when they are not available.
+var ErrNoGridPoints = errors.New("grid points are not available")
+
+// Validator is implemented by templates which can check the consistency of their grid definition.
+type Validator interface {
+	Validate() error
+}
+
+// Validate reports the inconsistencies of the grid definition of tpl, it returns nil if tpl can not be
+// validated. The messages are validated when they are read with the grib2 option WithGridValidation.
+func Validate(tpl Template) error {
+	if v, ok := tpl.(Validator); ok {
+		return v.Validate()
+	}
+
+	return nil
+}
+
+// checkLatitudes reports latitudes out of [-90, 90] and a last grid point which is not in the j
+// scanning direction from the first one.
+func checkLatitudes(lat1, lat2 float64, nj int32, mode scanMode) error {
+	var errs []error
+
+	for _, lat := range []float64{lat1, lat2} {
+		if math.Abs(lat) > 90+1e-9 {
+			errs = append(errs, fmt.Errorf("latitude %f is out of [-90, 90]", lat))
+		}
+	}
+
+	if nj > 1 && mode.jSign()*(lat2-lat1) < 0 {
+		errs = append(errs, fmt.Errorf("latitude of last grid point %f is not in the j scanning direction from %f", lat2, lat1))
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkIncrement reports n points spaced by d degrees which do not span the extent from the first to
+// the last grid point, the rounding of each coded value is tolerated.
+func checkIncrement(direction string, n int32, d, extent, unit float64) error {
+	if d <= 0 {
+		return fmt.Errorf("%s direction increment %f is not positive", direction, d)
+	}
+
+	var (
+		span      = float64(n-1) * d
+		tolerance = float64(n) * unit
+	)
+
+	if math.Abs(span-extent) > tolerance {
+		return fmt.Errorf("%d points in the %s direction spaced by %f span %f degrees, but the first and last grid points are %f degrees apart", n, direction, d, span, extent)
+	}
+
+	return nil
+}
+
+// longitudeExtent returns the longitudes from lon1 to lon2 in the i scanning direction, a span of 360
+// degrees is kept when the grid repeats its first meridian.
+func longitudeExtent(lon1, lon2 float64, ni int32, di float64, mode scanMode) float64 {
+	extent := normalizeLongitude(mode.iSign() * (lon2 - lon1))
+	if extent < 1e-9 && ni > 1 && float64(ni-1)*di > 180 {
+		return 360
+	}
+
+	return extent
+}
+
+// checkPointsPerRow reports a list of number of points which does not have a row for each parallel, or
+// whose interpretation is not supported.
+func checkPointsPerRow(nj int32, points []int32, interpretation int8) error {
+	if interpretation != pointsOfFullCircles && interpretation != pointsBetweenExtremes {
+		return fmt.Errorf("unsupported interpretation of list of numbers of points: %d", interpretation)
+	}
+
+	if int(nj) != len(points) {
+		return fmt.Errorf("quasi-regular grid has %d parallels, but the number of points of %d rows", nj, len(points))
+	}
+
+	for j, p := range points {
+		if p <= 0 {
+			return fmt.Errorf("row %d of quasi-regular grid has %d points", j, p)
+		}
+	}
+
+	return nil
+}
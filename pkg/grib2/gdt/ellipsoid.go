@@ -0,0 +1,118 @@
+package gdt
+
+import "math"
+
+// Ellipsoid is the shape of the Earth, an oblate spheroid whose axes are in metres, or a sphere if
+// they are equal.
+type Ellipsoid struct {
+	SemiMajorAxis float64 `json:"semiMajorAxis"`
+	SemiMinorAxis float64 `json:"semiMinorAxis"`
+}
+
+// NewEllipsoid returns the shape of the Earth of code table 3.2, the radius of a spherical Earth and
+// the axes of an oblate spheroid Earth are scaled values, see regulation 92.1.12.
+// https://codes.ecmwf.int/grib/format/grib2/ctables/3/2/
+func NewEllipsoid(shape int8, radiusFactor int8, radiusValue int32, majorFactor int8, majorValue int32, minorFactor int8, minorValue int32) Ellipsoid {
+	scaled := func(factor int8, value int32) float64 {
+		return float64(value) / math.Pow10(int(factor))
+	}
+
+	sphere := func(r float64) Ellipsoid {
+		return Ellipsoid{SemiMajorAxis: r, SemiMinorAxis: r}
+	}
+
+	flattened := func(a, invf float64) Ellipsoid {
+		return Ellipsoid{SemiMajorAxis: a, SemiMinorAxis: a * (1 - 1/invf)}
+	}
+
+	switch shape {
+	case 0:
+		return sphere(6367470)
+	case 1:
+		return sphere(scaled(radiusFactor, radiusValue))
+	case 2:
+		// IAU in 1965
+		return Ellipsoid{SemiMajorAxis: 6378160, SemiMinorAxis: 6356775}
+	case 3:
+		// axes in km
+		return Ellipsoid{SemiMajorAxis: scaled(majorFactor, majorValue) * 1000, SemiMinorAxis: scaled(minorFactor, minorValue) * 1000}
+	case 4:
+		// IAG-GRS80
+		return flattened(6378137, 298.257222101)
+	case 5, 10:
+		// WGS84
+		return flattened(6378137, 298.257223563)
+	case 7:
+		return Ellipsoid{SemiMajorAxis: scaled(majorFactor, majorValue), SemiMinorAxis: scaled(minorFactor, minorValue)}
+	case 8:
+		return sphere(6371200)
+	case 9:
+		// OSGB 1936 Datum, based on the Airy 1830 ellipsoid
+		return Ellipsoid{SemiMajorAxis: 6377563.396, SemiMinorAxis: 6356256.909}
+	}
+
+	return sphere(6371229)
+}
+
+// IsSphere reports whether the axes of the Earth are equal.
+func (e Ellipsoid) IsSphere() bool {
+	return e.SemiMajorAxis == e.SemiMinorAxis
+}
+
+// Flattening returns (a - b) / a.
+func (e Ellipsoid) Flattening() float64 {
+	return (e.SemiMajorAxis - e.SemiMinorAxis) / e.SemiMajorAxis
+}
+
+// Eccentricity returns the first eccentricity, sqrt(1 - b^2 / a^2).
+func (e Ellipsoid) Eccentricity() float64 {
+	return math.Sqrt(1 - e.SemiMinorAxis*e.SemiMinorAxis/(e.SemiMajorAxis*e.SemiMajorAxis))
+}
+
+// The conformal projections follow Snyder, Map Projections: A Working Manual (1987), they reduce to
+// the spherical formulas when the eccentricity is 0.
+
+// tsfn returns t, the tangent of half the colatitude of the conformal latitude of phi radians, eq. 15-9.
+func (e Ellipsoid) tsfn(phi float64) float64 {
+	var (
+		ecc  = e.Eccentricity()
+		esin = ecc * math.Sin(phi)
+	)
+
+	return math.Tan(math.Pi/4-phi/2) / math.Pow((1-esin)/(1+esin), ecc/2)
+}
+
+// msfn returns m, the radius of the parallel of phi radians divided by the semi-major axis, eq. 14-15.
+func (e Ellipsoid) msfn(phi float64) float64 {
+	var (
+		ecc  = e.Eccentricity()
+		esin = ecc * math.Sin(phi)
+	)
+
+	return math.Cos(phi) / math.Sqrt(1-esin*esin)
+}
+
+// phi returns the latitude in radians whose t is ts, the inverse of tsfn, eq. 7-9.
+func (e Ellipsoid) phi(ts float64) float64 {
+	var (
+		ecc = e.Eccentricity()
+		phi = math.Pi/2 - 2*math.Atan(ts)
+	)
+
+	if ecc == 0 {
+		return phi
+	}
+
+	for range 16 {
+		esin := ecc * math.Sin(phi)
+		next := math.Pi/2 - 2*math.Atan(ts*math.Pow((1-esin)/(1+esin), ecc/2))
+
+		if math.Abs(next-phi) < 1e-14 {
+			return next
+		}
+
+		phi = next
+	}
+
+	return phi
+}
@@ -0,0 +1,67 @@
+package gdt_test
+
+import (
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEllipsoid(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape gdt.Template0FixedPart
+		a, b  float64
+	}{
+		{name: "sphere of radius 6367470 m", shape: gdt.Template0FixedPart{ShapeOfTheEarth: 0}, a: 6367470, b: 6367470},
+		{
+			name:  "sphere of specified radius",
+			shape: gdt.Template0FixedPart{ShapeOfTheEarth: 1, ScaleFactorOfRadiusOfSphericalEarth: 1, ScaledValueOfRadiusOfSphericalEarth: 63710000},
+			a:     6371000,
+			b:     6371000,
+		},
+		{name: "IAU 1965", shape: gdt.Template0FixedPart{ShapeOfTheEarth: 2}, a: 6378160, b: 6356775},
+		{
+			name:  "specified axes in km",
+			shape: gdt.Template0FixedPart{ShapeOfTheEarth: 3, ScaleFactorOfEarthMajorAxis: 3, ScaledValueOfEarthMajorAxis: 6378137, ScaleFactorOfEarthMinorAxis: 3, ScaledValueOfEarthMinorAxis: 6356752},
+			a:     6378137,
+			b:     6356752,
+		},
+		{name: "IAG-GRS80", shape: gdt.Template0FixedPart{ShapeOfTheEarth: 4}, a: 6378137, b: 6356752.314140},
+		{name: "WGS84", shape: gdt.Template0FixedPart{ShapeOfTheEarth: 5}, a: 6378137, b: 6356752.314245},
+		{name: "sphere of radius 6371229 m", shape: gdt.Template0FixedPart{ShapeOfTheEarth: 6}, a: 6371229, b: 6371229},
+		{
+			name:  "specified axes in m",
+			shape: gdt.Template0FixedPart{ShapeOfTheEarth: 7, ScaleFactorOfEarthMajorAxis: 1, ScaledValueOfEarthMajorAxis: 63782064, ScaleFactorOfEarthMinorAxis: 1, ScaledValueOfEarthMinorAxis: 63565838},
+			a:     6378206.4,
+			b:     6356583.8,
+		},
+		{name: "sphere of radius 6371200 m", shape: gdt.Template0FixedPart{ShapeOfTheEarth: 8}, a: 6371200, b: 6371200},
+		{name: "OSGB 1936", shape: gdt.Template0FixedPart{ShapeOfTheEarth: 9}, a: 6377563.396, b: 6356256.909},
+		{name: "WGS84 with corrected geomagnetic coordinates", shape: gdt.Template0FixedPart{ShapeOfTheEarth: 10}, a: 6378137, b: 6356752.314245},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			earth := tt.shape.AsTemplate().GetEarthShape()
+
+			assert.InDelta(t, tt.a, earth.SemiMajorAxis, 1e-6)
+			assert.InDelta(t, tt.b, earth.SemiMinorAxis, 1e-6)
+			assert.Equal(t, tt.a == tt.b, earth.IsSphere())
+		})
+	}
+}
+
+func TestEllipsoid_WGS84(t *testing.T) {
+	earth := gdt.NewEllipsoid(5, 0, 0, 0, 0, 0, 0)
+
+	assert.InDelta(t, 1/298.257223563, earth.Flattening(), 1e-15)
+	assert.InDelta(t, 0.0818191908426, earth.Eccentricity(), 1e-13)
+
+	sphere := gdt.NewEllipsoid(6, 0, 0, 0, 0, 0, 0)
+	assert.Equal(t, 0.0, sphere.Flattening())
+	assert.Equal(t, 0.0, sphere.Eccentricity())
+
+	// spectral data do not define the shape of the Earth
+	assert.Equal(t, gdt.Ellipsoid{}, (&gdt.Template50FixedPart{}).AsTemplate().GetEarthShape())
+}
@@ -0,0 +1,109 @@
+package gdt
+
+import (
+	"math"
+	"sort"
+)
+
+// kdTree is a 3-d tree of points on the unit sphere, the nearest point of the Earth surface is the
+// one of the shortest chord, so that the search is not bothered by the poles and the antimeridian.
+type kdTree struct {
+	points [][3]float64
+	// nodes is the tree in the order of a depth first traversal, the median of each subtree is in the
+	// middle of its range and splits it along the axis given by its depth
+	nodes []int
+}
+
+func newKDTree(lats, lons []float64) *kdTree {
+	t := &kdTree{
+		points: make([][3]float64, len(lats)),
+		nodes:  make([]int, 0, len(lats)),
+	}
+
+	for i := range lats {
+		t.points[i] = unitVector(lats[i], lons[i])
+
+		// missing coordinates are left out of the tree
+		if !math.IsNaN(lats[i]) && !math.IsNaN(lons[i]) {
+			t.nodes = append(t.nodes, i)
+		}
+	}
+
+	t.build(t.nodes, 0)
+
+	return t
+}
+
+// unitVector returns the cartesian coordinates of the point lat, lon on the unit sphere.
+func unitVector(lat, lon float64) [3]float64 {
+	phi, lambda := lat*math.Pi/180, lon*math.Pi/180
+
+	return [3]float64{
+		math.Cos(phi) * math.Cos(lambda),
+		math.Cos(phi) * math.Sin(lambda),
+		math.Sin(phi),
+	}
+}
+
+func (t *kdTree) build(nodes []int, depth int) {
+	if len(nodes) < 2 {
+		return
+	}
+
+	axis := depth % 3
+	sort.Slice(nodes, func(i, j int) bool {
+		return t.points[nodes[i]][axis] < t.points[nodes[j]][axis]
+	})
+
+	m := len(nodes) / 2
+	t.build(nodes[:m], depth+1)
+	t.build(nodes[m+1:], depth+1)
+}
+
+// nearest returns the index of the point nearest to the point lat, lon, -1 if the tree is empty.
+func (t *kdTree) nearest(lat, lon float64) int {
+	var (
+		q    = unitVector(lat, lon)
+		best = -1
+		dist = math.Inf(1)
+	)
+
+	var search func(nodes []int, depth int)
+	search = func(nodes []int, depth int) {
+		if len(nodes) == 0 {
+			return
+		}
+
+		var (
+			m    = len(nodes) / 2
+			p    = t.points[nodes[m]]
+			axis = depth % 3
+		)
+
+		if d := chord2(p, q); d < dist || d == dist && nodes[m] < best {
+			best, dist = nodes[m], d
+		}
+
+		near, far := nodes[:m], nodes[m+1:]
+		diff := q[axis] - p[axis]
+		if diff > 0 {
+			near, far = far, near
+		}
+
+		search(near, depth+1)
+
+		if diff*diff <= dist {
+			search(far, depth+1)
+		}
+	}
+
+	search(t.nodes, 0)
+
+	return best
+}
+
+// chord2 returns the squared distance between the points p and q.
+func chord2(p, q [3]float64) float64 {
+	dx, dy, dz := p[0]-q[0], p[1]-q[1], p[2]-q[2]
+	return dx*dx + dy*dy + dz*dz
+}
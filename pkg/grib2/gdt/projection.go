@@ -0,0 +1,72 @@
+package gdt
+
+import "math"
+
+// projection maps geographic coordinates in degrees to coordinates in metres on a projection plane.
+type projection interface {
+	forward(lat, lon float64) (x, y float64)
+	inverse(x, y float64) (lat, lon float64)
+}
+
+// projectedGrid is a grid of nx x ny points evenly spaced on a projection plane.
+type projectedGrid struct {
+	projection
+	x1, y1 float64 // plane coordinates of the first grid point
+	dx, dy float64
+	nx, ny int
+	mode   scanMode
+}
+
+// newProjectedGrid returns the grid whose first point is at lat1, lon1, the grid lengths dx and dy
+// are in metres.
+func newProjectedGrid(p projection, lat1, lon1, dx, dy float64, nx, ny int, mode scanMode) *projectedGrid {
+	x1, y1 := p.forward(lat1, lon1)
+
+	return &projectedGrid{
+		projection: p,
+		x1:         x1,
+		y1:         y1,
+		dx:         dx,
+		dy:         dy,
+		nx:         nx,
+		ny:         ny,
+		mode:       mode,
+	}
+}
+
+// index returns the grid point nearest to lat, lon, -1 if it is out of the grid.
+func (g *projectedGrid) index(lat, lon float64) int {
+	if g.dx <= 0 || g.dy <= 0 {
+		return -1
+	}
+
+	x, y := g.forward(lat, lon)
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return -1
+	}
+
+	i := math.Round(g.mode.iSign() * (x - g.x1) / g.dx)
+	j := math.Round(g.mode.jSign() * (y - g.y1) / g.dy)
+
+	if i < 0 || j < 0 || i >= float64(g.nx) || j >= float64(g.ny) {
+		return -1
+	}
+
+	return g.mode.index(int(i), int(j), g.nx, g.ny)
+}
+
+// point returns the latitude and longitude of the nth grid point, the longitude is in [0, 360). It
+// reports false if the point is out of the grid or not on the Earth.
+func (g *projectedGrid) point(n int) (float64, float64, bool) {
+	i, j, ok := g.mode.position(n, g.nx, g.ny)
+	if !ok {
+		return 0, 0, false
+	}
+
+	lat, lon := g.inverse(g.x1+g.mode.iSign()*float64(i)*g.dx, g.y1+g.mode.jSign()*float64(j)*g.dy)
+	if math.IsNaN(lat) || math.IsNaN(lon) {
+		return 0, 0, false
+	}
+
+	return lat, normalizeLongitude(lon), true
+}
@@ -0,0 +1,244 @@
+package gdt
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// ReadPointsPerRow reads the list of numbers of points along each row of a quasi-regular grid, which
+// follows the template in section 3 (octets 73-nn of templates 3.0 and 3.40), each number is coded
+// in size octets and interpreted as given by code table 3.11. It returns tpl as a quasi-regular grid.
+func ReadPointsPerRow(r io.Reader, tpl Template, size uint8, interpretation uint8) (Template, error) {
+	var rows int
+
+	switch t := tpl.(type) {
+	case *Template0:
+		rows = int(t.Nj)
+	case *Template40:
+		rows = int(t.Nj)
+	default:
+		return nil, fmt.Errorf("quasi-regular grid is not supported by %T", tpl)
+	}
+
+	if rows <= 0 {
+		return nil, fmt.Errorf("quasi-regular grid with variable number of rows is not supported")
+	}
+
+	switch interpretation {
+	case pointsOfFullCircles, pointsBetweenExtremes:
+	default:
+		return nil, fmt.Errorf("unsupported interpretation of list of numbers of points: %d", interpretation)
+	}
+
+	buf := make([]byte, rows*int(size))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("read number of points of %d rows: %w", rows, err)
+	}
+
+	points := make([]int32, rows)
+
+	for j := range points {
+		b := buf[j*int(size) : (j+1)*int(size)]
+
+		switch size {
+		case 1:
+			points[j] = int32(b[0])
+		case 2:
+			points[j] = int32(binary.BigEndian.Uint16(b))
+		case 4:
+			points[j] = int32(binary.BigEndian.Uint32(b))
+		default:
+			return nil, fmt.Errorf("unsupported number of octets for number of points: %d", size)
+		}
+	}
+
+	switch t := tpl.(type) {
+	case *Template0:
+		fp := t.Template0FixedPart
+		fp.PointsPerRow = points
+		fp.InterpretationOfNumberOfPoints = int8(interpretation)
+
+		return fp.AsTemplate(), nil
+	case *Template40:
+		fp := t.Template40FixedPart
+		fp.PointsPerRow = points
+		fp.InterpretationOfNumberOfPoints = int8(interpretation)
+
+		return fp.AsTemplate(), nil
+	}
+
+	return tpl, nil
+}
+
+// code table 3.11, interpretation of list of numbers of points
+const (
+	// the numbers of points are of full parallels, the points of a row are the multiples of its increment
+	// between the extreme longitudes, which may not be reached in all rows
+	pointsOfFullCircles = 1
+	// the numbers of points are of the rows from the first to the last longitude, which are in every row
+	pointsBetweenExtremes = 2
+)
+
+// reducedGrid is a quasi-regular grid whose rows have a variable number of points, evenly spaced in
+// longitude.
+type reducedGrid struct {
+	latitudes []float64 // of each row, in scanning order
+	points    []int
+	offsets   []int     // index of the first point of each row
+	lon1      []float64 // longitude of the first point of each row
+	steps     []float64 // longitude increment of each row
+	global    bool      // rows are full parallels
+	mode      scanMode
+}
+
+// newReducedGrid returns the grid whose rows have points from lon1 to lon2 in the i direction, the
+// numbers of points are interpreted as of full parallels if circles, or of the rows otherwise.
+func newReducedGrid(latitudes []float64, points []int32, lon1, lon2 float64, mode scanMode, circles bool) *reducedGrid {
+	var (
+		span = normalizeLongitude(mode.iSign() * (lon2 - lon1))
+		rows = len(points)
+		g    = &reducedGrid{
+			latitudes: latitudes,
+			points:    make([]int, rows),
+			offsets:   make([]int, rows),
+			lon1:      make([]float64, rows),
+			steps:     make([]float64, rows),
+			global:    circles,
+			mode:      mode,
+		}
+		n int
+	)
+
+	for j, p := range points {
+		g.offsets[j] = n
+		g.points[j], g.lon1[j] = int(p), lon1
+
+		switch {
+		case circles && p > 0:
+			g.points[j], g.lon1[j] = circleRow(int(p), lon1, span, mode)
+			g.steps[j] = 360 / float64(p)
+			g.global = g.global && g.points[j] == int(p)
+		case p > 1:
+			g.steps[j] = span / float64(p-1)
+		}
+
+		n += g.points[j]
+	}
+
+	return g
+}
+
+// circleRow returns the number of points of a parallel of n points which are within span degrees from
+// lon1 in the i direction, and the longitude of the first one. The points are the multiples of 360/n.
+func circleRow(n int, lon1, span float64, mode scanMode) (int, float64) {
+	var (
+		step = 360 / float64(n)
+		sign = mode.iSign()
+		// coded longitudes are rounded, the points a thousandth of an increment beyond them are in the row
+		first = math.Ceil(sign*lon1/step - 1e-3)
+		last  = math.Floor((sign*lon1+span)/step + 1e-3)
+	)
+
+	return max(min(int(last-first)+1, n), 0), normalizeLongitude(sign * first * step)
+}
+
+// row returns the row nearest to lat, -1 if lat is out of the grid.
+func (g *reducedGrid) row(lat float64) int {
+	rows := len(g.latitudes)
+	if rows == 0 {
+		return -1
+	}
+
+	if rows == 1 {
+		return 0
+	}
+
+	// rows from north to south, or from south to north
+	descending := g.latitudes[0] > g.latitudes[rows-1]
+
+	j := sort.Search(rows, func(j int) bool {
+		if descending {
+			return g.latitudes[j] <= lat
+		}
+
+		return g.latitudes[j] >= lat
+	})
+
+	switch {
+	case j == 0:
+		if math.Abs(lat-g.latitudes[0]) > math.Abs(g.latitudes[1]-g.latitudes[0])/2 && !g.polar(0) {
+			return -1
+		}
+
+		return 0
+	case j == rows:
+		if math.Abs(lat-g.latitudes[rows-1]) > math.Abs(g.latitudes[rows-1]-g.latitudes[rows-2])/2 && !g.polar(rows-1) {
+			return -1
+		}
+
+		return rows - 1
+	}
+
+	if math.Abs(lat-g.latitudes[j-1]) <= math.Abs(g.latitudes[j]-lat) {
+		return j - 1
+	}
+
+	return j
+}
+
+// polar reports whether the global row j is the nearest row of the pole beyond it.
+func (g *reducedGrid) polar(j int) bool {
+	if !g.global || len(g.latitudes) < 2 {
+		return false
+	}
+
+	// the row next to the pole is the nearest one of any point beyond it
+	next := j + 1
+	if j > 0 {
+		next = j - 1
+	}
+
+	return math.Abs(g.latitudes[j])+math.Abs(g.latitudes[j]-g.latitudes[next]) > 90
+}
+
+// index returns the grid point nearest to lat, lon along its row, -1 if it is out of the grid.
+func (g *reducedGrid) index(lat, lon float64) int {
+	j := g.row(lat)
+	if j < 0 || g.points[j] == 0 {
+		return -1
+	}
+
+	var (
+		step = g.steps[j]
+		d    = g.mode.iSign() * (lon - g.lon1[j])
+	)
+
+	if step == 0 {
+		return g.offsets[j]
+	}
+
+	// a point half a step before the first one rounds to it
+	d = normalizeLongitude(d+step/2) - step/2
+
+	i := int(math.Round(d / step))
+	if i < 0 || i >= g.points[j] {
+		return -1
+	}
+
+	return g.offsets[j] + i
+}
+
+// point returns the latitude and longitude of the nth grid point, the longitude is in [0, 360).
+func (g *reducedGrid) point(n int) (float64, float64, bool) {
+	if n < 0 || len(g.points) == 0 || n >= g.offsets[len(g.offsets)-1]+g.points[len(g.points)-1] {
+		return 0, 0, false
+	}
+
+	j := sort.SearchInts(g.offsets, n+1) - 1
+	lon := g.lon1[j] + g.mode.iSign()*float64(n-g.offsets[j])*g.steps[j]
+
+	return g.latitudes[j], normalizeLongitude(lon), true
+}
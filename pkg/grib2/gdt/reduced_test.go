@@ -0,0 +1,282 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// octahedral reduced Gaussian grid O2
+var o2 = gdt.Template40FixedPart{
+	Ni:                        -1,
+	Nj:                        4,
+	LatitudeOfFirstGridPoint:  59444410,
+	LongitudeOfFirstGridPoint: 0,
+	LatitudeOfLastGridPoint:   -59444410,
+	LongitudeOfLastGridPoint:  345000000, // the last point of the longest rows
+	N:                         2,
+	PointsPerRow:              []int32{20, 24, 24, 20},
+
+	InterpretationOfNumberOfPoints: 1,
+}
+
+func TestTemplate40_QuasiRegular(t *testing.T) {
+	tpl := o2.AsTemplate()
+
+	tests := []struct {
+		n        int
+		lat, lon float32
+	}{
+		{n: 0, lat: 59.444410, lon: 0},
+		{n: 19, lat: 59.444410, lon: 342},
+		{n: 20, lat: 19.875721, lon: 0},
+		{n: 21, lat: 19.875721, lon: 15},
+		{n: 44, lat: -19.875721, lon: 0},
+		{n: 87, lat: -59.444410, lon: 342},
+	}
+
+	for _, tt := range tests {
+		lat, lon, ok := tpl.GetGridPoint(tt.n)
+		require.True(t, ok)
+		assert.InDelta(t, tt.lat, lat, 1e-5, "grid point %d", tt.n)
+		assert.InDelta(t, tt.lon, lon, 1e-5, "grid point %d", tt.n)
+	}
+
+	_, _, ok := tpl.GetGridPoint(88)
+	assert.False(t, ok)
+
+	for n := 0; n < 88; n++ {
+		lat, lon, ok := tpl.GetGridPoint(n)
+		require.True(t, ok)
+		require.Equal(t, n, tpl.GetGridIndex(lat, lon), "lat: %f, lon: %f", lat, lon)
+	}
+
+	// nearest row, then nearest point of the row, wrapping around the globe
+	assert.Equal(t, 0, tpl.GetGridIndex(90, 0))
+	assert.Equal(t, 0, tpl.GetGridIndex(60, 359))
+	assert.Equal(t, 21, tpl.GetGridIndex(30, 14))
+	assert.Equal(t, 68, tpl.GetGridIndex(-90, 0))
+}
+
+func TestTemplate40_QuasiRegular_SubArea(t *testing.T) {
+	// O2 from 10 to 50 degrees east, the points of the parallels within the extreme longitudes
+	tpldef := o2
+	tpldef.LongitudeOfFirstGridPoint, tpldef.LongitudeOfLastGridPoint = 10000000, 50000000
+	tpl := tpldef.AsTemplate()
+
+	tests := []struct {
+		n        int
+		lat, lon float32
+	}{
+		{n: 0, lat: 59.444410, lon: 18},
+		{n: 1, lat: 59.444410, lon: 36},
+		{n: 2, lat: 19.875721, lon: 15},
+		{n: 4, lat: 19.875721, lon: 45},
+		{n: 9, lat: -59.444410, lon: 36},
+	}
+
+	for _, tt := range tests {
+		lat, lon, ok := tpl.GetGridPoint(tt.n)
+		require.True(t, ok)
+		assert.InDelta(t, tt.lat, lat, 1e-5, "grid point %d", tt.n)
+		assert.InDelta(t, tt.lon, lon, 1e-5, "grid point %d", tt.n)
+		assert.Equal(t, tt.n, tpl.GetGridIndex(lat, lon), "grid point %d", tt.n)
+	}
+
+	_, _, ok := tpl.GetGridPoint(10)
+	assert.False(t, ok)
+
+	assert.Equal(t, 0, tpl.GetGridIndex(60, 10))
+	assert.Equal(t, -1, tpl.GetGridIndex(60, 50))
+	assert.Equal(t, -1, tpl.GetGridIndex(90, 18))
+
+	// the same numbers of points of the rows between the extreme longitudes
+	tpldef.InterpretationOfNumberOfPoints = 2
+	tpldef.PointsPerRow = []int32{2, 3, 3, 2}
+	tpl = tpldef.AsTemplate()
+
+	lat, lon, ok := tpl.GetGridPoint(1)
+	require.True(t, ok)
+	assert.InDelta(t, 59.444410, lat, 1e-5)
+	assert.InDelta(t, 50, lon, 1e-5)
+}
+
+func TestTemplate0_QuasiRegular(t *testing.T) {
+	// three parallels of a sub-area, from 0 to 20 degrees east
+	tpl := (&gdt.Template0FixedPart{
+		Ni:                        -1,
+		Nj:                        3,
+		LatitudeOfFirstGridPoint:  60000000,
+		LongitudeOfFirstGridPoint: 0,
+		LatitudeOfLastGridPoint:   50000000,
+		LongitudeOfLastGridPoint:  20000000,
+		JDirectionIncrement:       5000000,
+		PointsPerRow:              []int32{3, 5, 11},
+
+		InterpretationOfNumberOfPoints: 2,
+	}).AsTemplate()
+
+	tests := []struct {
+		n        int
+		lat, lon float32
+	}{
+		{n: 1, lat: 60, lon: 10},
+		{n: 4, lat: 55, lon: 5},
+		{n: 18, lat: 50, lon: 20},
+	}
+
+	for _, tt := range tests {
+		lat, lon, ok := tpl.GetGridPoint(tt.n)
+		require.True(t, ok)
+		assert.InDelta(t, tt.lat, lat, 1e-5, "grid point %d", tt.n)
+		assert.InDelta(t, tt.lon, lon, 1e-5, "grid point %d", tt.n)
+	}
+
+	for n := 0; n < 19; n++ {
+		lat, lon, ok := tpl.GetGridPoint(n)
+		require.True(t, ok)
+		require.Equal(t, n, tpl.GetGridIndex(lat, lon), "lat: %f, lon: %f", lat, lon)
+	}
+
+	assert.Equal(t, -1, tpl.GetGridIndex(70, 10))
+	assert.Equal(t, -1, tpl.GetGridIndex(55, 30))
+	assert.Equal(t, 8, tpl.GetGridIndex(50.5, 359.5))
+}
+
+func TestReadPointsPerRow(t *testing.T) {
+	tests := []struct {
+		name           string
+		tpl            gdt.Template
+		size           uint8
+		interpretation uint8
+		data           []byte
+		want           []int32
+		wantErr        bool
+	}{
+		{
+			name:           "1 octet",
+			tpl:            (&gdt.Template40FixedPart{Nj: 4, N: 2}).AsTemplate(),
+			size:           1,
+			interpretation: 1,
+			data:           []byte{20, 24, 24, 20},
+			want:           []int32{20, 24, 24, 20},
+		},
+		{
+			name:           "2 octets",
+			tpl:            (&gdt.Template0FixedPart{Nj: 2}).AsTemplate(),
+			size:           2,
+			interpretation: 2,
+			data:           []byte{0x01, 0x40, 0x00, 0x14},
+			want:           []int32{320, 20},
+		},
+		{
+			name:           "short list",
+			tpl:            (&gdt.Template0FixedPart{Nj: 2}).AsTemplate(),
+			size:           2,
+			interpretation: 1,
+			data:           []byte{0x01, 0x40},
+			wantErr:        true,
+		},
+		{
+			name:           "latitudes of the rows",
+			tpl:            (&gdt.Template0FixedPart{Nj: 2}).AsTemplate(),
+			size:           4,
+			interpretation: 3,
+			data:           []byte{0x03, 0x93, 0x87, 0x00, 0x02, 0xfa, 0xf0, 0x80},
+			wantErr:        true,
+		},
+		{
+			name:           "unsupported template",
+			tpl:            (&gdt.Template30FixedPart{Nx: 1, Ny: 1}).AsTemplate(),
+			size:           1,
+			interpretation: 1,
+			data:           []byte{1},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl, err := gdt.ReadPointsPerRow(bytes.NewReader(tt.data), tt.tpl, tt.size, tt.interpretation)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			switch tpl := tpl.(type) {
+			case *gdt.Template0:
+				assert.Equal(t, tt.want, tpl.PointsPerRow)
+			case *gdt.Template40:
+				assert.Equal(t, tt.want, tpl.PointsPerRow)
+			default:
+				t.Fatalf("unexpected template %T", tpl)
+			}
+		})
+	}
+}
+
+func TestReadTemplate40_QuasiRegular(t *testing.T) {
+	var buf bytes.Buffer
+
+	// O2 scanning in the -i direction
+	for _, v := range []any{
+		uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), // shape of the earth
+		uint32(0xffffffff), uint32(4), uint32(0), uint32(0xffffffff), // ni, nj, basic angle
+		uint32(59444410), uint32(0), uint8(0), // first grid point, resolution and component flags
+		uint32(0x80000000 | 59444410), uint32(15000000), uint32(0xffffffff), uint32(2), // last grid point, di, n
+		uint8(0x80),             // scanning mode
+		[]uint8{20, 24, 24, 20}, // number of points along each parallel
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	tpl, err := gdt.ReadTemplate(&buf, 40)
+	require.NoError(t, err)
+
+	tpl, err = gdt.ReadPointsPerRow(&buf, tpl, 1, 1)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template40{}, tpl)
+	assert.Equal(t, int8(-128), tpl.(*gdt.Template40).ScanningMode)
+
+	tests := []struct {
+		n        int
+		lat, lon float32
+	}{
+		{n: 1, lat: 59.444410, lon: 342},
+		{n: 21, lat: 19.875721, lon: 345},
+		{n: 87, lat: -59.444410, lon: 18},
+	}
+
+	for _, tt := range tests {
+		lat, lon, ok := tpl.GetGridPoint(tt.n)
+		require.True(t, ok)
+		assert.InDelta(t, tt.lat, lat, 1e-5, "grid point %d", tt.n)
+		assert.InDelta(t, tt.lon, lon, 1e-5, "grid point %d", tt.n)
+		assert.Equal(t, tt.n, tpl.GetGridIndex(lat, lon), "grid point %d", tt.n)
+	}
+}
+
+func TestTemplate40_QuasiRegular_JSON(t *testing.T) {
+	bs, err := json.Marshal(o2.AsTemplate())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"template40":{"latitudeOfFirstGridPoint":59444410,"latitudeOfLastGridPoint":-59444410,"longitudeOfLastGridPoint":345000000,"n":2,"scanningMode":0,"pointsPerRow":[20,24,24,20],"interpretationOfNumberOfPoints":1}}`, string(bs))
+
+	tpl, err := gdt.UnMarshalJSONTemplate(bs)
+	require.NoError(t, err)
+
+	for n := 0; n < 88; n++ {
+		lat, lon, ok := tpl.GetGridPoint(n)
+		require.True(t, ok)
+
+		wantLat, wantLon, _ := o2.AsTemplate().GetGridPoint(n)
+		assert.Equal(t, wantLat, lat)
+		assert.Equal(t, wantLon, lon)
+	}
+}
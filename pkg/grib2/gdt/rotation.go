@@ -0,0 +1,250 @@
+package gdt
+
+import "math"
+
+// rotatedPole is the general rotation of the sphere of rotated latitude/longitude grids: the sphere
+// is rotated about the geographic polar axis to the longitude of the southern pole, then through
+// 90 degrees plus the latitude of the southern pole, then by the angle of rotation about the new
+// polar axis.
+type rotatedPole struct {
+	sin, cos float64 // of the angle between the geographic and rotated polar axes
+	lonSP    float64
+	angle    float64
+}
+
+func newRotatedPole(latSP, lonSP, angle float64) rotatedPole {
+	theta := (90 + latSP) * math.Pi / 180
+
+	return rotatedPole{
+		sin:   math.Sin(theta),
+		cos:   math.Cos(theta),
+		lonSP: lonSP,
+		angle: angle,
+	}
+}
+
+// newCentredPole returns the rotation which moves the geographic point lat, lon to the intersection
+// of the rotated equator and prime meridian.
+func newCentredPole(lat, lon float64) rotatedPole {
+	return newRotatedPole(lat-90, lon, 0)
+}
+
+// rotate returns the rotated coordinates of the geographic point lat, lon.
+func (r rotatedPole) rotate(lat, lon float64) (float64, float64) {
+	var (
+		phi    = lat * math.Pi / 180
+		lambda = (lon - r.lonSP) * math.Pi / 180
+
+		x = math.Cos(phi) * math.Cos(lambda)
+		y = math.Cos(phi) * math.Sin(lambda)
+		z = math.Sin(phi)
+	)
+
+	x, z = r.cos*x+r.sin*z, r.cos*z-r.sin*x
+
+	rlat := math.Asin(math.Max(-1, math.Min(1, z))) * 180 / math.Pi
+	rlon := math.Atan2(y, x)*180/math.Pi + r.angle
+
+	return rlat, rlon
+}
+
+// unrotate returns the geographic coordinates of the rotated point rlat, rlon, the longitude is in [0, 360).
+func (r rotatedPole) unrotate(rlat, rlon float64) (float64, float64) {
+	var (
+		phi    = rlat * math.Pi / 180
+		lambda = (rlon - r.angle) * math.Pi / 180
+
+		x = math.Cos(phi) * math.Cos(lambda)
+		y = math.Cos(phi) * math.Sin(lambda)
+		z = math.Sin(phi)
+	)
+
+	x, z = r.cos*x-r.sin*z, r.cos*z+r.sin*x
+
+	lat := math.Asin(math.Max(-1, math.Min(1, z))) * 180 / math.Pi
+	lon := math.Atan2(y, x)*180/math.Pi + r.lonSP
+
+	return lat, normalizeLongitude(lon)
+}
+
+// rotatedGrid is a grid of ni x nj points evenly spaced in rotated latitude and longitude, the rows
+// may be staggered by half an increment, see bits 5-8 of flag table 3.4.
+type rotatedGrid struct {
+	pole         rotatedPole
+	rlat1, rlon1 float64 // rotated coordinates of the first grid point
+	di, dj       float64
+	ni, nj       int
+	mode         scanMode
+	// offsets of the odd and even rows in the i direction, of all points in the j direction, in increments
+	oddOffset, evenOffset, jOffset float64
+}
+
+func newRotatedGrid(pole rotatedPole, rlat1, rlon1, di, dj float64, ni, nj int, mode scanMode) *rotatedGrid {
+	g := &rotatedGrid{
+		pole:  pole,
+		rlat1: rlat1,
+		rlon1: rlon1,
+		di:    di,
+		dj:    dj,
+		ni:    ni,
+		nj:    nj,
+		mode:  mode,
+	}
+
+	if mode.oddRowsOffset() {
+		g.oddOffset = 0.5
+	}
+
+	if mode.evenRowsOffset() {
+		g.evenOffset = 0.5
+	}
+
+	if mode.jOffset() {
+		g.jOffset = 0.5
+	}
+
+	return g
+}
+
+// staggered reports whether the rows are not aligned, only rows of consecutive points can be staggered.
+func (g *rotatedGrid) staggered() bool {
+	return g.oddOffset != g.evenOffset || g.mode.shortOffsetRows() && g.oddOffset != 0
+}
+
+// rowOffset returns the offset in increments of the points of row j counted from 0, the first row is odd.
+func (g *rotatedGrid) rowOffset(j int) float64 {
+	if j%2 == 0 {
+		return g.oddOffset
+	}
+
+	return g.evenOffset
+}
+
+// rowLength returns the number of points of row j.
+func (g *rotatedGrid) rowLength(j int) int {
+	if g.mode.shortOffsetRows() && g.rowOffset(j) != 0 {
+		return g.ni - 1
+	}
+
+	return g.ni
+}
+
+// position returns the steps i and j from the first grid point of the nth point.
+func (g *rotatedGrid) position(n int) (int, int, bool) {
+	if !g.staggered() {
+		return g.mode.position(n, g.ni, g.nj)
+	}
+
+	if g.mode.jConsecutive() || n < 0 {
+		return 0, 0, false
+	}
+
+	var (
+		odd, even = g.rowLength(0), g.rowLength(1)
+		j         = 2 * (n / (odd + even))
+		i         = n % (odd + even)
+	)
+
+	if i >= odd {
+		i, j = i-odd, j+1
+	}
+
+	if j >= g.nj {
+		return 0, 0, false
+	}
+
+	if g.mode.alternate() && j%2 == 1 {
+		i = g.rowLength(j) - 1 - i
+	}
+
+	return i, j, true
+}
+
+// index returns the position in the data of the point i and j steps away from the first grid point,
+// -1 if it is out of the grid.
+func (g *rotatedGrid) index(i, j int) int {
+	if !g.staggered() {
+		return g.mode.index(i, j, g.ni, g.nj)
+	}
+
+	if g.mode.jConsecutive() || i < 0 || j < 0 || j >= g.nj || i >= g.rowLength(j) {
+		return -1
+	}
+
+	if g.mode.alternate() && j%2 == 1 {
+		i = g.rowLength(j) - 1 - i
+	}
+
+	odd, even := g.rowLength(0), g.rowLength(1)
+
+	return j/2*(odd+even) + j%2*odd + i
+}
+
+// rotated returns the rotated coordinates of the point i and j steps away from the first grid point.
+func (g *rotatedGrid) rotated(i, j int) (float64, float64) {
+	rlat := g.rlat1 + g.mode.jSign()*(float64(j)+g.jOffset)*g.dj
+	rlon := g.rlon1 + g.mode.iSign()*(float64(i)+g.rowOffset(j))*g.di
+
+	return rlat, rlon
+}
+
+// point returns the geographic latitude and longitude of the nth grid point.
+func (g *rotatedGrid) point(n int) (float64, float64, bool) {
+	i, j, ok := g.position(n)
+	if !ok {
+		return 0, 0, false
+	}
+
+	lat, lon := g.pole.unrotate(g.rotated(i, j))
+
+	return lat, lon, true
+}
+
+// nearest returns the steps i from the first grid point of the point of row j nearest to the rotated
+// longitude rlon.
+func (g *rotatedGrid) nearest(j int, rlon float64) int {
+	// longitude steps from the first point of the row, a point half a step before it rounds to it
+	dlon := g.mode.iSign()*(rlon-g.rlon1) - g.rowOffset(j)*g.di
+	dlon = normalizeLongitude(dlon+g.di/2) - g.di/2
+
+	return int(math.Round(dlon / g.di))
+}
+
+// locate returns the grid point nearest to the geographic point lat, lon, -1 if it is out of the grid.
+func (g *rotatedGrid) locate(lat, lon float64) int {
+	if g.di <= 0 || g.dj <= 0 {
+		return -1
+	}
+
+	rlat, rlon := g.pole.rotate(lat, lon)
+	v := g.mode.jSign()*(rlat-g.rlat1)/g.dj - g.jOffset
+
+	if !g.staggered() {
+		j := int(math.Round(v))
+		return g.index(g.nearest(j, rlon), j)
+	}
+
+	// the nearest point of a staggered grid may be on either of the rows around the point
+	var (
+		best     = -1
+		bestDist = math.Inf(1)
+	)
+
+	for _, j := range []int{int(math.Floor(v)), int(math.Floor(v)) + 1} {
+		i := g.nearest(j, rlon)
+
+		n := g.index(i, j)
+		if n < 0 {
+			continue
+		}
+
+		plat, plon := g.rotated(i, j)
+		dlon := math.Remainder(plon-rlon, 360) * math.Cos(rlat*math.Pi/180)
+
+		if d := dlon*dlon + (plat-rlat)*(plat-rlat); d < bestDist {
+			best, bestDist = n, d
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,104 @@
+package gdt
+
+import "math"
+
+// scanMode is the scanning mode of the grid points, flag table 3.4.
+type scanMode int8
+
+// iNegative reports whether points of the i direction scan in the -i (x decreasing) direction.
+func (m scanMode) iNegative() bool { return uint8(m)&0x80 != 0 }
+
+// jPositive reports whether points of the j direction scan in the +j (y increasing) direction.
+func (m scanMode) jPositive() bool { return uint8(m)&0x40 != 0 }
+
+// jConsecutive reports whether adjacent points in the j direction are consecutive.
+func (m scanMode) jConsecutive() bool { return uint8(m)&0x20 != 0 }
+
+// alternate reports whether adjacent rows scan in opposite directions.
+func (m scanMode) alternate() bool { return uint8(m)&0x10 != 0 }
+
+// oddRowsOffset reports whether points within odd rows are offset by half an increment in the i direction.
+func (m scanMode) oddRowsOffset() bool { return uint8(m)&0x08 != 0 }
+
+// evenRowsOffset reports whether points within even rows are offset by half an increment in the i direction.
+func (m scanMode) evenRowsOffset() bool { return uint8(m)&0x04 != 0 }
+
+// jOffset reports whether points are offset by half an increment in the j direction.
+func (m scanMode) jOffset() bool { return uint8(m)&0x02 != 0 }
+
+// shortOffsetRows reports whether rows offset in the i direction have one point less.
+func (m scanMode) shortOffsetRows() bool { return uint8(m)&0x01 != 0 }
+
+// position returns the steps i and j from the first grid point to the nth point of a grid of
+// ni x nj points.
+func (m scanMode) position(n, ni, nj int) (int, int, bool) {
+	if n < 0 || ni <= 0 || nj <= 0 || n >= ni*nj {
+		return 0, 0, false
+	}
+
+	if m.jConsecutive() {
+		i, j := n/nj, n%nj
+		if m.alternate() && i%2 == 1 {
+			j = nj - 1 - j
+		}
+
+		return i, j, true
+	}
+
+	i, j := n%ni, n/ni
+	if m.alternate() && j%2 == 1 {
+		i = ni - 1 - i
+	}
+
+	return i, j, true
+}
+
+// index returns the position in the data of the grid point i and j steps away from the first
+// grid point, -1 if it is out of the grid of ni x nj points.
+func (m scanMode) index(i, j, ni, nj int) int {
+	if i < 0 || j < 0 || i >= ni || j >= nj {
+		return -1
+	}
+
+	if m.jConsecutive() {
+		if m.alternate() && i%2 == 1 {
+			j = nj - 1 - j
+		}
+
+		return i*nj + j
+	}
+
+	if m.alternate() && j%2 == 1 {
+		i = ni - 1 - i
+	}
+
+	return j*ni + i
+}
+
+// iSign returns the sign of the increments along the i direction.
+func (m scanMode) iSign() float64 {
+	if m.iNegative() {
+		return -1
+	}
+
+	return 1
+}
+
+// jSign returns the sign of the increments along the j direction.
+func (m scanMode) jSign() float64 {
+	if m.jPositive() {
+		return 1
+	}
+
+	return -1
+}
+
+// normalizeLongitude returns lon in [0, 360).
+func normalizeLongitude(lon float64) float64 {
+	lon = math.Mod(lon, 360)
+	if lon < 0 {
+		lon += 360
+	}
+
+	return lon
+}
@@ -10,6 +10,7 @@ import (
 type Template interface {
 	GetNi() int32
 	GetNj() int32
+	GetEarthShape() Ellipsoid
 	GetGridIndex(lat, lon float32) (n int)
 	GetGridPoint(n int) (float32, float32, bool)
 }
@@ -19,6 +20,7 @@ type MissingTemplate struct{}
 func (m MissingTemplate) GetGridPointFromLL(float32, float32) int { return 0 }
 func (m MissingTemplate) GetNi() int32                            { return 0 }
 func (m MissingTemplate) GetNj() int32                            { return 0 }
+func (m MissingTemplate) GetEarthShape() Ellipsoid                { return Ellipsoid{} }
 func (m MissingTemplate) GetGridIndex(lat, lon float32) (n int) {
 	return 0
 }
@@ -36,6 +38,38 @@ func ReadTemplate(r io.Reader, n uint16) (Template, error) {
 
 		return tpl.Export(), nil
 
+	case 1:
+		var tpl template1FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
+	case 10:
+		var tpl template10FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
+	case 20:
+		var tpl template20FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
+	case 30:
+		var tpl template30FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
 	case 40:
 		var tpl template40FixedPart
 		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
@@ -44,9 +78,57 @@ func ReadTemplate(r io.Reader, n uint16) (Template, error) {
 
 		return tpl.Export(), nil
 
+	case 50:
+		var tpl template50FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
+	case 90:
+		var tpl template90FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
+	case 101:
+		var tpl template101FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
+	case 204:
+		var tpl template204FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
 	case 255:
 		return &MissingTemplate{}, nil
 
+	case 32768:
+		var tpl template32768FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
+	case 32769:
+		var tpl template32769FixedPart
+		if err := binary.Read(r, binary.BigEndian, &tpl); err != nil {
+			return nil, err
+		}
+
+		return tpl.Export(), nil
+
 	default:
 		return nil, fmt.Errorf("unsupported grid definition template: %d", n)
 	}
@@ -54,8 +136,18 @@ func ReadTemplate(r io.Reader, n uint16) (Template, error) {
 
 func UnMarshalJSONTemplate(data []byte) (Template, error) {
 	var tpl struct {
-		Template0  *Template0FixedPart  `json:"template0"`
-		Template40 *Template40FixedPart `json:"template40"`
+		Template0     *Template0FixedPart     `json:"template0"`
+		Template1     *Template1FixedPart     `json:"template1"`
+		Template10    *Template10FixedPart    `json:"template10"`
+		Template20    *Template20FixedPart    `json:"template20"`
+		Template30    *Template30FixedPart    `json:"template30"`
+		Template40    *Template40FixedPart    `json:"template40"`
+		Template50    *Template50FixedPart    `json:"template50"`
+		Template90    *Template90FixedPart    `json:"template90"`
+		Template101   *Template101FixedPart   `json:"template101"`
+		Template204   *Template204FixedPart   `json:"template204"`
+		Template32768 *Template32768FixedPart `json:"template32768"`
+		Template32769 *Template32769FixedPart `json:"template32769"`
 	}
 
 	if err := json.Unmarshal(data, &tpl); err != nil {
@@ -65,8 +157,28 @@ func UnMarshalJSONTemplate(data []byte) (Template, error) {
 	switch {
 	case tpl.Template0 != nil:
 		return tpl.Template0.AsTemplate(), nil
+	case tpl.Template1 != nil:
+		return tpl.Template1.AsTemplate(), nil
+	case tpl.Template10 != nil:
+		return tpl.Template10.AsTemplate(), nil
+	case tpl.Template20 != nil:
+		return tpl.Template20.AsTemplate(), nil
+	case tpl.Template30 != nil:
+		return tpl.Template30.AsTemplate(), nil
 	case tpl.Template40 != nil:
 		return tpl.Template40.AsTemplate(), nil
+	case tpl.Template50 != nil:
+		return tpl.Template50.AsTemplate(), nil
+	case tpl.Template90 != nil:
+		return tpl.Template90.AsTemplate(), nil
+	case tpl.Template101 != nil:
+		return tpl.Template101.AsTemplate(), nil
+	case tpl.Template204 != nil:
+		return tpl.Template204.AsTemplate(), nil
+	case tpl.Template32768 != nil:
+		return tpl.Template32768.AsTemplate(), nil
+	case tpl.Template32769 != nil:
+		return tpl.Template32769.AsTemplate(), nil
 	}
 
 	return nil, fmt.Errorf("unsupported grid definition template")
@@ -1,6 +1,8 @@
 package gdt
 
 import (
+	"errors"
+	"fmt"
 	"math"
 
 	"github.com/scorix/grib-go/pkg/grib2/regulation"
@@ -25,8 +27,8 @@ in the grid. However, this may not be true if bit 8 of the scanning mode flags (
 */
 type Template0 struct {
 	Template0FixedPart `json:"template0"`
-	// TODO: 73-nn: List of number of points along each meridian or parallel (These octets are only present for quasi-regular grids as described in notes 2 and 3)
-	grids grids.Grid `json:"-"`
+	grids              grids.Grid   `json:"-"`
+	reduced            *reducedGrid `json:"-"`
 }
 
 type template0FixedPart struct {
@@ -66,29 +68,29 @@ func (t template0FixedPart) Export() Template {
 		SubdivisionsOfBasicAngle:               regulation.ToInt32(t.SubdivisionsOfBasicAngle),
 		LatitudeOfFirstGridPoint:               regulation.ToInt32(t.LatitudeOfFirstGridPoint),
 		LongitudeOfFirstGridPoint:              regulation.ToInt32(t.LongitudeOfFirstGridPoint),
-		ResolutionAndComponentFlags:            regulation.ToInt8(t.ResolutionAndComponentFlags),
+		ResolutionAndComponentFlags:            regulation.FlagOctet(t.ResolutionAndComponentFlags),
 		LatitudeOfLastGridPoint:                regulation.ToInt32(t.LatitudeOfLastGridPoint),
 		LongitudeOfLastGridPoint:               regulation.ToInt32(t.LongitudeOfLastGridPoint),
 		IDirectionIncrement:                    regulation.ToInt32(t.IDirectionIncrement),
 		JDirectionIncrement:                    regulation.ToInt32(t.JDirectionIncrement),
-		ScanningMode:                           regulation.ToInt8(t.ScanningMode),
+		ScanningMode:                           regulation.FlagOctet(t.ScanningMode),
 	}
 
 	return t0.AsTemplate()
 }
 
 type Template0FixedPart struct {
-	ShapeOfTheEarth                        int8  `json:"-"`
-	ScaleFactorOfRadiusOfSphericalEarth    int8  `json:"-"`
-	ScaledValueOfRadiusOfSphericalEarth    int32 `json:"-"`
-	ScaleFactorOfEarthMajorAxis            int8  `json:"-"`
-	ScaledValueOfEarthMajorAxis            int32 `json:"-"`
-	ScaleFactorOfEarthMinorAxis            int8  `json:"-"`
-	ScaledValueOfEarthMinorAxis            int32 `json:"-"`
+	ShapeOfTheEarth                        int8  `json:"shapeOfTheEarth,omitempty"`
+	ScaleFactorOfRadiusOfSphericalEarth    int8  `json:"scaleFactorOfRadiusOfSphericalEarth,omitempty"`
+	ScaledValueOfRadiusOfSphericalEarth    int32 `json:"scaledValueOfRadiusOfSphericalEarth,omitempty"`
+	ScaleFactorOfEarthMajorAxis            int8  `json:"scaleFactorOfEarthMajorAxis,omitempty"`
+	ScaledValueOfEarthMajorAxis            int32 `json:"scaledValueOfEarthMajorAxis,omitempty"`
+	ScaleFactorOfEarthMinorAxis            int8  `json:"scaleFactorOfEarthMinorAxis,omitempty"`
+	ScaledValueOfEarthMinorAxis            int32 `json:"scaledValueOfEarthMinorAxis,omitempty"`
 	Ni                                     int32 `json:"-"`
 	Nj                                     int32 `json:"-"`
-	BasicAngleOfTheInitialProductionDomain int32 `json:"-"`
-	SubdivisionsOfBasicAngle               int32 `json:"-"`
+	BasicAngleOfTheInitialProductionDomain int32 `json:"basicAngleOfTheInitialProductionDomain,omitempty"`
+	SubdivisionsOfBasicAngle               int32 `json:"subdivisionsOfBasicAngle,omitempty"`
 	LatitudeOfFirstGridPoint               int32 `json:"latitudeOfFirstGridPoint"`
 	LongitudeOfFirstGridPoint              int32 `json:"longitudeOfFirstGridPoint"`
 	ResolutionAndComponentFlags            int8  `json:"-"`
@@ -97,13 +99,21 @@ type Template0FixedPart struct {
 	IDirectionIncrement                    int32 `json:"iDirectionIncrement"`
 	JDirectionIncrement                    int32 `json:"jDirectionIncrement"`
 	ScanningMode                           int8  `json:"scanningMode"`
+	// 73-nn: List of number of points along each parallel of a quasi-regular grid, see notes 2 and 3
+	PointsPerRow []int32 `json:"pointsPerRow,omitempty"`
+	// Interpretation of the list of numbers of points, code table 3.11 in section 3
+	InterpretationOfNumberOfPoints int8 `json:"interpretationOfNumberOfPoints,omitempty"`
 }
 
 func (t *Template0FixedPart) AsTemplate() Template {
-	firstLat := float64(t.LatitudeOfFirstGridPoint) / 1e6
-	lastLat := float64(t.LatitudeOfLastGridPoint) / 1e6
-	firstLon := float64(t.LongitudeOfFirstGridPoint) / 1e6
-	lastLon := float64(t.LongitudeOfLastGridPoint) / 1e6
+	if len(t.PointsPerRow) > 0 {
+		return t.asQuasiRegular()
+	}
+
+	firstLat := t.degrees(t.LatitudeOfFirstGridPoint)
+	lastLat := t.degrees(t.LatitudeOfLastGridPoint)
+	firstLon := t.degrees(t.LongitudeOfFirstGridPoint)
+	lastLon := t.degrees(t.LongitudeOfLastGridPoint)
 	minLat := math.Min(firstLat, lastLat)
 	maxLat := math.Max(firstLat, lastLat)
 	minLon := math.Min(firstLon, lastLon)
@@ -116,12 +126,88 @@ func (t *Template0FixedPart) AsTemplate() Template {
 			maxLat,
 			minLon,
 			maxLon,
-			float64(t.IDirectionIncrement)/1e6,
-			float64(t.JDirectionIncrement)/1e6,
+			t.degrees(t.IDirectionIncrement),
+			t.degrees(t.JDirectionIncrement),
 		),
 	}
 }
 
+// asQuasiRegular returns the grid whose parallels are evenly spaced from the first to the last grid point.
+func (t *Template0FixedPart) asQuasiRegular() Template {
+	var (
+		rows = len(t.PointsPerRow)
+		lat1 = t.degrees(t.LatitudeOfFirstGridPoint)
+		lat2 = t.degrees(t.LatitudeOfLastGridPoint)
+		lats = make([]float64, rows)
+		scan = scanMode(t.ScanningMode)
+		lon1 = t.degrees(t.LongitudeOfFirstGridPoint)
+		lon2 = t.degrees(t.LongitudeOfLastGridPoint)
+	)
+
+	for j := range lats {
+		lats[j] = lat1
+		if rows > 1 {
+			lats[j] = lat1 + (lat2-lat1)*float64(j)/float64(rows-1)
+		}
+	}
+
+	return &Template0{
+		Template0FixedPart: *t,
+		reduced:            newReducedGrid(lats, t.PointsPerRow, lon1, lon2, scan, t.InterpretationOfNumberOfPoints != pointsBetweenExtremes),
+	}
+}
+
+// degrees returns v in the unit of the extreme latitudes and longitudes, and direction increments, see note 1.
+func (t *Template0FixedPart) degrees(v int32) float64 {
+	return degrees(v, t.BasicAngleOfTheInitialProductionDomain, t.SubdivisionsOfBasicAngle)
+}
+
+// Validate reports the inconsistencies between the numbers of points, the first and last grid points and the
+// direction increments of the grid.
+func (t *Template0FixedPart) Validate() error {
+	var (
+		mode = scanMode(t.ScanningMode)
+		unit = t.degrees(1)
+		lat1 = t.degrees(t.LatitudeOfFirstGridPoint)
+		lat2 = t.degrees(t.LatitudeOfLastGridPoint)
+		// bits 3 and 4 of the resolution and component flags: i and j direction increments are given
+		iGiven = uint8(t.ResolutionAndComponentFlags)&0x20 != 0
+		jGiven = uint8(t.ResolutionAndComponentFlags)&0x10 != 0
+	)
+
+	errs := []error{checkLatitudes(lat1, lat2, t.Nj, mode)}
+
+	if len(t.PointsPerRow) > 0 {
+		errs = append(errs, checkPointsPerRow(t.Nj, t.PointsPerRow, t.InterpretationOfNumberOfPoints))
+	} else if t.Ni <= 0 {
+		errs = append(errs, fmt.Errorf("invalid number of points along a parallel: %d", t.Ni))
+	} else if iGiven {
+		var (
+			di     = t.degrees(t.IDirectionIncrement)
+			extent = longitudeExtent(t.degrees(t.LongitudeOfFirstGridPoint), t.degrees(t.LongitudeOfLastGridPoint), t.Ni, di, mode)
+		)
+
+		errs = append(errs, checkIncrement("i", t.Ni, di, extent, unit))
+	}
+
+	if t.Nj <= 0 {
+		errs = append(errs, fmt.Errorf("invalid number of points along a meridian: %d", t.Nj))
+	} else if jGiven {
+		errs = append(errs, checkIncrement("j", t.Nj, t.degrees(t.JDirectionIncrement), math.Abs(lat2-lat1), unit))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *Template0FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(
+		t.ShapeOfTheEarth,
+		t.ScaleFactorOfRadiusOfSphericalEarth, t.ScaledValueOfRadiusOfSphericalEarth,
+		t.ScaleFactorOfEarthMajorAxis, t.ScaledValueOfEarthMajorAxis,
+		t.ScaleFactorOfEarthMinorAxis, t.ScaledValueOfEarthMinorAxis,
+	)
+}
+
 func (t *Template0FixedPart) GetNi() int32 {
 	return t.Ni
 }
@@ -131,10 +217,19 @@ func (t *Template0FixedPart) GetNj() int32 {
 }
 
 func (t *Template0) GetGridIndex(lat, lon float32) (n int) {
+	if t.reduced != nil {
+		return t.reduced.index(float64(lat), float64(lon))
+	}
+
 	return grids.GuessGridIndex(t.grids, float64(lat), float64(lon), grids.ScanMode(t.ScanningMode))
 }
 
 func (t *Template0) GetGridPoint(n int) (float32, float32, bool) {
+	if t.reduced != nil {
+		lat, lon, ok := t.reduced.point(n)
+		return float32(lat), float32(lon), ok
+	}
+
 	lat, lon, ok := grids.GridPoint(t.grids, n, grids.ScanMode(t.ScanningMode))
 	return float32(lat), float32(lon), ok
 }
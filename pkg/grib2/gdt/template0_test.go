@@ -1,6 +1,8 @@
 package gdt_test
 
 import (
+	"bytes"
+	"encoding/binary"
 	"testing"
 
 	"github.com/scorix/grib-go/pkg/grib2/gdt"
@@ -32,3 +34,191 @@ func TestTemplate0_GetGridIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestTemplate0_BasicAngle(t *testing.T) {
+	// 1/8 degree global grid, in units of 1/8 degree
+	tpldef := gdt.Template0FixedPart{
+		Ni:                                     2880,
+		Nj:                                     1441,
+		BasicAngleOfTheInitialProductionDomain: 1,
+		SubdivisionsOfBasicAngle:               8,
+		LatitudeOfFirstGridPoint:               720,
+		LongitudeOfFirstGridPoint:              0,
+		ResolutionAndComponentFlags:            48,
+		LatitudeOfLastGridPoint:                -720,
+		LongitudeOfLastGridPoint:               2879,
+		IDirectionIncrement:                    1,
+		JDirectionIncrement:                    1,
+	}
+	tpl := tpldef.AsTemplate()
+
+	require.NoError(t, gdt.Validate(tpl))
+
+	assert.Equal(t, 0, tpl.GetGridIndex(90, 0))
+	assert.Equal(t, 2880+1, tpl.GetGridIndex(89.875, 0.125))
+	assert.Equal(t, 720*2880+4, tpl.GetGridIndex(0, 0.5))
+
+	lat, lon, ok := tpl.GetGridPoint(720*2880 + 4)
+	require.True(t, ok)
+	assert.InDelta(t, 0, lat, 1e-6)
+	assert.InDelta(t, 0.5, lon, 1e-6)
+
+	// zero and missing values stand for the default unit of 10^-6 degrees
+	for _, angle := range [][2]int32{{0, 0}, {0, -1}, {-1, -1}} {
+		tpldef := gdt.Template0FixedPart{
+			Ni:                                     360,
+			Nj:                                     181,
+			BasicAngleOfTheInitialProductionDomain: angle[0],
+			SubdivisionsOfBasicAngle:               angle[1],
+			LatitudeOfFirstGridPoint:               90000000,
+			ResolutionAndComponentFlags:            48,
+			LatitudeOfLastGridPoint:                -90000000,
+			LongitudeOfLastGridPoint:               359000000,
+			IDirectionIncrement:                    1000000,
+			JDirectionIncrement:                    1000000,
+		}
+		tpl := tpldef.AsTemplate()
+
+		require.NoError(t, gdt.Validate(tpl), "angle: %v", angle)
+		assert.Equal(t, 361, tpl.GetGridIndex(89, 1), "angle: %v", angle)
+	}
+}
+
+func TestTemplate0_Validate(t *testing.T) {
+	valid := gdt.Template0FixedPart{
+		Ni:                          1440,
+		Nj:                          721,
+		SubdivisionsOfBasicAngle:    -1,
+		LatitudeOfFirstGridPoint:    90000000,
+		ResolutionAndComponentFlags: 48,
+		LatitudeOfLastGridPoint:     -90000000,
+		LongitudeOfLastGridPoint:    359750000,
+		IDirectionIncrement:         250000,
+		JDirectionIncrement:         250000,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*gdt.Template0FixedPart)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(*gdt.Template0FixedPart) {},
+		},
+		{
+			name: "one third degree increments",
+			modify: func(t *gdt.Template0FixedPart) {
+				t.Ni, t.Nj = 1080, 541
+				t.LongitudeOfLastGridPoint = 359666667
+				t.IDirectionIncrement, t.JDirectionIncrement = 333333, 333333
+			},
+		},
+		{
+			name: "first meridian repeated",
+			modify: func(t *gdt.Template0FixedPart) {
+				t.Ni = 1441
+				t.LongitudeOfLastGridPoint = 360000000
+			},
+		},
+		{
+			name: "increments not given",
+			modify: func(t *gdt.Template0FixedPart) {
+				t.ResolutionAndComponentFlags = 0
+				t.IDirectionIncrement, t.JDirectionIncrement = -1, -1
+			},
+		},
+		{
+			name: "south to north",
+			modify: func(t *gdt.Template0FixedPart) {
+				t.ScanningMode = 0x40
+				t.LatitudeOfFirstGridPoint, t.LatitudeOfLastGridPoint = -90000000, 90000000
+			},
+		},
+		{
+			name: "east to west",
+			modify: func(t *gdt.Template0FixedPart) {
+				t.ScanningMode = -128 // 0x80
+				t.LongitudeOfFirstGridPoint, t.LongitudeOfLastGridPoint = 359750000, 0
+			},
+		},
+		{
+			name:    "wrong i scanning direction",
+			modify:  func(t *gdt.Template0FixedPart) { t.ScanningMode = -128 },
+			wantErr: "the first and last grid points are 0.250000 degrees apart",
+		},
+		{
+			name:    "wrong ni",
+			modify:  func(t *gdt.Template0FixedPart) { t.Ni = 1439 },
+			wantErr: "1439 points in the i direction",
+		},
+		{
+			name:    "wrong nj",
+			modify:  func(t *gdt.Template0FixedPart) { t.Nj = 181 },
+			wantErr: "181 points in the j direction",
+		},
+		{
+			name:    "wrong increment",
+			modify:  func(t *gdt.Template0FixedPart) { t.JDirectionIncrement = 1000000 },
+			wantErr: "j direction spaced by 1.000000",
+		},
+		{
+			name:    "missing increment",
+			modify:  func(t *gdt.Template0FixedPart) { t.IDirectionIncrement = -1 },
+			wantErr: "i direction increment -0.000001 is not positive",
+		},
+		{
+			name:    "wrong scanning direction",
+			modify:  func(t *gdt.Template0FixedPart) { t.ScanningMode = 0x40 },
+			wantErr: "not in the j scanning direction",
+		},
+		{
+			name:    "latitude out of range",
+			modify:  func(t *gdt.Template0FixedPart) { t.LatitudeOfFirstGridPoint = 90250000; t.Nj = 722 },
+			wantErr: "latitude 90.250000 is out of [-90, 90]",
+		},
+		{
+			name:    "invalid ni",
+			modify:  func(t *gdt.Template0FixedPart) { t.Ni = -1 },
+			wantErr: "invalid number of points along a parallel: -1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpldef := valid
+			tt.modify(&tpldef)
+
+			err := gdt.Validate(tpldef.AsTemplate())
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadTemplate0_Validate(t *testing.T) {
+	var buf bytes.Buffer
+
+	// 0.25 degree global grid scanning from east to west
+	for _, v := range []any{
+		uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), // shape of the earth
+		uint32(1440), uint32(721), uint32(0), uint32(0xffffffff), // ni, nj, basic angle
+		uint32(90000000), uint32(359750000), uint8(48), // first grid point, resolution and component flags
+		uint32(0x80000000 | 90000000), uint32(0), uint32(250000), uint32(250000), // last grid point, di, dj
+		uint8(0x80), // scanning mode
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	tpl, err := gdt.ReadTemplate(&buf, 0)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template0{}, tpl)
+	assert.Equal(t, int8(-128), tpl.(*gdt.Template0).ScanningMode)
+	assert.Equal(t, int8(48), tpl.(*gdt.Template0).ResolutionAndComponentFlags)
+
+	require.NoError(t, gdt.Validate(tpl))
+	assert.Equal(t, 1, tpl.GetGridIndex(90, 359.5))
+}
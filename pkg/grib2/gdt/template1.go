@@ -0,0 +1,157 @@
+package gdt
+
+import (
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) Basic angle of the initial production domain and subdivisions of this basic angle are provided to manage cases where the recommended unit of 10-6 degrees is not applicable to describe the extreme longitudes and latitudes, and direction increments. For these last six descriptors, the unit is equal to the ratio of the basic angle and the subdivisions number. For ordinary cases, zero and missing values should be coded, equivalent to respective values of 1 and 106 (10-6 degrees unit).
+
+( 2) Three parameters define a general latitude/longitude coordinate system, formed by a general rotation of the sphere. One choice for these parameters is: (a) The geographic latitude in degrees of the southern pole of the coordinate system, θp for example. (b) The geographic longitude in degrees of the southern pole of the coordinate system, λp for example. (c) The angle of rotation in degrees about the new polar axis (measured clockwise when looking from the southern to the northern pole) of the coordinate system, assuming the new axis to have been obtained by first rotating the sphere through λp degrees about the geographic polar axis, and then rotating through (90 + θp) degrees so that the southern pole moved along the (previously rotated) Greenwich meridian.
+
+( 3) The first and last grid points, and the direction increments, are given in the rotated coordinate system.
+*/
+type Template1 struct {
+	Template1FixedPart `json:"template1"`
+	grid               *rotatedGrid `json:"-"`
+}
+
+// https://codes.ecmwf.int/grib/format/grib2/templates/3/1/
+type template1FixedPart struct {
+	ShapeOfTheEarth                        uint8
+	ScaleFactorOfRadiusOfSphericalEarth    uint8
+	ScaledValueOfRadiusOfSphericalEarth    uint32
+	ScaleFactorOfEarthMajorAxis            uint8
+	ScaledValueOfEarthMajorAxis            uint32
+	ScaleFactorOfEarthMinorAxis            uint8
+	ScaledValueOfEarthMinorAxis            uint32
+	Ni                                     uint32
+	Nj                                     uint32
+	BasicAngleOfTheInitialProductionDomain uint32
+	SubdivisionsOfBasicAngle               uint32
+	LatitudeOfFirstGridPoint               uint32
+	LongitudeOfFirstGridPoint              uint32
+	ResolutionAndComponentFlags            uint8
+	LatitudeOfLastGridPoint                uint32
+	LongitudeOfLastGridPoint               uint32
+	IDirectionIncrement                    uint32
+	JDirectionIncrement                    uint32
+	ScanningMode                           uint8
+	LatitudeOfSouthernPole                 uint32
+	LongitudeOfSouthernPole                uint32
+	AngleOfRotation                        float32
+}
+
+func (t template1FixedPart) Export() Template {
+	t1 := Template1FixedPart{
+		ShapeOfTheEarth:                        regulation.ToInt8(t.ShapeOfTheEarth),
+		ScaleFactorOfRadiusOfSphericalEarth:    regulation.ToInt8(t.ScaleFactorOfRadiusOfSphericalEarth),
+		ScaledValueOfRadiusOfSphericalEarth:    regulation.ToInt32(t.ScaledValueOfRadiusOfSphericalEarth),
+		ScaleFactorOfEarthMajorAxis:            regulation.ToInt8(t.ScaleFactorOfEarthMajorAxis),
+		ScaledValueOfEarthMajorAxis:            regulation.ToInt32(t.ScaledValueOfEarthMajorAxis),
+		ScaleFactorOfEarthMinorAxis:            regulation.ToInt8(t.ScaleFactorOfEarthMinorAxis),
+		ScaledValueOfEarthMinorAxis:            regulation.ToInt32(t.ScaledValueOfEarthMinorAxis),
+		Ni:                                     regulation.ToInt32(t.Ni),
+		Nj:                                     regulation.ToInt32(t.Nj),
+		BasicAngleOfTheInitialProductionDomain: regulation.ToInt32(t.BasicAngleOfTheInitialProductionDomain),
+		SubdivisionsOfBasicAngle:               regulation.ToInt32(t.SubdivisionsOfBasicAngle),
+		LatitudeOfFirstGridPoint:               regulation.ToInt32(t.LatitudeOfFirstGridPoint),
+		LongitudeOfFirstGridPoint:              regulation.ToInt32(t.LongitudeOfFirstGridPoint),
+		ResolutionAndComponentFlags:            regulation.FlagOctet(t.ResolutionAndComponentFlags),
+		LatitudeOfLastGridPoint:                regulation.ToInt32(t.LatitudeOfLastGridPoint),
+		LongitudeOfLastGridPoint:               regulation.ToInt32(t.LongitudeOfLastGridPoint),
+		IDirectionIncrement:                    regulation.ToInt32(t.IDirectionIncrement),
+		JDirectionIncrement:                    regulation.ToInt32(t.JDirectionIncrement),
+		ScanningMode:                           regulation.FlagOctet(t.ScanningMode),
+		LatitudeOfSouthernPole:                 regulation.ToInt32(t.LatitudeOfSouthernPole),
+		LongitudeOfSouthernPole:                regulation.ToInt32(t.LongitudeOfSouthernPole),
+		AngleOfRotation:                        t.AngleOfRotation,
+	}
+
+	return t1.AsTemplate()
+}
+
+type Template1FixedPart struct {
+	ShapeOfTheEarth                        int8    `json:"shapeOfTheEarth,omitempty"`
+	ScaleFactorOfRadiusOfSphericalEarth    int8    `json:"scaleFactorOfRadiusOfSphericalEarth,omitempty"`
+	ScaledValueOfRadiusOfSphericalEarth    int32   `json:"scaledValueOfRadiusOfSphericalEarth,omitempty"`
+	ScaleFactorOfEarthMajorAxis            int8    `json:"scaleFactorOfEarthMajorAxis,omitempty"`
+	ScaledValueOfEarthMajorAxis            int32   `json:"scaledValueOfEarthMajorAxis,omitempty"`
+	ScaleFactorOfEarthMinorAxis            int8    `json:"scaleFactorOfEarthMinorAxis,omitempty"`
+	ScaledValueOfEarthMinorAxis            int32   `json:"scaledValueOfEarthMinorAxis,omitempty"`
+	Ni                                     int32   `json:"ni"`
+	Nj                                     int32   `json:"nj"`
+	BasicAngleOfTheInitialProductionDomain int32   `json:"basicAngleOfTheInitialProductionDomain,omitempty"`
+	SubdivisionsOfBasicAngle               int32   `json:"subdivisionsOfBasicAngle,omitempty"`
+	LatitudeOfFirstGridPoint               int32   `json:"latitudeOfFirstGridPoint"`
+	LongitudeOfFirstGridPoint              int32   `json:"longitudeOfFirstGridPoint"`
+	ResolutionAndComponentFlags            int8    `json:"-"`
+	LatitudeOfLastGridPoint                int32   `json:"latitudeOfLastGridPoint"`
+	LongitudeOfLastGridPoint               int32   `json:"longitudeOfLastGridPoint"`
+	IDirectionIncrement                    int32   `json:"iDirectionIncrement"`
+	JDirectionIncrement                    int32   `json:"jDirectionIncrement"`
+	ScanningMode                           int8    `json:"scanningMode"`
+	LatitudeOfSouthernPole                 int32   `json:"latitudeOfSouthernPole"`
+	LongitudeOfSouthernPole                int32   `json:"longitudeOfSouthernPole"`
+	AngleOfRotation                        float32 `json:"angleOfRotation"`
+}
+
+func (t *Template1FixedPart) AsTemplate() Template {
+	return &Template1{
+		Template1FixedPart: *t,
+		grid: newRotatedGrid(
+			newRotatedPole(float64(t.LatitudeOfSouthernPole)/1e6, float64(t.LongitudeOfSouthernPole)/1e6, float64(t.AngleOfRotation)),
+			t.degrees(t.LatitudeOfFirstGridPoint),
+			t.degrees(t.LongitudeOfFirstGridPoint),
+			t.degrees(t.IDirectionIncrement),
+			t.degrees(t.JDirectionIncrement),
+			int(t.Ni),
+			int(t.Nj),
+			scanMode(t.ScanningMode),
+		),
+	}
+}
+
+func (t *Template1FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(
+		t.ShapeOfTheEarth,
+		t.ScaleFactorOfRadiusOfSphericalEarth, t.ScaledValueOfRadiusOfSphericalEarth,
+		t.ScaleFactorOfEarthMajorAxis, t.ScaledValueOfEarthMajorAxis,
+		t.ScaleFactorOfEarthMinorAxis, t.ScaledValueOfEarthMinorAxis,
+	)
+}
+
+func (t *Template1FixedPart) GetNi() int32 {
+	return t.Ni
+}
+
+func (t *Template1FixedPart) GetNj() int32 {
+	return t.Nj
+}
+
+// degrees returns v in the unit of the extreme latitudes and longitudes, and direction increments, see note 1.
+func (t *Template1FixedPart) degrees(v int32) float64 {
+	return degrees(v, t.BasicAngleOfTheInitialProductionDomain, t.SubdivisionsOfBasicAngle)
+}
+
+// Rotate returns the rotated coordinates of the geographic point lat, lon.
+func (t *Template1) Rotate(lat, lon float64) (float64, float64) {
+	return t.grid.pole.rotate(lat, lon)
+}
+
+// Unrotate returns the geographic coordinates of the rotated point rlat, rlon, the longitude is in [0, 360).
+func (t *Template1) Unrotate(rlat, rlon float64) (float64, float64) {
+	return t.grid.pole.unrotate(rlat, rlon)
+}
+
+// GetGridIndex returns the grid point nearest to the geographic point lat, lon, -1 if it is out of the grid.
+func (t *Template1) GetGridIndex(lat, lon float32) (n int) {
+	return t.grid.locate(float64(lat), float64(lon))
+}
+
+// GetGridPoint returns the geographic latitude and longitude of the nth grid point.
+func (t *Template1) GetGridPoint(n int) (float32, float32, bool) {
+	lat, lon, ok := t.grid.point(n)
+	return float32(lat), float32(lon), ok
+}
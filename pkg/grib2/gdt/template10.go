@@ -0,0 +1,187 @@
+package gdt
+
+import (
+	"math"
+
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) The latitudes and longitudes are in units of 10-6 degrees, Di and Dj are in units of 10-3 metres.
+
+( 2) Di and Dj are the grid lengths at the latitude LaD, the latitude at which the Mercator projection intersects the Earth.
+
+( 3) The orientation of the grid is the angle between the i direction on the map and the equator.
+
+( 4) A scaled value of radius of spherical Earth, or major or minor axis of oblate spheroid Earth is derived from applying appropriate scale factor to the value expressed in metres.
+
+( 5) It is recommended to use unsigned direction increments.
+*/
+type Template10 struct {
+	Template10FixedPart `json:"template10"`
+	grid                *projectedGrid `json:"-"`
+}
+
+// https://codes.ecmwf.int/grib/format/grib2/templates/3/10/
+type template10FixedPart struct {
+	ShapeOfTheEarth                     uint8
+	ScaleFactorOfRadiusOfSphericalEarth uint8
+	ScaledValueOfRadiusOfSphericalEarth uint32
+	ScaleFactorOfEarthMajorAxis         uint8
+	ScaledValueOfEarthMajorAxis         uint32
+	ScaleFactorOfEarthMinorAxis         uint8
+	ScaledValueOfEarthMinorAxis         uint32
+	Ni                                  uint32
+	Nj                                  uint32
+	LatitudeOfFirstGridPoint            uint32
+	LongitudeOfFirstGridPoint           uint32
+	ResolutionAndComponentFlags         uint8
+	LaD                                 uint32
+	LatitudeOfLastGridPoint             uint32
+	LongitudeOfLastGridPoint            uint32
+	ScanningMode                        uint8
+	OrientationOfTheGrid                uint32
+	Di                                  uint32
+	Dj                                  uint32
+}
+
+func (t template10FixedPart) Export() Template {
+	t10 := Template10FixedPart{
+		ShapeOfTheEarth:                     regulation.ToInt8(t.ShapeOfTheEarth),
+		ScaleFactorOfRadiusOfSphericalEarth: regulation.ToInt8(t.ScaleFactorOfRadiusOfSphericalEarth),
+		ScaledValueOfRadiusOfSphericalEarth: regulation.ToInt32(t.ScaledValueOfRadiusOfSphericalEarth),
+		ScaleFactorOfEarthMajorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMajorAxis),
+		ScaledValueOfEarthMajorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMajorAxis),
+		ScaleFactorOfEarthMinorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMinorAxis),
+		ScaledValueOfEarthMinorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMinorAxis),
+		Ni:                                  regulation.ToInt32(t.Ni),
+		Nj:                                  regulation.ToInt32(t.Nj),
+		LatitudeOfFirstGridPoint:            regulation.ToInt32(t.LatitudeOfFirstGridPoint),
+		LongitudeOfFirstGridPoint:           regulation.ToInt32(t.LongitudeOfFirstGridPoint),
+		ResolutionAndComponentFlags:         regulation.FlagOctet(t.ResolutionAndComponentFlags),
+		LaD:                                 regulation.ToInt32(t.LaD),
+		LatitudeOfLastGridPoint:             regulation.ToInt32(t.LatitudeOfLastGridPoint),
+		LongitudeOfLastGridPoint:            regulation.ToInt32(t.LongitudeOfLastGridPoint),
+		ScanningMode:                        regulation.FlagOctet(t.ScanningMode),
+		OrientationOfTheGrid:                regulation.ToInt32(t.OrientationOfTheGrid),
+		Di:                                  regulation.ToInt32(t.Di),
+		Dj:                                  regulation.ToInt32(t.Dj),
+	}
+
+	return t10.AsTemplate()
+}
+
+type Template10FixedPart struct {
+	ShapeOfTheEarth                     int8  `json:"shapeOfTheEarth"`
+	ScaleFactorOfRadiusOfSphericalEarth int8  `json:"scaleFactorOfRadiusOfSphericalEarth"`
+	ScaledValueOfRadiusOfSphericalEarth int32 `json:"scaledValueOfRadiusOfSphericalEarth"`
+	ScaleFactorOfEarthMajorAxis         int8  `json:"scaleFactorOfEarthMajorAxis"`
+	ScaledValueOfEarthMajorAxis         int32 `json:"scaledValueOfEarthMajorAxis"`
+	ScaleFactorOfEarthMinorAxis         int8  `json:"scaleFactorOfEarthMinorAxis"`
+	ScaledValueOfEarthMinorAxis         int32 `json:"scaledValueOfEarthMinorAxis"`
+	Ni                                  int32 `json:"ni"`
+	Nj                                  int32 `json:"nj"`
+	LatitudeOfFirstGridPoint            int32 `json:"latitudeOfFirstGridPoint"`
+	LongitudeOfFirstGridPoint           int32 `json:"longitudeOfFirstGridPoint"`
+	ResolutionAndComponentFlags         int8  `json:"-"`
+	LaD                                 int32 `json:"laD"`
+	LatitudeOfLastGridPoint             int32 `json:"latitudeOfLastGridPoint"`
+	LongitudeOfLastGridPoint            int32 `json:"longitudeOfLastGridPoint"`
+	ScanningMode                        int8  `json:"scanningMode"`
+	OrientationOfTheGrid                int32 `json:"orientationOfTheGrid"`
+	Di                                  int32 `json:"di"`
+	Dj                                  int32 `json:"dj"`
+}
+
+func (t *Template10FixedPart) AsTemplate() Template {
+	earth := t.GetEarthShape()
+
+	var (
+		mode = scanMode(t.ScanningMode)
+		lon1 = float64(t.LongitudeOfFirstGridPoint) / 1e6
+		// longitudes are measured from the middle of the grid, so that any grid up to 360 degrees
+		// wide is continuous on the plane
+		span    = normalizeLongitude(mode.iSign() * (float64(t.LongitudeOfLastGridPoint)/1e6 - lon1))
+		central = lon1 + mode.iSign()*span/2
+	)
+
+	p := newMercator(earth, float64(t.LaD)/1e6, central, float64(t.OrientationOfTheGrid)/1e6)
+
+	return &Template10{
+		Template10FixedPart: *t,
+		grid: newProjectedGrid(
+			p,
+			float64(t.LatitudeOfFirstGridPoint)/1e6,
+			lon1,
+			float64(t.Di)/1e3,
+			float64(t.Dj)/1e3,
+			int(t.Ni),
+			int(t.Nj),
+			mode,
+		),
+	}
+}
+
+func (t *Template10FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(
+		t.ShapeOfTheEarth,
+		t.ScaleFactorOfRadiusOfSphericalEarth, t.ScaledValueOfRadiusOfSphericalEarth,
+		t.ScaleFactorOfEarthMajorAxis, t.ScaledValueOfEarthMajorAxis,
+		t.ScaleFactorOfEarthMinorAxis, t.ScaledValueOfEarthMinorAxis,
+	)
+}
+
+func (t *Template10FixedPart) GetNi() int32 {
+	return t.Ni
+}
+
+func (t *Template10FixedPart) GetNj() int32 {
+	return t.Nj
+}
+
+func (t *Template10) GetGridIndex(lat, lon float32) (n int) {
+	return t.grid.index(float64(lat), float64(lon))
+}
+
+func (t *Template10) GetGridPoint(n int) (float32, float32, bool) {
+	lat, lon, ok := t.grid.point(n)
+	return float32(lat), float32(lon), ok
+}
+
+// mercator is the Mercator projection of the Earth, with the central meridian at x = 0 and the
+// equator at y = 0, the axes are rotated by the orientation of the grid.
+type mercator struct {
+	earth    Ellipsoid
+	k        float64 // semi-major axis times the scale at the equator, so that the scale is true at LaD
+	central  float64 // radians
+	sin, cos float64 // orientation of the grid
+}
+
+func newMercator(earth Ellipsoid, lad, central, orientation float64) *mercator {
+	return &mercator{
+		earth:   earth,
+		k:       earth.SemiMajorAxis * earth.msfn(lad*math.Pi/180),
+		central: central * math.Pi / 180,
+		sin:     math.Sin(orientation * math.Pi / 180),
+		cos:     math.Cos(orientation * math.Pi / 180),
+	}
+}
+
+func (p *mercator) forward(lat, lon float64) (float64, float64) {
+	var (
+		x = p.k * math.Remainder(lon*math.Pi/180-p.central, 2*math.Pi)
+		y = -p.k * math.Log(p.earth.tsfn(lat*math.Pi/180))
+	)
+
+	return p.cos*x + p.sin*y, p.cos*y - p.sin*x
+}
+
+func (p *mercator) inverse(x, y float64) (float64, float64) {
+	x, y = p.cos*x-p.sin*y, p.sin*x+p.cos*y
+
+	lat := p.earth.phi(math.Exp(-y / p.k))
+	lon := p.central + x/p.k
+
+	return lat * 180 / math.Pi, lon * 180 / math.Pi
+}
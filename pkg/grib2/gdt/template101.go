@@ -0,0 +1,95 @@
+package gdt
+
+import (
+	"fmt"
+
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) The grid points are not in rows, their coordinates are not in the message but in a grid file, which is identified by the UUID of the horizontal grid and the number of grid used.
+
+( 2) The coordinates of the grid must be registered before the grid points can be located, see RegisterUnstructuredGrid, RegisterUnstructuredGridFile and RegisterUnstructuredGridSource.
+*/
+type Template101 struct {
+	Template101FixedPart `json:"template101"`
+}
+
+// https://codes.ecmwf.int/grib/format/grib2/templates/3/101/
+type template101FixedPart struct {
+	ShapeOfTheEarth         uint8
+	NumberOfGridUsed        [3]byte
+	NumberOfGridInReference uint8
+	UUIDOfTheHorizontalGrid [16]byte
+}
+
+func (t template101FixedPart) Export() Template {
+	u := t.UUIDOfTheHorizontalGrid
+
+	t101 := Template101FixedPart{
+		ShapeOfTheEarth:         regulation.ToInt8(t.ShapeOfTheEarth),
+		NumberOfGridUsed:        int32(t.NumberOfGridUsed[0])<<16 | int32(t.NumberOfGridUsed[1])<<8 | int32(t.NumberOfGridUsed[2]),
+		NumberOfGridInReference: regulation.ToInt8(t.NumberOfGridInReference),
+		UUIDOfTheHorizontalGrid: fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16]),
+	}
+
+	return t101.AsTemplate()
+}
+
+type Template101FixedPart struct {
+	ShapeOfTheEarth         int8   `json:"shapeOfTheEarth"`
+	NumberOfGridUsed        int32  `json:"numberOfGridUsed"`
+	NumberOfGridInReference int8   `json:"numberOfGridInReference"`
+	UUIDOfTheHorizontalGrid string `json:"uuidOfHGrid"`
+}
+
+func (t *Template101FixedPart) AsTemplate() Template {
+	return &Template101{
+		Template101FixedPart: *t,
+	}
+}
+
+func (t *Template101FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(t.ShapeOfTheEarth, 0, 0, 0, 0, 0, 0)
+}
+
+// GetNi returns 0, the grid points are not in rows.
+func (t *Template101FixedPart) GetNi() int32 {
+	return 0
+}
+
+// GetNj returns 0, the grid points are not in rows.
+func (t *Template101FixedPart) GetNj() int32 {
+	return 0
+}
+
+// Validate reports the coordinates of the grid which are not registered or can not be loaded.
+func (t *Template101FixedPart) Validate() error {
+	if _, err := lookupUnstructuredGrid(t.UUIDOfTheHorizontalGrid); err != nil {
+		return fmt.Errorf("%w: %w", ErrNoGridPoints, err)
+	}
+
+	return nil
+}
+
+// GetGridIndex returns the grid point nearest to the point lat, lon, -1 if the coordinates of the grid
+// are not registered, see note 2.
+func (t *Template101) GetGridIndex(lat, lon float32) (n int) {
+	g, err := lookupUnstructuredGrid(t.UUIDOfTheHorizontalGrid)
+	if err != nil {
+		return -1
+	}
+
+	return g.tree.nearest(float64(lat), float64(lon))
+}
+
+// GetGridPoint returns the latitude and longitude of the nth grid point, see note 2.
+func (t *Template101) GetGridPoint(n int) (float32, float32, bool) {
+	g, err := lookupUnstructuredGrid(t.UUIDOfTheHorizontalGrid)
+	if err != nil || n < 0 || n >= len(g.lats) {
+		return 0, 0, false
+	}
+
+	return float32(g.lats[n]), float32(normalizeLongitude(g.lons[n])), true
+}
@@ -0,0 +1,127 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// greatCircle returns the angle in radians between two points.
+func greatCircle(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := lat1*math.Pi/180, lat2*math.Pi/180
+	c := math.Sin(phi1)*math.Sin(phi2) + math.Cos(phi1)*math.Cos(phi2)*math.Cos((lon2-lon1)*math.Pi/180)
+
+	return math.Acos(math.Max(-1, math.Min(1, c)))
+}
+
+func TestTemplate101(t *testing.T) {
+	const uuid = "0b3c6e8a-11b4-4ad9-9d1e-7f0a2c5d9e01"
+
+	var (
+		rnd        = rand.New(rand.NewSource(101))
+		lats, lons = make([]float64, 2000), make([]float64, 2000)
+	)
+
+	for i := range lats {
+		lats[i] = math.Asin(2*rnd.Float64()-1) * 180 / math.Pi
+		lons[i] = rnd.Float64()*360 - 180
+	}
+
+	tpl := (&gdt.Template101FixedPart{ShapeOfTheEarth: 6, NumberOfGridUsed: 26, UUIDOfTheHorizontalGrid: uuid}).AsTemplate()
+
+	assert.Equal(t, -1, tpl.GetGridIndex(0, 0))
+	assert.Error(t, gdt.Validate(tpl))
+
+	require.Error(t, gdt.RegisterUnstructuredGrid(uuid, lats, lons[1:]))
+	require.NoError(t, gdt.RegisterUnstructuredGrid(uuid, lats, lons))
+	require.NoError(t, gdt.Validate(tpl))
+
+	for n := range lats {
+		lat, lon, ok := tpl.GetGridPoint(n)
+		require.True(t, ok)
+		assert.InDelta(t, lats[n], lat, 1e-4)
+		assert.InDelta(t, 0, math.Remainder(lons[n]-float64(lon), 360), 1e-4)
+	}
+
+	_, _, ok := tpl.GetGridPoint(len(lats))
+	assert.False(t, ok)
+
+	for range 500 {
+		lat := math.Asin(2*rnd.Float64()-1) * 180 / math.Pi
+		lon := rnd.Float64() * 360
+
+		want, dist := -1, math.Inf(1)
+		for i := range lats {
+			if d := greatCircle(lat, lon, lats[i], lons[i]); d < dist {
+				want, dist = i, d
+			}
+		}
+
+		got := tpl.GetGridIndex(float32(lat), float32(lon))
+		require.GreaterOrEqual(t, got, 0)
+		assert.InDelta(t, dist, greatCircle(lat, lon, lats[got], lons[got]), 1e-6, "lat: %f, lon: %f, want: %d, got: %d", lat, lon, want, got)
+	}
+}
+
+func TestTemplate101_RegisterFile(t *testing.T) {
+	const uuid = "5d6c4e1a-0f2b-4c3d-8e9f-a1b2c3d4e5f6"
+
+	var (
+		dir   = t.TempDir()
+		valid = filepath.Join(dir, "grid.json")
+		other = filepath.Join(dir, "other.json")
+	)
+
+	require.NoError(t, os.WriteFile(valid, []byte(`{"uuid":"5D6C4E1A-0F2B-4C3D-8E9F-A1B2C3D4E5F6","latitudes":[90,0,0,-90],"longitudes":[0,0,90,0]}`), 0o644))
+	require.NoError(t, os.WriteFile(other, []byte(`{"uuid":"a27b8de6-18c4-11e4-820a-b5b098c6a5c0","latitudes":[0],"longitudes":[0]}`), 0o644))
+
+	tpl := (&gdt.Template101FixedPart{UUIDOfTheHorizontalGrid: uuid}).AsTemplate()
+
+	gdt.RegisterUnstructuredGridFile(uuid, other)
+	assert.Error(t, gdt.Validate(tpl))
+	assert.Equal(t, -1, tpl.GetGridIndex(0, 0))
+
+	gdt.RegisterUnstructuredGridFile(uuid, filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, gdt.Validate(tpl), os.ErrNotExist)
+
+	gdt.RegisterUnstructuredGridFile(uuid, valid)
+	require.NoError(t, gdt.Validate(tpl))
+
+	assert.Equal(t, 0, tpl.GetGridIndex(80, 200))
+	assert.Equal(t, 1, tpl.GetGridIndex(10, -10))
+	assert.Equal(t, 2, tpl.GetGridIndex(-10, 80))
+	assert.Equal(t, 3, tpl.GetGridIndex(-60, 0))
+}
+
+func TestReadTemplate101(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, v := range []any{
+		uint8(6), [3]byte{0, 0, 26}, uint8(1), // shape of the earth, number of grid used and in reference
+		[16]byte{0xa2, 0x7b, 0x8d, 0xe6, 0x18, 0xc4, 0x11, 0xe4, 0x82, 0x0a, 0xb5, 0xb0, 0x98, 0xc6, 0xa5, 0xc0}, // uuid
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	require.Equal(t, 21, buf.Len())
+
+	tpl, err := gdt.ReadTemplate(&buf, 101)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template101{}, tpl)
+
+	t101 := tpl.(*gdt.Template101)
+	assert.Equal(t, int32(0), t101.GetNi())
+	assert.Equal(t, int32(0), t101.GetNj())
+	assert.Equal(t, int32(26), t101.NumberOfGridUsed)
+	assert.Equal(t, int8(1), t101.NumberOfGridInReference)
+	assert.Equal(t, "a27b8de6-18c4-11e4-820a-b5b098c6a5c0", t101.UUIDOfTheHorizontalGrid)
+	assert.Equal(t, gdt.NewEllipsoid(6, 0, 0, 0, 0, 0, 0), tpl.GetEarthShape())
+}
@@ -0,0 +1,179 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mercatorLL returns the latitude and longitude i, j steps of 20 km away from 0N 350E on the
+// Mercator projection of a sphere true at 20N.
+func mercatorLL(i, j float64) (float64, float64) {
+	k := 6371229 * math.Cos(20*math.Pi/180)
+
+	lat := 2*math.Atan(math.Exp(j*20000/k)) - math.Pi/2
+	lon := 350 + i*20000/k*180/math.Pi
+
+	return lat * 180 / math.Pi, math.Mod(lon, 360)
+}
+
+func TestTemplate10_GetGridPoint(t *testing.T) {
+	lat2, lon2 := mercatorLL(249, 99)
+
+	tpl := (&gdt.Template10FixedPart{
+		ShapeOfTheEarth:           6,
+		Ni:                        250,
+		Nj:                        100,
+		LatitudeOfFirstGridPoint:  0,
+		LongitudeOfFirstGridPoint: 350000000,
+		LaD:                       20000000,
+		LatitudeOfLastGridPoint:   int32(math.Round(lat2 * 1e6)),
+		LongitudeOfLastGridPoint:  int32(math.Round(lon2 * 1e6)),
+		ScanningMode:              0x40,
+		Di:                        20000000,
+		Dj:                        20000000,
+	}).AsTemplate()
+
+	tests := []struct {
+		n    int
+		i, j float64
+	}{
+		{n: 0, i: 0, j: 0},
+		{n: 1, i: 1, j: 0},
+		{n: 250, i: 0, j: 1},
+		{n: 250*50 + 100, i: 100, j: 50},
+		{n: 250*100 - 1, i: 249, j: 99},
+	}
+
+	for _, tt := range tests {
+		wantLat, wantLon := mercatorLL(tt.i, tt.j)
+
+		lat, lon, ok := tpl.GetGridPoint(tt.n)
+		require.True(t, ok)
+		assert.InDelta(t, wantLat, lat, 1e-4, "grid point %d", tt.n)
+		assert.InDelta(t, wantLon, lon, 1e-4, "grid point %d", tt.n)
+		assert.Equal(t, tt.n, tpl.GetGridIndex(float32(wantLat), float32(wantLon)), "grid point %d", tt.n)
+	}
+
+	// the grid crosses the Greenwich meridian
+	_, lon, _ := tpl.GetGridPoint(249)
+	assert.Less(t, lon, float32(90))
+
+	assert.Equal(t, -1, tpl.GetGridIndex(-1, 0))
+	assert.Equal(t, -1, tpl.GetGridIndex(10, 300))
+}
+
+func TestTemplate10_GetGridIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		tpldef gdt.Template10FixedPart
+	}{
+		{
+			name: "-i, +j, j consecutive",
+			tpldef: gdt.Template10FixedPart{
+				ShapeOfTheEarth:           6,
+				Ni:                        30,
+				Nj:                        20,
+				LatitudeOfFirstGridPoint:  -10000000,
+				LongitudeOfFirstGridPoint: 5000000,
+				LaD:                       0,
+				LatitudeOfLastGridPoint:   10000000,
+				LongitudeOfLastGridPoint:  330000000,
+				ScanningMode:              -32, // 0xe0
+				Di:                        100000000,
+				Dj:                        100000000,
+			},
+		},
+		{
+			name: "oriented grid",
+			tpldef: gdt.Template10FixedPart{
+				ShapeOfTheEarth:                     1,
+				ScaledValueOfRadiusOfSphericalEarth: 6371200,
+				Ni:                                  20,
+				Nj:                                  20,
+				LatitudeOfFirstGridPoint:            30000000,
+				LongitudeOfFirstGridPoint:           120000000,
+				LaD:                                 30000000,
+				LatitudeOfLastGridPoint:             45000000,
+				LongitudeOfLastGridPoint:            140000000,
+				ScanningMode:                        0x40,
+				OrientationOfTheGrid:                15000000,
+				Di:                                  50000000,
+				Dj:                                  50000000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := tt.tpldef.AsTemplate()
+
+			lat, lon, ok := tpl.GetGridPoint(0)
+			require.True(t, ok)
+			assert.InDelta(t, float64(tt.tpldef.LatitudeOfFirstGridPoint)/1e6, lat, 1e-4)
+			assert.InDelta(t, float64(tt.tpldef.LongitudeOfFirstGridPoint)/1e6, lon, 1e-4)
+
+			for n := 0; n < int(tpl.GetNi()*tpl.GetNj()); n++ {
+				lat, lon, ok := tpl.GetGridPoint(n)
+				require.True(t, ok)
+				require.Equal(t, n, tpl.GetGridIndex(lat, lon), "lat: %f, lon: %f", lat, lon)
+			}
+		})
+	}
+}
+
+func TestReadTemplate10(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, v := range []any{
+		uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), // shape of the earth
+		uint32(250), uint32(100), uint32(0x80000000 | 5000000), uint32(350000000), uint8(48), // ni, nj, first grid point
+		uint32(20000000), uint32(30000000), uint32(30000000), // LaD, last grid point
+		uint8(0x40), uint32(0), uint32(20000000), uint32(20000000), // scanning mode, orientation, Di, Dj
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	require.Equal(t, 58, buf.Len())
+
+	tpl, err := gdt.ReadTemplate(&buf, 10)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template10{}, tpl)
+
+	t10 := tpl.(*gdt.Template10)
+	assert.Equal(t, int32(250), t10.GetNi())
+	assert.Equal(t, int32(100), t10.GetNj())
+	assert.Equal(t, int32(-5000000), t10.LatitudeOfFirstGridPoint)
+	assert.Equal(t, int32(20000000), t10.Dj)
+}
+
+func TestTemplate10_Ellipsoid(t *testing.T) {
+	// Snyder, Map Projections: A Working Manual (1987), p. 267: the Clarke 1866 ellipsoid, the point at
+	// 35N 75W is at x = 11688673.7 m, y = 4139145.6 m from 0N 180W
+	tpl := (&gdt.Template10FixedPart{
+		ShapeOfTheEarth:             7,
+		ScaleFactorOfEarthMajorAxis: 1,
+		ScaledValueOfEarthMajorAxis: 63782064,
+		ScaleFactorOfEarthMinorAxis: 1,
+		ScaledValueOfEarthMinorAxis: 63565838,
+		Ni:                          1001,
+		Nj:                          1001,
+		LongitudeOfFirstGridPoint:   180000000,
+		LatitudeOfLastGridPoint:     35000000,
+		LongitudeOfLastGridPoint:    290000000,
+		ScanningMode:                0x40,
+		Di:                          11688674,
+		Dj:                          4139146,
+	}).AsTemplate()
+
+	lat, lon, ok := tpl.GetGridPoint(1000*1001 + 1000)
+	require.True(t, ok)
+	assert.InDelta(t, 35, lat, 2e-5)
+	assert.InDelta(t, 285, lon, 2e-5)
+	assert.Equal(t, 1000*1001+1000, tpl.GetGridIndex(35, 285))
+}
@@ -0,0 +1,109 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate1(t *testing.T) {
+	tests := []struct {
+		name   string
+		tpldef gdt.Template1FixedPart
+	}{
+		{
+			name: "south pole at 40S 10E",
+			tpldef: gdt.Template1FixedPart{
+				Ni:                        21,
+				Nj:                        11,
+				LatitudeOfFirstGridPoint:  -5000000,
+				LongitudeOfFirstGridPoint: 350000000,
+				LatitudeOfLastGridPoint:   5000000,
+				LongitudeOfLastGridPoint:  10000000,
+				IDirectionIncrement:       1000000,
+				JDirectionIncrement:       1000000,
+				ScanningMode:              0x40,
+				LatitudeOfSouthernPole:    -40000000,
+				LongitudeOfSouthernPole:   10000000,
+			},
+		},
+		{
+			name: "rotated by 30 degrees, scanning j consecutive",
+			tpldef: gdt.Template1FixedPart{
+				Ni:                        10,
+				Nj:                        20,
+				LatitudeOfFirstGridPoint:  9500000,
+				LongitudeOfFirstGridPoint: 0,
+				LatitudeOfLastGridPoint:   0,
+				LongitudeOfLastGridPoint:  4500000,
+				IDirectionIncrement:       500000,
+				JDirectionIncrement:       500000,
+				ScanningMode:              0x20,
+				LatitudeOfSouthernPole:    -35000000,
+				LongitudeOfSouthernPole:   190000000,
+				AngleOfRotation:           30,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := tt.tpldef.AsTemplate().(*gdt.Template1)
+
+			for n := 0; n < int(tpl.Ni*tpl.Nj); n++ {
+				lat, lon, ok := tpl.GetGridPoint(n)
+				require.True(t, ok)
+				require.Equal(t, n, tpl.GetGridIndex(lat, lon), "lat: %f, lon: %f", lat, lon)
+			}
+
+			_, _, ok := tpl.GetGridPoint(int(tpl.Ni * tpl.Nj))
+			assert.False(t, ok)
+		})
+	}
+
+	tpl := tests[0].tpldef.AsTemplate().(*gdt.Template1)
+
+	// the centre of the rotated grid is at the rotated south pole moved to the equator
+	lat, lon, ok := tpl.GetGridPoint(5*21 + 10)
+	require.True(t, ok)
+	assert.InDelta(t, 50, lat, 1e-4)
+	assert.InDelta(t, 10, lon, 1e-4)
+
+	rlat, rlon := tpl.Rotate(50, 10)
+	assert.InDelta(t, 0, rlat, 1e-9)
+	assert.InDelta(t, 0, rlon, 1e-9)
+
+	assert.Equal(t, -1, tpl.GetGridIndex(-50, 10))
+	assert.Equal(t, 5*21+10, tpl.GetGridIndex(50.1, 10.1))
+}
+
+func TestReadTemplate1(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, v := range []any{
+		uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), // shape of the earth
+		uint32(21), uint32(11), uint32(0), uint32(0xffffffff), // ni, nj, basic angle
+		uint32(0x80000000 | 5000000), uint32(350000000), uint8(48), // first grid point
+		uint32(5000000), uint32(10000000), uint32(1000000), uint32(1000000), uint8(0x40), // last grid point
+		uint32(0x80000000 | 40000000), uint32(10000000), float32(0), // southern pole, angle of rotation
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	require.Equal(t, 70, buf.Len())
+
+	tpl, err := gdt.ReadTemplate(&buf, 1)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template1{}, tpl)
+
+	t1 := tpl.(*gdt.Template1)
+	assert.Equal(t, int32(21), t1.GetNi())
+	assert.Equal(t, int32(11), t1.GetNj())
+	assert.Equal(t, int32(-5000000), t1.LatitudeOfFirstGridPoint)
+	assert.Equal(t, int32(-40000000), t1.LatitudeOfSouthernPole)
+	assert.Equal(t, int32(10000000), t1.LongitudeOfSouthernPole)
+}
@@ -0,0 +1,199 @@
+package gdt
+
+import (
+	"math"
+
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) The latitude and longitude of the first grid point are in units of 10-6 degrees, Dx and Dy are in units of 10-3 metres.
+
+( 2) Dx and Dy are the grid lengths at the latitude LaD, the grid is projected on the plane tangent or secant to the Earth at the latitude LaD.
+
+( 3) The resolution flags (bits 3-4 of Flag table 3.3) are not applicable.
+
+( 4) LoV is the longitude value of the meridian which is parallel to the y-axis (or columns of the grid) along which latitude increases as the y-coordinate increases (the orientation longitude may or may not appear on a particular grid).
+
+( 5) A scaled value of radius of spherical Earth, or major or minor axis of oblate spheroid Earth is derived from applying appropriate scale factor to the value expressed in metres.
+
+( 6) It is recommended to use unsigned direction increments.
+*/
+type Template20 struct {
+	Template20FixedPart `json:"template20"`
+	grid                *projectedGrid `json:"-"`
+}
+
+// https://codes.ecmwf.int/grib/format/grib2/templates/3/20/
+type template20FixedPart struct {
+	ShapeOfTheEarth                     uint8
+	ScaleFactorOfRadiusOfSphericalEarth uint8
+	ScaledValueOfRadiusOfSphericalEarth uint32
+	ScaleFactorOfEarthMajorAxis         uint8
+	ScaledValueOfEarthMajorAxis         uint32
+	ScaleFactorOfEarthMinorAxis         uint8
+	ScaledValueOfEarthMinorAxis         uint32
+	Nx                                  uint32
+	Ny                                  uint32
+	LatitudeOfFirstGridPoint            uint32
+	LongitudeOfFirstGridPoint           uint32
+	ResolutionAndComponentFlags         uint8
+	LaD                                 uint32
+	LoV                                 uint32
+	Dx                                  uint32
+	Dy                                  uint32
+	ProjectionCentreFlag                uint8
+	ScanningMode                        uint8
+}
+
+func (t template20FixedPart) Export() Template {
+	t20 := Template20FixedPart{
+		ShapeOfTheEarth:                     regulation.ToInt8(t.ShapeOfTheEarth),
+		ScaleFactorOfRadiusOfSphericalEarth: regulation.ToInt8(t.ScaleFactorOfRadiusOfSphericalEarth),
+		ScaledValueOfRadiusOfSphericalEarth: regulation.ToInt32(t.ScaledValueOfRadiusOfSphericalEarth),
+		ScaleFactorOfEarthMajorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMajorAxis),
+		ScaledValueOfEarthMajorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMajorAxis),
+		ScaleFactorOfEarthMinorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMinorAxis),
+		ScaledValueOfEarthMinorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMinorAxis),
+		Nx:                                  regulation.ToInt32(t.Nx),
+		Ny:                                  regulation.ToInt32(t.Ny),
+		LatitudeOfFirstGridPoint:            regulation.ToInt32(t.LatitudeOfFirstGridPoint),
+		LongitudeOfFirstGridPoint:           regulation.ToInt32(t.LongitudeOfFirstGridPoint),
+		ResolutionAndComponentFlags:         regulation.FlagOctet(t.ResolutionAndComponentFlags),
+		LaD:                                 regulation.ToInt32(t.LaD),
+		LoV:                                 regulation.ToInt32(t.LoV),
+		Dx:                                  regulation.ToInt32(t.Dx),
+		Dy:                                  regulation.ToInt32(t.Dy),
+		ProjectionCentreFlag:                regulation.FlagOctet(t.ProjectionCentreFlag),
+		ScanningMode:                        regulation.FlagOctet(t.ScanningMode),
+	}
+
+	return t20.AsTemplate()
+}
+
+type Template20FixedPart struct {
+	ShapeOfTheEarth                     int8  `json:"shapeOfTheEarth"`
+	ScaleFactorOfRadiusOfSphericalEarth int8  `json:"scaleFactorOfRadiusOfSphericalEarth"`
+	ScaledValueOfRadiusOfSphericalEarth int32 `json:"scaledValueOfRadiusOfSphericalEarth"`
+	ScaleFactorOfEarthMajorAxis         int8  `json:"scaleFactorOfEarthMajorAxis"`
+	ScaledValueOfEarthMajorAxis         int32 `json:"scaledValueOfEarthMajorAxis"`
+	ScaleFactorOfEarthMinorAxis         int8  `json:"scaleFactorOfEarthMinorAxis"`
+	ScaledValueOfEarthMinorAxis         int32 `json:"scaledValueOfEarthMinorAxis"`
+	Nx                                  int32 `json:"nx"`
+	Ny                                  int32 `json:"ny"`
+	LatitudeOfFirstGridPoint            int32 `json:"latitudeOfFirstGridPoint"`
+	LongitudeOfFirstGridPoint           int32 `json:"longitudeOfFirstGridPoint"`
+	ResolutionAndComponentFlags         int8  `json:"-"`
+	LaD                                 int32 `json:"laD"`
+	LoV                                 int32 `json:"loV"`
+	Dx                                  int32 `json:"dx"`
+	Dy                                  int32 `json:"dy"`
+	ProjectionCentreFlag                int8  `json:"projectionCentreFlag"`
+	ScanningMode                        int8  `json:"scanningMode"`
+}
+
+func (t *Template20FixedPart) AsTemplate() Template {
+	earth := t.GetEarthShape()
+
+	// bit 1 of the projection centre flag: the south pole is on the projection plane
+	south := uint8(t.ProjectionCentreFlag)&0x80 != 0
+	p := newPolarStereographic(earth, float64(t.LaD)/1e6, float64(t.LoV)/1e6, south)
+
+	return &Template20{
+		Template20FixedPart: *t,
+		grid: newProjectedGrid(
+			p,
+			float64(t.LatitudeOfFirstGridPoint)/1e6,
+			float64(t.LongitudeOfFirstGridPoint)/1e6,
+			float64(t.Dx)/1e3,
+			float64(t.Dy)/1e3,
+			int(t.Nx),
+			int(t.Ny),
+			scanMode(t.ScanningMode),
+		),
+	}
+}
+
+func (t *Template20FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(
+		t.ShapeOfTheEarth,
+		t.ScaleFactorOfRadiusOfSphericalEarth, t.ScaledValueOfRadiusOfSphericalEarth,
+		t.ScaleFactorOfEarthMajorAxis, t.ScaledValueOfEarthMajorAxis,
+		t.ScaleFactorOfEarthMinorAxis, t.ScaledValueOfEarthMinorAxis,
+	)
+}
+
+func (t *Template20FixedPart) GetNi() int32 {
+	return t.Nx
+}
+
+func (t *Template20FixedPart) GetNj() int32 {
+	return t.Ny
+}
+
+func (t *Template20) GetGridIndex(lat, lon float32) (n int) {
+	return t.grid.index(float64(lat), float64(lon))
+}
+
+func (t *Template20) GetGridPoint(n int) (float32, float32, bool) {
+	lat, lon, ok := t.grid.point(n)
+	return float32(lat), float32(lon), ok
+}
+
+// polarStereographic is the polar stereographic projection of the Earth, with the pole at the
+// origin of the plane.
+type polarStereographic struct {
+	earth Ellipsoid
+	k     float64 // distance from the pole on the plane divided by t, so that the scale is true at LaD
+	lov   float64 // radians
+	south bool
+}
+
+func newPolarStereographic(earth Ellipsoid, lad, lov float64, south bool) *polarStereographic {
+	var (
+		a   = earth.SemiMajorAxis
+		e   = earth.Eccentricity()
+		phi = math.Abs(lad) * math.Pi / 180
+		// true scale at the pole, eq. 21-33
+		k = 2 * a / math.Sqrt(math.Pow(1+e, 1+e)*math.Pow(1-e, 1-e))
+	)
+
+	if math.Abs(phi-math.Pi/2) > 1e-10 {
+		// eq. 21-34
+		k = a * earth.msfn(phi) / earth.tsfn(phi)
+	}
+
+	return &polarStereographic{
+		earth: earth,
+		k:     k,
+		lov:   lov * math.Pi / 180,
+		south: south,
+	}
+}
+
+func (p *polarStereographic) forward(lat, lon float64) (float64, float64) {
+	var (
+		phi    = lat * math.Pi / 180
+		lambda = lon*math.Pi/180 - p.lov
+	)
+
+	if p.south {
+		rho := p.k * p.earth.tsfn(-phi)
+		return rho * math.Sin(lambda), rho * math.Cos(lambda)
+	}
+
+	rho := p.k * p.earth.tsfn(phi)
+
+	return rho * math.Sin(lambda), -rho * math.Cos(lambda)
+}
+
+func (p *polarStereographic) inverse(x, y float64) (float64, float64) {
+	phi := p.earth.phi(math.Hypot(x, y) / p.k)
+
+	if p.south {
+		return -phi * 180 / math.Pi, (p.lov + math.Atan2(x, y)) * 180 / math.Pi
+	}
+
+	return phi * 180 / math.Pi, (p.lov + math.Atan2(x, -y)) * 180 / math.Pi
+}
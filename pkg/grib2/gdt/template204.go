@@ -0,0 +1,157 @@
+package gdt
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) The latitudes and longitudes of the grid points are not in the template, they are given by two fields of the same grid, with the parameters geographical latitude (0.191.1) and geographical longitude (0.191.2), usually in the same file.
+
+( 2) The coordinates must be attached before the grid points can be located, see SetCoordinates.
+
+( 3) Octets 39-54 and 56-71 are reserved, they stand for the extreme points and the increments of template 3.0.
+*/
+type Template204 struct {
+	Template204FixedPart `json:"template204"`
+	coords               *curvilinearCoordinates `json:"-"`
+}
+
+// https://codes.ecmwf.int/grib/format/grib2/templates/3/204/
+type template204FixedPart struct {
+	ShapeOfTheEarth                     uint8
+	ScaleFactorOfRadiusOfSphericalEarth uint8
+	ScaledValueOfRadiusOfSphericalEarth uint32
+	ScaleFactorOfEarthMajorAxis         uint8
+	ScaledValueOfEarthMajorAxis         uint32
+	ScaleFactorOfEarthMinorAxis         uint8
+	ScaledValueOfEarthMinorAxis         uint32
+	Ni                                  uint32
+	Nj                                  uint32
+	_                                   [16]byte
+	ResolutionAndComponentFlags         uint8
+	_                                   [16]byte
+	ScanningMode                        uint8
+}
+
+func (t template204FixedPart) Export() Template {
+	t204 := Template204FixedPart{
+		ShapeOfTheEarth:                     regulation.ToInt8(t.ShapeOfTheEarth),
+		ScaleFactorOfRadiusOfSphericalEarth: regulation.ToInt8(t.ScaleFactorOfRadiusOfSphericalEarth),
+		ScaledValueOfRadiusOfSphericalEarth: regulation.ToInt32(t.ScaledValueOfRadiusOfSphericalEarth),
+		ScaleFactorOfEarthMajorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMajorAxis),
+		ScaledValueOfEarthMajorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMajorAxis),
+		ScaleFactorOfEarthMinorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMinorAxis),
+		ScaledValueOfEarthMinorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMinorAxis),
+		Ni:                                  regulation.ToInt32(t.Ni),
+		Nj:                                  regulation.ToInt32(t.Nj),
+		ResolutionAndComponentFlags:         regulation.FlagOctet(t.ResolutionAndComponentFlags),
+		ScanningMode:                        regulation.FlagOctet(t.ScanningMode),
+	}
+
+	return t204.AsTemplate()
+}
+
+type Template204FixedPart struct {
+	ShapeOfTheEarth                     int8  `json:"shapeOfTheEarth"`
+	ScaleFactorOfRadiusOfSphericalEarth int8  `json:"scaleFactorOfRadiusOfSphericalEarth"`
+	ScaledValueOfRadiusOfSphericalEarth int32 `json:"scaledValueOfRadiusOfSphericalEarth"`
+	ScaleFactorOfEarthMajorAxis         int8  `json:"scaleFactorOfEarthMajorAxis"`
+	ScaledValueOfEarthMajorAxis         int32 `json:"scaledValueOfEarthMajorAxis"`
+	ScaleFactorOfEarthMinorAxis         int8  `json:"scaleFactorOfEarthMinorAxis"`
+	ScaledValueOfEarthMinorAxis         int32 `json:"scaledValueOfEarthMinorAxis"`
+	Ni                                  int32 `json:"ni"`
+	Nj                                  int32 `json:"nj"`
+	ResolutionAndComponentFlags         int8  `json:"-"`
+	ScanningMode                        int8  `json:"scanningMode"`
+}
+
+func (t *Template204FixedPart) AsTemplate() Template {
+	return &Template204{
+		Template204FixedPart: *t,
+	}
+}
+
+func (t *Template204FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(
+		t.ShapeOfTheEarth,
+		t.ScaleFactorOfRadiusOfSphericalEarth, t.ScaledValueOfRadiusOfSphericalEarth,
+		t.ScaleFactorOfEarthMajorAxis, t.ScaledValueOfEarthMajorAxis,
+		t.ScaleFactorOfEarthMinorAxis, t.ScaledValueOfEarthMinorAxis,
+	)
+}
+
+func (t *Template204FixedPart) GetNi() int32 {
+	return t.Ni
+}
+
+func (t *Template204FixedPart) GetNj() int32 {
+	return t.Nj
+}
+
+type curvilinearCoordinates struct {
+	lats, lons []float32
+	tree       *kdTree
+}
+
+// SetCoordinates attaches the latitudes and longitudes in degrees of the grid points, in the order of
+// the data, see note 1. Missing coordinates are NaN.
+func (t *Template204) SetCoordinates(lats, lons []float32) error {
+	if n := int(t.Ni) * int(t.Nj); len(lats) != n || len(lons) != n {
+		return fmt.Errorf("%d latitudes and %d longitudes for a grid of %d points", len(lats), len(lons), n)
+	}
+
+	lats64, lons64 := make([]float64, len(lats)), make([]float64, len(lons))
+	for i := range lats {
+		lats64[i], lons64[i] = float64(lats[i]), float64(lons[i])
+	}
+
+	t.coords = &curvilinearCoordinates{
+		lats: lats,
+		lons: lons,
+		tree: newKDTree(lats64, lons64),
+	}
+
+	return nil
+}
+
+// HasCoordinates reports whether the coordinates of the grid points are attached.
+func (t *Template204) HasCoordinates() bool {
+	return t.coords != nil
+}
+
+// Validate reports the coordinates of the grid points which are not attached.
+func (t *Template204) Validate() error {
+	if t.coords == nil {
+		return fmt.Errorf("%w: coordinates of the curvilinear grid are not attached", ErrNoGridPoints)
+	}
+
+	return nil
+}
+
+// GetGridIndex returns the grid point nearest to the point lat, lon, -1 if the coordinates are not
+// attached, see note 2.
+func (t *Template204) GetGridIndex(lat, lon float32) (n int) {
+	if t.coords == nil {
+		return -1
+	}
+
+	return t.coords.tree.nearest(float64(lat), float64(lon))
+}
+
+// GetGridPoint returns the latitude and longitude of the nth grid point, see note 2.
+func (t *Template204) GetGridPoint(n int) (float32, float32, bool) {
+	if t.coords == nil || n < 0 || n >= len(t.coords.lats) {
+		return 0, 0, false
+	}
+
+	lat, lon := t.coords.lats[n], t.coords.lons[n]
+	if math.IsNaN(float64(lat)) || math.IsNaN(float64(lon)) {
+		return 0, 0, false
+	}
+
+	return lat, float32(normalizeLongitude(float64(lon))), true
+}
@@ -0,0 +1,72 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate204(t *testing.T) {
+	tpl := (&gdt.Template204FixedPart{ShapeOfTheEarth: 6, Ni: 3, Nj: 2, ScanningMode: 0x40}).AsTemplate().(*gdt.Template204)
+
+	_, _, ok := tpl.GetGridPoint(0)
+	assert.False(t, ok)
+	assert.Equal(t, -1, tpl.GetGridIndex(0, 0))
+	assert.False(t, tpl.HasCoordinates())
+
+	nan := float32(math.NaN())
+
+	require.Error(t, tpl.SetCoordinates([]float32{0, 0, 0}, []float32{0, 0, 0}))
+	require.NoError(t, tpl.SetCoordinates(
+		[]float32{-10, -10, nan, 10, 10, 10},
+		[]float32{-170, 170, nan, -170, 170, 180},
+	))
+	assert.True(t, tpl.HasCoordinates())
+
+	// the longitudes are in [0, 360)
+	lat, lon, ok := tpl.GetGridPoint(0)
+	require.True(t, ok)
+	assert.Equal(t, float32(-10), lat)
+	assert.Equal(t, float32(190), lon)
+
+	// missing coordinates are not grid points
+	_, _, ok = tpl.GetGridPoint(2)
+	assert.False(t, ok)
+
+	_, _, ok = tpl.GetGridPoint(6)
+	assert.False(t, ok)
+
+	// across the antimeridian
+	assert.Equal(t, 0, tpl.GetGridIndex(-9, 188))
+	assert.Equal(t, 1, tpl.GetGridIndex(-9, 172))
+	assert.Equal(t, 5, tpl.GetGridIndex(9, -179))
+	assert.Equal(t, 3, tpl.GetGridIndex(9, 189))
+}
+
+func TestReadTemplate204(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, v := range []any{
+		uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), // shape of the earth
+		uint32(1442), uint32(1021), [16]byte{}, uint8(0x30), [16]byte{}, uint8(0x40), // ni, nj, reserved, flags, reserved, scanning mode
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	require.Equal(t, 58, buf.Len())
+
+	tpl, err := gdt.ReadTemplate(&buf, 204)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template204{}, tpl)
+
+	t204 := tpl.(*gdt.Template204)
+	assert.Equal(t, int32(1442), t204.GetNi())
+	assert.Equal(t, int32(1021), t204.GetNj())
+	assert.Equal(t, int8(0x40), t204.ScanningMode)
+	assert.Equal(t, gdt.NewEllipsoid(6, 0, 0, 0, 0, 0, 0), tpl.GetEarthShape())
+}
@@ -0,0 +1,165 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate20_GetGridPoint(t *testing.T) {
+	const radius = 6371229
+
+	tests := []struct {
+		name   string
+		tpldef gdt.Template20FixedPart
+		// latitude of the grid point Nx, one step of Dy along LoV from the first grid point
+		wantLat float64
+	}{
+		{
+			name: "north pole",
+			tpldef: gdt.Template20FixedPart{
+				ShapeOfTheEarth:           6,
+				Nx:                        10,
+				Ny:                        8,
+				LatitudeOfFirstGridPoint:  60000000,
+				LongitudeOfFirstGridPoint: 250000000,
+				LaD:                       60000000,
+				LoV:                       250000000,
+				Dx:                        10000000,
+				Dy:                        10000000,
+				ScanningMode:              0x40,
+			},
+			wantLat: 60 + 10000.0/radius*180/math.Pi,
+		},
+		{
+			name: "south pole",
+			tpldef: gdt.Template20FixedPart{
+				ShapeOfTheEarth:           6,
+				Nx:                        10,
+				Ny:                        8,
+				LatitudeOfFirstGridPoint:  -70000000,
+				LongitudeOfFirstGridPoint: 0,
+				LaD:                       -70000000,
+				LoV:                       0,
+				Dx:                        10000000,
+				Dy:                        10000000,
+				ProjectionCentreFlag:      -128,
+				ScanningMode:              0x40,
+			},
+			// y increases away from the south pole along LoV
+			wantLat: -70 + 10000.0/radius*180/math.Pi,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := tt.tpldef.AsTemplate()
+
+			lat, lon, ok := tpl.GetGridPoint(0)
+			require.True(t, ok)
+			assert.InDelta(t, float64(tt.tpldef.LatitudeOfFirstGridPoint)/1e6, lat, 1e-4)
+			assert.InDelta(t, float64(tt.tpldef.LongitudeOfFirstGridPoint)/1e6, lon, 1e-4)
+
+			lat, _, ok = tpl.GetGridPoint(int(tt.tpldef.Nx))
+			require.True(t, ok)
+			assert.InDelta(t, tt.wantLat, lat, 1e-4)
+
+			for n := 0; n < int(tpl.GetNi()*tpl.GetNj()); n++ {
+				lat, lon, ok := tpl.GetGridPoint(n)
+				require.True(t, ok)
+				require.Equal(t, n, tpl.GetGridIndex(lat, lon), "lat: %f, lon: %f", lat, lon)
+			}
+
+			// the opposite hemisphere is far out of the grid
+			assert.Equal(t, -1, tpl.GetGridIndex(-lat, lon))
+		})
+	}
+}
+
+func TestTemplate20_Pole(t *testing.T) {
+	// 5 x 5 points of 100 km centred on the north pole
+	tpldef := gdt.Template20FixedPart{
+		ShapeOfTheEarth: 6,
+		Nx:              5,
+		Ny:              5,
+		LaD:             90000000,
+		LoV:             0,
+		Dx:              100000000,
+		Dy:              100000000,
+		ScanningMode:    0x40,
+	}
+
+	// first grid point 2 steps west and south of the pole, on the meridian 315
+	lat := 90 - 2*math.Atan(200000*math.Sqrt2/(2*6371229))*180/math.Pi
+	tpldef.LatitudeOfFirstGridPoint = int32(math.Round(lat * 1e6))
+	tpldef.LongitudeOfFirstGridPoint = 315000000
+
+	tpl := tpldef.AsTemplate()
+
+	lat2, _, ok := tpl.GetGridPoint(12)
+	require.True(t, ok)
+	assert.InDelta(t, 90, lat2, 1e-4)
+	assert.Equal(t, 12, tpl.GetGridIndex(90, 0))
+	assert.Equal(t, 12, tpl.GetGridIndex(89.99, 123))
+}
+
+func TestReadTemplate20(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, v := range []any{
+		uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), // shape of the earth
+		uint32(10), uint32(8), uint32(0x80000000 | 70000000), uint32(0), uint8(8), // nx, ny, first grid point
+		uint32(0x80000000 | 70000000), uint32(0), uint32(10000000), uint32(10000000), // LaD, LoV, Dx, Dy
+		uint8(0x80), uint8(0x40), // projection centre, scanning mode
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	require.Equal(t, 51, buf.Len())
+
+	tpl, err := gdt.ReadTemplate(&buf, 20)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template20{}, tpl)
+
+	t20 := tpl.(*gdt.Template20)
+	assert.Equal(t, int32(10), t20.GetNi())
+	assert.Equal(t, int32(8), t20.GetNj())
+	assert.Equal(t, int32(-70000000), t20.LaD)
+	assert.Equal(t, int8(-128), t20.ProjectionCentreFlag)
+
+	lat, lon, ok := tpl.GetGridPoint(0)
+	require.True(t, ok)
+	assert.InDelta(t, -70, lat, 1e-4)
+	assert.InDelta(t, 0, lon, 1e-4)
+}
+
+func TestTemplate20_Ellipsoid(t *testing.T) {
+	// Snyder, Map Projections: A Working Manual (1987), p. 315: the International ellipsoid, true scale
+	// at 71S, LoV 100W, the point at 75S 150E is at x = -1540033.6 m, y = -560526.4 m from the pole
+	tpl := (&gdt.Template20FixedPart{
+		ShapeOfTheEarth:             7,
+		ScaledValueOfEarthMajorAxis: 6378388,
+		ScaleFactorOfEarthMinorAxis: 2,
+		ScaledValueOfEarthMinorAxis: 635691195,
+		Nx:                          1001,
+		Ny:                          1001,
+		LatitudeOfFirstGridPoint:    -90000000,
+		LaD:                         -71000000,
+		LoV:                         260000000,
+		Dx:                          1540034,
+		Dy:                          560526,
+		ProjectionCentreFlag:        int8(-128),
+		ScanningMode:                int8(-128),
+	}).AsTemplate()
+
+	lat, lon, ok := tpl.GetGridPoint(1000*1001 + 1000)
+	require.True(t, ok)
+	assert.InDelta(t, -75, lat, 2e-5)
+	assert.InDelta(t, 150, lon, 2e-5)
+	assert.Equal(t, 1000*1001+1000, tpl.GetGridIndex(-75, 150))
+}
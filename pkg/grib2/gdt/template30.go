@@ -0,0 +1,226 @@
+package gdt
+
+import (
+	"math"
+
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) The latitude and longitude of the first grid point are in units of 10-6 degrees, Dx and Dy are in units of 10-3 metres.
+
+( 2) Dx and Dy are the grid lengths at the latitude LaD, the grid is projected on the cone tangent or secant at Latin1 and Latin2.
+
+( 3) If Latin 1 = Latin 2, then the projection is on a tangent cone.
+
+( 4) The resolution flags (bits 3-4 of Flag table 3.3) are not applicable.
+
+( 5) LoV is the longitude value of the meridian which is parallel to the y-axis (or columns of the grid) along which latitude increases as the y-coordinate increases (the orientation longitude may or may not appear on a particular grid).
+
+( 6) A scaled value of radius of spherical Earth, or major or minor axis of oblate spheroid Earth is derived from applying appropriate scale factor to the value expressed in metres.
+
+( 7) It is recommended to use unsigned direction increments.
+*/
+type Template30 struct {
+	Template30FixedPart `json:"template30"`
+	grid                *projectedGrid `json:"-"`
+}
+
+// https://codes.ecmwf.int/grib/format/grib2/templates/3/30/
+type template30FixedPart struct {
+	ShapeOfTheEarth                     uint8
+	ScaleFactorOfRadiusOfSphericalEarth uint8
+	ScaledValueOfRadiusOfSphericalEarth uint32
+	ScaleFactorOfEarthMajorAxis         uint8
+	ScaledValueOfEarthMajorAxis         uint32
+	ScaleFactorOfEarthMinorAxis         uint8
+	ScaledValueOfEarthMinorAxis         uint32
+	Nx                                  uint32
+	Ny                                  uint32
+	LatitudeOfFirstGridPoint            uint32
+	LongitudeOfFirstGridPoint           uint32
+	ResolutionAndComponentFlags         uint8
+	LaD                                 uint32
+	LoV                                 uint32
+	Dx                                  uint32
+	Dy                                  uint32
+	ProjectionCentreFlag                uint8
+	ScanningMode                        uint8
+	Latin1                              uint32
+	Latin2                              uint32
+	LatitudeOfSouthernPole              uint32
+	LongitudeOfSouthernPole             uint32
+}
+
+func (t template30FixedPart) Export() Template {
+	t30 := Template30FixedPart{
+		ShapeOfTheEarth:                     regulation.ToInt8(t.ShapeOfTheEarth),
+		ScaleFactorOfRadiusOfSphericalEarth: regulation.ToInt8(t.ScaleFactorOfRadiusOfSphericalEarth),
+		ScaledValueOfRadiusOfSphericalEarth: regulation.ToInt32(t.ScaledValueOfRadiusOfSphericalEarth),
+		ScaleFactorOfEarthMajorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMajorAxis),
+		ScaledValueOfEarthMajorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMajorAxis),
+		ScaleFactorOfEarthMinorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMinorAxis),
+		ScaledValueOfEarthMinorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMinorAxis),
+		Nx:                                  regulation.ToInt32(t.Nx),
+		Ny:                                  regulation.ToInt32(t.Ny),
+		LatitudeOfFirstGridPoint:            regulation.ToInt32(t.LatitudeOfFirstGridPoint),
+		LongitudeOfFirstGridPoint:           regulation.ToInt32(t.LongitudeOfFirstGridPoint),
+		ResolutionAndComponentFlags:         regulation.FlagOctet(t.ResolutionAndComponentFlags),
+		LaD:                                 regulation.ToInt32(t.LaD),
+		LoV:                                 regulation.ToInt32(t.LoV),
+		Dx:                                  regulation.ToInt32(t.Dx),
+		Dy:                                  regulation.ToInt32(t.Dy),
+		ProjectionCentreFlag:                regulation.FlagOctet(t.ProjectionCentreFlag),
+		ScanningMode:                        regulation.FlagOctet(t.ScanningMode),
+		Latin1:                              regulation.ToInt32(t.Latin1),
+		Latin2:                              regulation.ToInt32(t.Latin2),
+		LatitudeOfSouthernPole:              regulation.ToInt32(t.LatitudeOfSouthernPole),
+		LongitudeOfSouthernPole:             regulation.ToInt32(t.LongitudeOfSouthernPole),
+	}
+
+	return t30.AsTemplate()
+}
+
+type Template30FixedPart struct {
+	ShapeOfTheEarth                     int8  `json:"shapeOfTheEarth"`
+	ScaleFactorOfRadiusOfSphericalEarth int8  `json:"scaleFactorOfRadiusOfSphericalEarth"`
+	ScaledValueOfRadiusOfSphericalEarth int32 `json:"scaledValueOfRadiusOfSphericalEarth"`
+	ScaleFactorOfEarthMajorAxis         int8  `json:"scaleFactorOfEarthMajorAxis"`
+	ScaledValueOfEarthMajorAxis         int32 `json:"scaledValueOfEarthMajorAxis"`
+	ScaleFactorOfEarthMinorAxis         int8  `json:"scaleFactorOfEarthMinorAxis"`
+	ScaledValueOfEarthMinorAxis         int32 `json:"scaledValueOfEarthMinorAxis"`
+	Nx                                  int32 `json:"nx"`
+	Ny                                  int32 `json:"ny"`
+	LatitudeOfFirstGridPoint            int32 `json:"latitudeOfFirstGridPoint"`
+	LongitudeOfFirstGridPoint           int32 `json:"longitudeOfFirstGridPoint"`
+	ResolutionAndComponentFlags         int8  `json:"-"`
+	LaD                                 int32 `json:"laD"`
+	LoV                                 int32 `json:"loV"`
+	Dx                                  int32 `json:"dx"`
+	Dy                                  int32 `json:"dy"`
+	ProjectionCentreFlag                int8  `json:"projectionCentreFlag"`
+	ScanningMode                        int8  `json:"scanningMode"`
+	Latin1                              int32 `json:"latin1"`
+	Latin2                              int32 `json:"latin2"`
+	LatitudeOfSouthernPole              int32 `json:"latitudeOfSouthernPole"`
+	LongitudeOfSouthernPole             int32 `json:"longitudeOfSouthernPole"`
+}
+
+func (t *Template30FixedPart) AsTemplate() Template {
+	earth := t.GetEarthShape()
+
+	// bit 1 of the projection centre flag: the south pole is on the projection plane
+	south := uint8(t.ProjectionCentreFlag)&0x80 != 0
+	p := newLambertConformal(earth, float64(t.Latin1)/1e6, float64(t.Latin2)/1e6, float64(t.LoV)/1e6, south)
+
+	return &Template30{
+		Template30FixedPart: *t,
+		grid: newProjectedGrid(
+			p,
+			float64(t.LatitudeOfFirstGridPoint)/1e6,
+			float64(t.LongitudeOfFirstGridPoint)/1e6,
+			float64(t.Dx)/1e3,
+			float64(t.Dy)/1e3,
+			int(t.Nx),
+			int(t.Ny),
+			scanMode(t.ScanningMode),
+		),
+	}
+}
+
+func (t *Template30FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(
+		t.ShapeOfTheEarth,
+		t.ScaleFactorOfRadiusOfSphericalEarth, t.ScaledValueOfRadiusOfSphericalEarth,
+		t.ScaleFactorOfEarthMajorAxis, t.ScaledValueOfEarthMajorAxis,
+		t.ScaleFactorOfEarthMinorAxis, t.ScaledValueOfEarthMinorAxis,
+	)
+}
+
+func (t *Template30FixedPart) GetNi() int32 {
+	return t.Nx
+}
+
+func (t *Template30FixedPart) GetNj() int32 {
+	return t.Ny
+}
+
+func (t *Template30) GetGridIndex(lat, lon float32) (n int) {
+	return t.grid.index(float64(lat), float64(lon))
+}
+
+func (t *Template30) GetGridPoint(n int) (float32, float32, bool) {
+	lat, lon, ok := t.grid.point(n)
+	return float32(lat), float32(lon), ok
+}
+
+// lambertConformal is the Lambert conformal conic projection of the Earth, with the apex of the
+// cone at the origin of the plane.
+type lambertConformal struct {
+	earth Ellipsoid
+	n     float64 // cone constant, negative if the apex is over the south pole
+	f     float64 // semi-major axis times F of eq. 15-10
+	lov   float64 // radians
+}
+
+// newLambertConformal returns the projection on the cone secant at latin1 and latin2, whose apex is over
+// the south pole if south, or the north pole.
+func newLambertConformal(earth Ellipsoid, latin1, latin2, lov float64, south bool) *lambertConformal {
+	// the secant latitudes are in the hemisphere of the apex, whatever their sign
+	if south {
+		latin1, latin2 = -math.Abs(latin1), -math.Abs(latin2)
+	} else {
+		latin1, latin2 = math.Abs(latin1), math.Abs(latin2)
+	}
+
+	var (
+		phi1 = latin1 * math.Pi / 180
+		phi2 = latin2 * math.Pi / 180
+		m1   = earth.msfn(phi1)
+		t1   = earth.tsfn(phi1)
+		n    = math.Sin(phi1)
+	)
+
+	if math.Abs(phi1-phi2) > 1e-10 {
+		// eq. 15-8
+		n = math.Log(m1/earth.msfn(phi2)) / math.Log(t1/earth.tsfn(phi2))
+	}
+
+	return &lambertConformal{
+		earth: earth,
+		n:     n,
+		f:     earth.SemiMajorAxis * m1 / (n * math.Pow(t1, n)),
+		lov:   lov * math.Pi / 180,
+	}
+}
+
+// rho returns the distance from the apex of the cone of the parallel at lat radians, eq. 15-7.
+func (p *lambertConformal) rho(lat float64) float64 {
+	return p.f * math.Pow(p.earth.tsfn(lat), p.n)
+}
+
+func (p *lambertConformal) forward(lat, lon float64) (float64, float64) {
+	var (
+		rho = p.rho(lat * math.Pi / 180)
+		// longitude from LoV in (-180, 180]
+		dlon  = math.Remainder(lon*math.Pi/180-p.lov, 2*math.Pi)
+		theta = p.n * dlon
+	)
+
+	return rho * math.Sin(theta), -rho * math.Cos(theta)
+}
+
+func (p *lambertConformal) inverse(x, y float64) (float64, float64) {
+	rho := math.Copysign(math.Hypot(x, y), p.n)
+	if rho == 0 {
+		return math.Copysign(90, p.n), p.lov * 180 / math.Pi
+	}
+
+	theta := math.Atan2(x/math.Copysign(1, p.n), -y/math.Copysign(1, p.n))
+
+	lat := p.earth.phi(math.Pow(rho/p.f, 1/p.n))
+	lon := p.lov + theta/p.n
+
+	return lat * 180 / math.Pi, lon * 180 / math.Pi
+}
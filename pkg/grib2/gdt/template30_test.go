@@ -0,0 +1,222 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// grib_dump of a HRRR CONUS field
+var hrrr = gdt.Template30FixedPart{
+	ShapeOfTheEarth:             6,
+	Nx:                          1799,
+	Ny:                          1059,
+	LatitudeOfFirstGridPoint:    21138123,
+	LongitudeOfFirstGridPoint:   237280472,
+	ResolutionAndComponentFlags: 8,
+	LaD:                         38500000,
+	LoV:                         262500000,
+	Dx:                          3000000,
+	Dy:                          3000000,
+	ScanningMode:                0x40,
+	Latin1:                      38500000,
+	Latin2:                      38500000,
+	LatitudeOfSouthernPole:      -90000000,
+}
+
+func TestTemplate30_GetGridPoint(t *testing.T) {
+	tpl := hrrr.AsTemplate()
+
+	tests := []struct {
+		n        int
+		lat, lon float32
+	}{
+		{n: 0, lat: 21.138123, lon: 237.280472},
+		{n: 1798, lat: 21.140547, lon: 287.71062},
+		{n: 1799 * 1058, lat: 47.838623, lon: 225.904520},
+		{n: 1799*1059 - 1, lat: 47.842195, lon: 299.082807},
+	}
+
+	for _, tt := range tests {
+		lat, lon, ok := tpl.GetGridPoint(tt.n)
+		require.True(t, ok)
+		assert.InDelta(t, tt.lat, lat, 1e-3, "grid point %d", tt.n)
+		assert.InDelta(t, tt.lon, lon, 1e-3, "grid point %d", tt.n)
+		assert.Equal(t, tt.n, tpl.GetGridIndex(tt.lat, tt.lon), "grid point %d", tt.n)
+	}
+
+	_, _, ok := tpl.GetGridPoint(1799 * 1059)
+	assert.False(t, ok)
+
+	// the nearest grid point of a location 1 km away
+	assert.Equal(t, 1799*1058, tpl.GetGridIndex(47.838623+0.009, 225.904520))
+
+	// out of the grid
+	assert.Equal(t, -1, tpl.GetGridIndex(0, 262.5))
+	assert.Equal(t, -1, tpl.GetGridIndex(-60, 80))
+}
+
+func TestTemplate30_GetGridIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		tpldef gdt.Template30FixedPart
+	}{
+		{
+			name: "secant cone, j consecutive",
+			tpldef: gdt.Template30FixedPart{
+				ShapeOfTheEarth:                     1,
+				ScaledValueOfRadiusOfSphericalEarth: 6371200,
+				Nx:                                  30,
+				Ny:                                  20,
+				LatitudeOfFirstGridPoint:            60000000,
+				LongitudeOfFirstGridPoint:           0,
+				LoV:                                 10000000,
+				Dx:                                  50000000,
+				Dy:                                  50000000,
+				ScanningMode:                        0x20,
+				Latin1:                              30000000,
+				Latin2:                              60000000,
+			},
+		},
+		{
+			name: "south pole on the projection plane, alternate rows",
+			tpldef: gdt.Template30FixedPart{
+				ShapeOfTheEarth:           6,
+				Nx:                        25,
+				Ny:                        25,
+				LatitudeOfFirstGridPoint:  -50000000,
+				LongitudeOfFirstGridPoint: 120000000,
+				LoV:                       135000000,
+				Dx:                        40000000,
+				Dy:                        40000000,
+				ProjectionCentreFlag:      -128,
+				ScanningMode:              -48, // 0xd0: -i, +j, alternate rows
+				Latin1:                    -35000000,
+				Latin2:                    -35000000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := tt.tpldef.AsTemplate()
+
+			for n := 0; n < int(tpl.GetNi()*tpl.GetNj()); n++ {
+				lat, lon, ok := tpl.GetGridPoint(n)
+				require.True(t, ok)
+				require.Equal(t, n, tpl.GetGridIndex(lat, lon), "lat: %f, lon: %f", lat, lon)
+			}
+		})
+	}
+}
+
+func TestTemplate30_SouthPole(t *testing.T) {
+	north := gdt.Template30FixedPart{
+		ShapeOfTheEarth:           6,
+		Nx:                        30,
+		Ny:                        20,
+		LatitudeOfFirstGridPoint:  20000000,
+		LongitudeOfFirstGridPoint: 100000000,
+		LoV:                       110000000,
+		Dx:                        50000000,
+		Dy:                        50000000,
+		ScanningMode:              0x40,
+		Latin1:                    30000000,
+		Latin2:                    60000000,
+	}
+
+	// the mirror image of the grid across the equator, the rows go southward away from the apex
+	south := north
+	south.LatitudeOfFirstGridPoint = -20000000
+	south.ProjectionCentreFlag = -128
+	south.ScanningMode = 0
+
+	// the secant latitudes of the south pole centred cone are given in the southern hemisphere or as
+	// distances from the pole
+	negative := south
+	negative.Latin1, negative.Latin2 = -30000000, -60000000
+
+	want := north.AsTemplate()
+
+	for _, fp := range []gdt.Template30FixedPart{south, negative} {
+		tpl := fp.AsTemplate()
+
+		for _, n := range []int{0, 29, 30*10 + 15, 30*20 - 1} {
+			wantLat, wantLon, ok := want.GetGridPoint(n)
+			require.True(t, ok)
+
+			lat, lon, ok := tpl.GetGridPoint(n)
+			require.True(t, ok)
+			assert.InDelta(t, -wantLat, lat, 1e-4, "grid point %d", n)
+			assert.InDelta(t, wantLon, lon, 1e-4, "grid point %d", n)
+			assert.Equal(t, n, tpl.GetGridIndex(lat, lon), "grid point %d", n)
+		}
+
+		// north of the first row
+		assert.Equal(t, -1, tpl.GetGridIndex(0, 100))
+	}
+}
+
+func TestReadTemplate30(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, v := range []any{
+		uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), // shape of the earth
+		uint32(1799), uint32(1059), uint32(21138123), uint32(237280472), uint8(8), // nx, ny, first grid point
+		uint32(38500000), uint32(262500000), uint32(3000000), uint32(3000000), // LaD, LoV, Dx, Dy
+		uint8(0), uint8(0x80), uint32(38500000), uint32(38500000), // projection centre, scanning mode, Latin1, Latin2
+		uint32(0x80000000 | 90000000), uint32(0), // southern pole
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	require.Equal(t, 67, buf.Len())
+
+	tpl, err := gdt.ReadTemplate(&buf, 30)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template30{}, tpl)
+
+	t30 := tpl.(*gdt.Template30)
+	assert.Equal(t, int32(1799), t30.GetNi())
+	assert.Equal(t, int32(1059), t30.GetNj())
+	assert.Equal(t, int8(-128), t30.ScanningMode)
+	assert.Equal(t, int32(-90000000), t30.LatitudeOfSouthernPole)
+
+	// scanning in the -i direction, the grid spreads westward from the first grid point
+	lat, lon, ok := tpl.GetGridPoint(1)
+	require.True(t, ok)
+	assert.Less(t, lon, float32(237.280472))
+	assert.Equal(t, 1, tpl.GetGridIndex(lat, lon))
+}
+
+func TestTemplate30_Ellipsoid(t *testing.T) {
+	// Snyder, Map Projections: A Working Manual (1987), p. 296: the Clarke 1866 ellipsoid, standard
+	// parallels 33N and 45N, origin at 23N 96W, the point at 35N 75W is at x = 1894410.9 m, y = 1564649.5 m
+	tpl := (&gdt.Template30FixedPart{
+		ShapeOfTheEarth:             7,
+		ScaleFactorOfEarthMajorAxis: 1,
+		ScaledValueOfEarthMajorAxis: 63782064,
+		ScaleFactorOfEarthMinorAxis: 1,
+		ScaledValueOfEarthMinorAxis: 63565838,
+		Nx:                          1001,
+		Ny:                          1001,
+		LatitudeOfFirstGridPoint:    23000000,
+		LongitudeOfFirstGridPoint:   264000000,
+		LoV:                         264000000,
+		Dx:                          1894411,
+		Dy:                          1564649,
+		ScanningMode:                0x40,
+		Latin1:                      33000000,
+		Latin2:                      45000000,
+	}).AsTemplate()
+
+	lat, lon, ok := tpl.GetGridPoint(1000*1001 + 1000)
+	require.True(t, ok)
+	assert.InDelta(t, 35, lat, 2e-5)
+	assert.InDelta(t, 285, lon, 2e-5)
+	assert.Equal(t, 1000*1001+1000, tpl.GetGridIndex(35, 285))
+}
@@ -0,0 +1,122 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// egrid is an E-grid of 21 x 11 points centred at 50N 253E.
+var egrid = gdt.Template32768FixedPart{
+	ShapeOfTheEarth:           6,
+	Nx:                        21,
+	Ny:                        11,
+	LatitudeOfFirstGridPoint:  43973700,
+	LongitudeOfFirstGridPoint: 238388631,
+	CentralLatitude:           50000000,
+	CentralLongitude:          253000000,
+	Di:                        1000000,
+	Dj:                        1000000,
+	ScanningMode:              0x40,
+}
+
+func TestTemplate32768(t *testing.T) {
+	tests := []struct {
+		name   string
+		tpldef gdt.Template32768FixedPart
+	}{
+		{
+			name:   "even rows offset by default",
+			tpldef: egrid,
+		},
+		{
+			name: "odd rows offset, even rows shortened",
+			tpldef: func() gdt.Template32768FixedPart {
+				tpl := egrid
+				tpl.ScanningMode = 0x40 | 0x08 | 0x01
+				return tpl
+			}(),
+		},
+		{
+			name: "alternate rows",
+			tpldef: func() gdt.Template32768FixedPart {
+				tpl := egrid
+				tpl.ScanningMode = 0x40 | 0x10
+				return tpl
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl := tt.tpldef.AsTemplate()
+
+			var n int
+			for ; ; n++ {
+				lat, lon, ok := tpl.GetGridPoint(n)
+				if !ok {
+					break
+				}
+
+				require.Equal(t, n, tpl.GetGridIndex(lat, lon), "n: %d, lat: %f, lon: %f", n, lat, lon)
+			}
+
+			if tt.tpldef.ScanningMode&0x01 != 0 {
+				assert.Equal(t, 21*11-6, n)
+			} else {
+				assert.Equal(t, 21*11, n)
+			}
+		})
+	}
+
+	tpl := egrid.AsTemplate()
+
+	// the centre of the grid is the 11th point of the 6th row, which is even
+	lat, lon, ok := tpl.GetGridPoint(5*21 + 10)
+	require.True(t, ok)
+	assert.InDelta(t, 50, lat, 1e-3)
+	assert.InDelta(t, 253, lon, 1e-3)
+
+	// the points of the even rows are half an increment east of those of the odd rows
+	lat0, lon0, _ := tpl.GetGridPoint(4*21 + 10)
+	_, lon1, _ := tpl.GetGridPoint(5*21 + 10)
+	_, lon2, _ := tpl.GetGridPoint(6*21 + 10)
+	assert.Less(t, lon0, lon1)
+	assert.InDelta(t, lon0, lon2, 0.1)
+
+	// the nearest point of a location between two rows
+	assert.Equal(t, 5*21+10, tpl.GetGridIndex(50.2, 253.2))
+	assert.Equal(t, 4*21+10, tpl.GetGridIndex(lat0-0.2, lon0))
+
+	assert.Equal(t, -1, tpl.GetGridIndex(0, 253))
+}
+
+func TestReadTemplate32768(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, v := range []any{
+		uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), // shape of the earth
+		uint32(21), uint32(11), uint32(0), uint32(0), // nx, ny, basic angle
+		uint32(43973700), uint32(238388631), uint8(0x38), // first grid point
+		uint32(50000000), uint32(253000000), uint32(1000000), uint32(1000000), uint8(0x40), // centre, Di, Dj
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	require.Equal(t, 58, buf.Len())
+
+	tpl, err := gdt.ReadTemplate(&buf, 32768)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template32768{}, tpl)
+
+	t32768 := tpl.(*gdt.Template32768)
+	assert.Equal(t, int32(21), t32768.GetNi())
+	assert.Equal(t, int32(11), t32768.GetNj())
+	assert.Equal(t, int32(50000000), t32768.CentralLatitude)
+	assert.Equal(t, int32(253000000), t32768.CentralLongitude)
+	assert.Equal(t, gdt.NewEllipsoid(6, 0, 0, 0, 0, 0, 0), tpl.GetEarthShape())
+}
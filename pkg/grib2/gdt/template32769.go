@@ -0,0 +1,142 @@
+package gdt
+
+import (
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) NCEP local template, rotated latitude/longitude Arakawa non-E staggered grid (e.g. the B-grid).
+
+( 2) The grid is defined in the latitude/longitude coordinate system rotated so that the centre of the grid is at the intersection of the rotated equator and prime meridian.
+
+( 3) Di and Dj are the distances between adjacent points of a row and between adjacent rows in the rotated coordinate system, the latitudes, longitudes and direction increments are in units of the ratio of the basic angle and the subdivisions number, see note 1 of template 3.1.
+
+( 4) The staggering of the points is defined by the scanning mode flags (bits 5-8 of flag table 3.4).
+*/
+type Template32769 struct {
+	Template32769FixedPart `json:"template32769"`
+	grid                   *rotatedGrid `json:"-"`
+}
+
+// https://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_doc/grib2_temp3-32769.shtml
+type template32769FixedPart struct {
+	ShapeOfTheEarth                        uint8
+	ScaleFactorOfRadiusOfSphericalEarth    uint8
+	ScaledValueOfRadiusOfSphericalEarth    uint32
+	ScaleFactorOfEarthMajorAxis            uint8
+	ScaledValueOfEarthMajorAxis            uint32
+	ScaleFactorOfEarthMinorAxis            uint8
+	ScaledValueOfEarthMinorAxis            uint32
+	Nx                                     uint32
+	Ny                                     uint32
+	BasicAngleOfTheInitialProductionDomain uint32
+	SubdivisionsOfBasicAngle               uint32
+	LatitudeOfFirstGridPoint               uint32
+	LongitudeOfFirstGridPoint              uint32
+	ResolutionAndComponentFlags            uint8
+	CentralLatitude                        uint32
+	CentralLongitude                       uint32
+	Di                                     uint32
+	Dj                                     uint32
+	ScanningMode                           uint8
+	LatitudeOfLastGridPoint                uint32
+	LongitudeOfLastGridPoint               uint32
+}
+
+func (t template32769FixedPart) Export() Template {
+	t32769 := Template32769FixedPart{
+		ShapeOfTheEarth:                        regulation.ToInt8(t.ShapeOfTheEarth),
+		ScaleFactorOfRadiusOfSphericalEarth:    regulation.ToInt8(t.ScaleFactorOfRadiusOfSphericalEarth),
+		ScaledValueOfRadiusOfSphericalEarth:    regulation.ToInt32(t.ScaledValueOfRadiusOfSphericalEarth),
+		ScaleFactorOfEarthMajorAxis:            regulation.ToInt8(t.ScaleFactorOfEarthMajorAxis),
+		ScaledValueOfEarthMajorAxis:            regulation.ToInt32(t.ScaledValueOfEarthMajorAxis),
+		ScaleFactorOfEarthMinorAxis:            regulation.ToInt8(t.ScaleFactorOfEarthMinorAxis),
+		ScaledValueOfEarthMinorAxis:            regulation.ToInt32(t.ScaledValueOfEarthMinorAxis),
+		Nx:                                     regulation.ToInt32(t.Nx),
+		Ny:                                     regulation.ToInt32(t.Ny),
+		BasicAngleOfTheInitialProductionDomain: regulation.ToInt32(t.BasicAngleOfTheInitialProductionDomain),
+		SubdivisionsOfBasicAngle:               regulation.ToInt32(t.SubdivisionsOfBasicAngle),
+		LatitudeOfFirstGridPoint:               regulation.ToInt32(t.LatitudeOfFirstGridPoint),
+		LongitudeOfFirstGridPoint:              regulation.ToInt32(t.LongitudeOfFirstGridPoint),
+		ResolutionAndComponentFlags:            regulation.FlagOctet(t.ResolutionAndComponentFlags),
+		CentralLatitude:                        regulation.ToInt32(t.CentralLatitude),
+		CentralLongitude:                       regulation.ToInt32(t.CentralLongitude),
+		Di:                                     regulation.ToInt32(t.Di),
+		Dj:                                     regulation.ToInt32(t.Dj),
+		ScanningMode:                           regulation.FlagOctet(t.ScanningMode),
+		LatitudeOfLastGridPoint:                regulation.ToInt32(t.LatitudeOfLastGridPoint),
+		LongitudeOfLastGridPoint:               regulation.ToInt32(t.LongitudeOfLastGridPoint),
+	}
+
+	return t32769.AsTemplate()
+}
+
+type Template32769FixedPart struct {
+	ShapeOfTheEarth                        int8  `json:"shapeOfTheEarth"`
+	ScaleFactorOfRadiusOfSphericalEarth    int8  `json:"scaleFactorOfRadiusOfSphericalEarth"`
+	ScaledValueOfRadiusOfSphericalEarth    int32 `json:"scaledValueOfRadiusOfSphericalEarth"`
+	ScaleFactorOfEarthMajorAxis            int8  `json:"scaleFactorOfEarthMajorAxis"`
+	ScaledValueOfEarthMajorAxis            int32 `json:"scaledValueOfEarthMajorAxis"`
+	ScaleFactorOfEarthMinorAxis            int8  `json:"scaleFactorOfEarthMinorAxis"`
+	ScaledValueOfEarthMinorAxis            int32 `json:"scaledValueOfEarthMinorAxis"`
+	Nx                                     int32 `json:"nx"`
+	Ny                                     int32 `json:"ny"`
+	BasicAngleOfTheInitialProductionDomain int32 `json:"basicAngleOfTheInitialProductionDomain,omitempty"`
+	SubdivisionsOfBasicAngle               int32 `json:"subdivisionsOfBasicAngle,omitempty"`
+	LatitudeOfFirstGridPoint               int32 `json:"latitudeOfFirstGridPoint"`
+	LongitudeOfFirstGridPoint              int32 `json:"longitudeOfFirstGridPoint"`
+	ResolutionAndComponentFlags            int8  `json:"-"`
+	CentralLatitude                        int32 `json:"centralLatitude"`
+	CentralLongitude                       int32 `json:"centralLongitude"`
+	Di                                     int32 `json:"di"`
+	Dj                                     int32 `json:"dj"`
+	ScanningMode                           int8  `json:"scanningMode"`
+	LatitudeOfLastGridPoint                int32 `json:"latitudeOfLastGridPoint"`
+	LongitudeOfLastGridPoint               int32 `json:"longitudeOfLastGridPoint"`
+}
+
+func (t *Template32769FixedPart) AsTemplate() Template {
+	var (
+		pole       = newCentredPole(t.degrees(t.CentralLatitude), t.degrees(t.CentralLongitude))
+		rlat, rlon = pole.rotate(t.degrees(t.LatitudeOfFirstGridPoint), t.degrees(t.LongitudeOfFirstGridPoint))
+	)
+
+	return &Template32769{
+		Template32769FixedPart: *t,
+		grid:                   newRotatedGrid(pole, rlat, rlon, t.degrees(t.Di), t.degrees(t.Dj), int(t.Nx), int(t.Ny), scanMode(t.ScanningMode)),
+	}
+}
+
+// degrees returns v in the unit of the latitudes, longitudes and direction increments, see note 3.
+func (t *Template32769FixedPart) degrees(v int32) float64 {
+	return degrees(v, t.BasicAngleOfTheInitialProductionDomain, t.SubdivisionsOfBasicAngle)
+}
+
+func (t *Template32769FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(
+		t.ShapeOfTheEarth,
+		t.ScaleFactorOfRadiusOfSphericalEarth, t.ScaledValueOfRadiusOfSphericalEarth,
+		t.ScaleFactorOfEarthMajorAxis, t.ScaledValueOfEarthMajorAxis,
+		t.ScaleFactorOfEarthMinorAxis, t.ScaledValueOfEarthMinorAxis,
+	)
+}
+
+func (t *Template32769FixedPart) GetNi() int32 {
+	return t.Nx
+}
+
+func (t *Template32769FixedPart) GetNj() int32 {
+	return t.Ny
+}
+
+// GetGridIndex returns the grid point nearest to the geographic point lat, lon, -1 if it is out of the grid.
+func (t *Template32769) GetGridIndex(lat, lon float32) (n int) {
+	return t.grid.locate(float64(lat), float64(lon))
+}
+
+// GetGridPoint returns the geographic latitude and longitude of the nth grid point.
+func (t *Template32769) GetGridPoint(n int) (float32, float32, bool) {
+	lat, lon, ok := t.grid.point(n)
+	return float32(lat), float32(lon), ok
+}
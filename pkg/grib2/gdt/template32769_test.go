@@ -0,0 +1,77 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate32769(t *testing.T) {
+	bgrid := gdt.Template32769FixedPart{
+		ShapeOfTheEarth:           6,
+		Nx:                        21,
+		Ny:                        11,
+		LatitudeOfFirstGridPoint:  43973700,
+		LongitudeOfFirstGridPoint: 238388631,
+		CentralLatitude:           50000000,
+		CentralLongitude:          253000000,
+		Di:                        1000000,
+		Dj:                        1000000,
+		ScanningMode:              0x40,
+		LatitudeOfLastGridPoint:   53967766,
+		LongitudeOfLastGridPoint:  269231013,
+	}
+
+	tpl := bgrid.AsTemplate()
+
+	for n := 0; n < 21*11; n++ {
+		lat, lon, ok := tpl.GetGridPoint(n)
+		require.True(t, ok)
+		require.Equal(t, n, tpl.GetGridIndex(lat, lon), "n: %d, lat: %f, lon: %f", n, lat, lon)
+	}
+
+	_, _, ok := tpl.GetGridPoint(21 * 11)
+	assert.False(t, ok)
+
+	// the rows are not staggered unless flagged, the last grid point is in the north east corner
+	lat, lon, ok := tpl.GetGridPoint(21*11 - 1)
+	require.True(t, ok)
+	assert.InDelta(t, 53.967766, lat, 1e-3)
+	assert.InDelta(t, 269.231013, lon, 1e-3)
+
+	// the points of the rows are half an increment east of the first grid point
+	bgrid.ScanningMode |= 0x08 | 0x04
+	_, lon, ok = bgrid.AsTemplate().GetGridPoint(0)
+	require.True(t, ok)
+	assert.Greater(t, lon, float32(238.388631))
+}
+
+func TestReadTemplate32769(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, v := range []any{
+		uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0), // shape of the earth
+		uint32(21), uint32(11), uint32(0), uint32(0), // nx, ny, basic angle
+		uint32(43973700), uint32(238388631), uint8(0x38), // first grid point
+		uint32(50000000), uint32(253000000), uint32(1000000), uint32(1000000), uint8(0x40), // centre, Di, Dj
+		uint32(53967766), uint32(269231013), // last grid point
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	require.Equal(t, 66, buf.Len())
+
+	tpl, err := gdt.ReadTemplate(&buf, 32769)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template32769{}, tpl)
+
+	t32769 := tpl.(*gdt.Template32769)
+	assert.Equal(t, int32(21), t32769.GetNi())
+	assert.Equal(t, int32(11), t32769.GetNj())
+	assert.Equal(t, int32(53967766), t32769.LatitudeOfLastGridPoint)
+	assert.Equal(t, int32(269231013), t32769.LongitudeOfLastGridPoint)
+}
@@ -1,6 +1,11 @@
 package gdt
 
 import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/scorix/grib-go/internal/pkg/quadrature"
 	"github.com/scorix/grib-go/pkg/grib2/regulation"
 	"github.com/scorix/walg/pkg/geo/grids"
 	"github.com/scorix/walg/pkg/geo/grids/gaussian"
@@ -21,8 +26,8 @@ Notes:
 
 type Template40 struct {
 	Template40FixedPart `json:"template40"`
-	// TODO: 73-nn: List of number of points along each meridian or parallel (These octets are only present for quasi-regular grids as described in note 4)
-	grids grids.Grid `json:"-"`
+	grids               grids.Grid   `json:"-"`
+	reduced             *reducedGrid `json:"-"`
 }
 
 // https://codes.ecmwf.int/grib/format/grib2/templates/3/40/
@@ -63,40 +68,48 @@ func (t template40FixedPart) Export() Template {
 		SubdivisionsOfBasicAngle:               regulation.ToInt32(t.SubdivisionsOfBasicAngle),
 		LatitudeOfFirstGridPoint:               regulation.ToInt32(t.LatitudeOfFirstGridPoint),
 		LongitudeOfFirstGridPoint:              regulation.ToInt32(t.LongitudeOfFirstGridPoint),
-		ResolutionAndComponentFlags:            regulation.ToInt8(t.ResolutionAndComponentFlags),
+		ResolutionAndComponentFlags:            regulation.FlagOctet(t.ResolutionAndComponentFlags),
 		LatitudeOfLastGridPoint:                regulation.ToInt32(t.LatitudeOfLastGridPoint),
 		LongitudeOfLastGridPoint:               regulation.ToInt32(t.LongitudeOfLastGridPoint),
 		IDirectionIncrement:                    regulation.ToInt32(t.IDirectionIncrement),
 		N:                                      regulation.ToInt32(t.N),
-		ScanningMode:                           regulation.ToInt8(t.ScanningMode),
+		ScanningMode:                           regulation.FlagOctet(t.ScanningMode),
 	}
 
 	return t40.AsTemplate()
 }
 
 type Template40FixedPart struct {
-	ShapeOfTheEarth                        int8  `json:"-"`
-	ScaleFactorOfRadiusOfSphericalEarth    int8  `json:"-"`
-	ScaledValueOfRadiusOfSphericalEarth    int32 `json:"-"`
-	ScaleFactorOfEarthMajorAxis            int8  `json:"-"`
-	ScaledValueOfEarthMajorAxis            int32 `json:"-"`
-	ScaleFactorOfEarthMinorAxis            int8  `json:"-"`
-	ScaledValueOfEarthMinorAxis            int32 `json:"-"`
+	ShapeOfTheEarth                        int8  `json:"shapeOfTheEarth,omitempty"`
+	ScaleFactorOfRadiusOfSphericalEarth    int8  `json:"scaleFactorOfRadiusOfSphericalEarth,omitempty"`
+	ScaledValueOfRadiusOfSphericalEarth    int32 `json:"scaledValueOfRadiusOfSphericalEarth,omitempty"`
+	ScaleFactorOfEarthMajorAxis            int8  `json:"scaleFactorOfEarthMajorAxis,omitempty"`
+	ScaledValueOfEarthMajorAxis            int32 `json:"scaledValueOfEarthMajorAxis,omitempty"`
+	ScaleFactorOfEarthMinorAxis            int8  `json:"scaleFactorOfEarthMinorAxis,omitempty"`
+	ScaledValueOfEarthMinorAxis            int32 `json:"scaledValueOfEarthMinorAxis,omitempty"`
 	Ni                                     int32 `json:"-"`
 	Nj                                     int32 `json:"-"`
-	BasicAngleOfTheInitialProductionDomain int32 `json:"-"`
-	SubdivisionsOfBasicAngle               int32 `json:"-"`
-	LatitudeOfFirstGridPoint               int32 `json:"-"`
-	LongitudeOfFirstGridPoint              int32 `json:"-"`
+	BasicAngleOfTheInitialProductionDomain int32 `json:"basicAngleOfTheInitialProductionDomain,omitempty"`
+	SubdivisionsOfBasicAngle               int32 `json:"subdivisionsOfBasicAngle,omitempty"`
+	LatitudeOfFirstGridPoint               int32 `json:"latitudeOfFirstGridPoint,omitempty"`
+	LongitudeOfFirstGridPoint              int32 `json:"longitudeOfFirstGridPoint,omitempty"`
 	ResolutionAndComponentFlags            int8  `json:"-"`
-	LatitudeOfLastGridPoint                int32 `json:"-"`
-	LongitudeOfLastGridPoint               int32 `json:"-"`
+	LatitudeOfLastGridPoint                int32 `json:"latitudeOfLastGridPoint,omitempty"`
+	LongitudeOfLastGridPoint               int32 `json:"longitudeOfLastGridPoint,omitempty"`
 	IDirectionIncrement                    int32 `json:"-"`
 	N                                      int32 `json:"n"`
 	ScanningMode                           int8  `json:"scanningMode"`
+	// 73-nn: List of number of points along each parallel of a quasi-regular grid, see note 4
+	PointsPerRow []int32 `json:"pointsPerRow,omitempty"`
+	// Interpretation of the list of numbers of points, code table 3.11 in section 3
+	InterpretationOfNumberOfPoints int8 `json:"interpretationOfNumberOfPoints,omitempty"`
 }
 
 func (t *Template40FixedPart) AsTemplate() Template {
+	if len(t.PointsPerRow) > 0 {
+		return t.asQuasiRegular()
+	}
+
 	return &Template40{
 		Template40FixedPart: *t,
 		grids: gaussian.NewRegular(
@@ -105,6 +118,119 @@ func (t *Template40FixedPart) AsTemplate() Template {
 	}
 }
 
+// asQuasiRegular returns the grid whose rows are the Gaussian parallels from the first to the last grid point.
+func (t *Template40FixedPart) asQuasiRegular() Template {
+	var (
+		gaussian = quadrature.GaussianLatitudes(int(t.N))
+		lat1     = t.degrees(t.LatitudeOfFirstGridPoint)
+		lat2     = t.degrees(t.LatitudeOfLastGridPoint)
+		rows     = len(t.PointsPerRow)
+		lats     = make([]float64, 0, rows)
+	)
+
+	// the parallel of the first grid point, then the next ones towards the last grid point
+	j := nearestLatitude(gaussian, lat1)
+
+	step := 1
+	if lat2 > lat1 {
+		step = -1
+	}
+
+	for ; len(lats) < rows && j >= 0 && j < len(gaussian); j += step {
+		lats = append(lats, gaussian[j])
+	}
+
+	return &Template40{
+		Template40FixedPart: *t,
+		reduced: newReducedGrid(
+			lats,
+			t.PointsPerRow[:len(lats)],
+			t.degrees(t.LongitudeOfFirstGridPoint),
+			t.degrees(t.LongitudeOfLastGridPoint),
+			scanMode(t.ScanningMode),
+			t.InterpretationOfNumberOfPoints != pointsBetweenExtremes,
+		),
+	}
+}
+
+// nearestLatitude returns the index of the latitude nearest to lat.
+func nearestLatitude(lats []float64, lat float64) int {
+	nearest := 0
+
+	for j, l := range lats {
+		if math.Abs(l-lat) < math.Abs(lats[nearest]-lat) {
+			nearest = j
+		}
+	}
+
+	return nearest
+}
+
+// degrees returns v in the unit of the extreme latitudes and longitudes, and direction increments, see note 1.
+func (t *Template40FixedPart) degrees(v int32) float64 {
+	return degrees(v, t.BasicAngleOfTheInitialProductionDomain, t.SubdivisionsOfBasicAngle)
+}
+
+// Validate reports the inconsistencies between the numbers of points, the first and last grid points and the
+// direction increments of the grid, the first and last grid points must be on Gaussian parallels.
+func (t *Template40FixedPart) Validate() error {
+	if t.N <= 0 {
+		return fmt.Errorf("invalid number of parallels between a pole and the equator: %d", t.N)
+	}
+
+	var (
+		mode     = scanMode(t.ScanningMode)
+		unit     = t.degrees(1)
+		lat1     = t.degrees(t.LatitudeOfFirstGridPoint)
+		lat2     = t.degrees(t.LatitudeOfLastGridPoint)
+		gaussian = quadrature.GaussianLatitudes(int(t.N))
+		// bit 3 of the resolution and component flags: i direction increments are given
+		iGiven = uint8(t.ResolutionAndComponentFlags)&0x20 != 0
+	)
+
+	errs := []error{checkLatitudes(lat1, lat2, t.Nj, mode)}
+
+	if len(t.PointsPerRow) > 0 {
+		errs = append(errs, checkPointsPerRow(t.Nj, t.PointsPerRow, t.InterpretationOfNumberOfPoints))
+	} else if t.Ni <= 0 {
+		errs = append(errs, fmt.Errorf("invalid number of points along a parallel: %d", t.Ni))
+	} else if iGiven {
+		var (
+			di     = t.degrees(t.IDirectionIncrement)
+			extent = longitudeExtent(t.degrees(t.LongitudeOfFirstGridPoint), t.degrees(t.LongitudeOfLastGridPoint), t.Ni, di, mode)
+		)
+
+		errs = append(errs, checkIncrement("i", t.Ni, di, extent, unit))
+	}
+
+	j1, j2 := nearestLatitude(gaussian, lat1), nearestLatitude(gaussian, lat2)
+
+	for _, p := range []struct {
+		lat float64
+		j   int
+	}{{lat1, j1}, {lat2, j2}} {
+		// coded latitudes are rounded to the unit
+		if math.Abs(gaussian[p.j]-p.lat) > unit {
+			errs = append(errs, fmt.Errorf("latitude %f is not a Gaussian latitude of N%d, the nearest one is %f", p.lat, t.N, gaussian[p.j]))
+		}
+	}
+
+	if rows := int32(max(j1, j2) - min(j1, j2) + 1); t.Nj != rows {
+		errs = append(errs, fmt.Errorf("%d points along a meridian, but there are %d Gaussian parallels from the first to the last grid point", t.Nj, rows))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *Template40FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(
+		t.ShapeOfTheEarth,
+		t.ScaleFactorOfRadiusOfSphericalEarth, t.ScaledValueOfRadiusOfSphericalEarth,
+		t.ScaleFactorOfEarthMajorAxis, t.ScaledValueOfEarthMajorAxis,
+		t.ScaleFactorOfEarthMinorAxis, t.ScaledValueOfEarthMinorAxis,
+	)
+}
+
 func (t *Template40FixedPart) GetNi() int32 {
 	return t.Ni
 }
@@ -114,10 +240,19 @@ func (t *Template40FixedPart) GetNj() int32 {
 }
 
 func (t *Template40) GetGridIndex(lat, lon float32) (n int) {
+	if t.reduced != nil {
+		return t.reduced.index(float64(lat), float64(lon))
+	}
+
 	return grids.GuessGridIndex(t.grids, float64(lat), float64(lon), grids.ScanMode(t.ScanningMode))
 }
 
 func (t *Template40) GetGridPoint(n int) (float32, float32, bool) {
+	if t.reduced != nil {
+		lat, lon, ok := t.reduced.point(n)
+		return float32(lat), float32(lon), ok
+	}
+
 	lat, lon, ok := grids.GridPoint(t.grids, n, grids.ScanMode(t.ScanningMode))
 	return float32(lat), float32(lon), ok
 }
@@ -0,0 +1,116 @@
+package gdt_test
+
+import (
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate40_Validate(t *testing.T) {
+	// N320 regular Gaussian grid
+	valid := gdt.Template40FixedPart{
+		Ni:                          1280,
+		Nj:                          640,
+		SubdivisionsOfBasicAngle:    -1,
+		LatitudeOfFirstGridPoint:    89784877,
+		ResolutionAndComponentFlags: 48,
+		LatitudeOfLastGridPoint:     -89784877,
+		LongitudeOfLastGridPoint:    359718750,
+		IDirectionIncrement:         281250,
+		N:                           320,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*gdt.Template40FixedPart)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(*gdt.Template40FixedPart) {},
+		},
+		{
+			name: "regional",
+			modify: func(t *gdt.Template40FixedPart) {
+				t.Ni, t.Nj = 321, 2
+				t.LatitudeOfFirstGridPoint, t.LatitudeOfLastGridPoint = 89784877, 89506203
+				t.LongitudeOfLastGridPoint = 90000000
+			},
+		},
+		{
+			name: "quasi-regular",
+			modify: func(t *gdt.Template40FixedPart) {
+				t.N, t.Ni, t.Nj = 2, -1, 4
+				t.ResolutionAndComponentFlags = 0
+				t.IDirectionIncrement = -1
+				t.LatitudeOfFirstGridPoint, t.LatitudeOfLastGridPoint = 59444408, -59444408
+				t.LongitudeOfLastGridPoint = 345000000
+				t.PointsPerRow = []int32{20, 24, 24, 20}
+				t.InterpretationOfNumberOfPoints = 1
+			},
+		},
+		{
+			name: "east to west",
+			modify: func(t *gdt.Template40FixedPart) {
+				t.ScanningMode = -128 // 0x80
+				t.LongitudeOfFirstGridPoint, t.LongitudeOfLastGridPoint = 359718750, 0
+			},
+		},
+		{
+			name:    "wrong i scanning direction",
+			modify:  func(t *gdt.Template40FixedPart) { t.ScanningMode = -128 },
+			wantErr: "the first and last grid points are 0.281250 degrees apart",
+		},
+		{
+			name:    "invalid n",
+			modify:  func(t *gdt.Template40FixedPart) { t.N = 0 },
+			wantErr: "invalid number of parallels between a pole and the equator: 0",
+		},
+		{
+			name:    "wrong nj",
+			modify:  func(t *gdt.Template40FixedPart) { t.Nj = 641 },
+			wantErr: "641 points along a meridian, but there are 640 Gaussian parallels",
+		},
+		{
+			name:    "not a Gaussian latitude",
+			modify:  func(t *gdt.Template40FixedPart) { t.LatitudeOfFirstGridPoint = 89700000 },
+			wantErr: "latitude 89.700000 is not a Gaussian latitude of N320",
+		},
+		{
+			name:    "wrong increment",
+			modify:  func(t *gdt.Template40FixedPart) { t.IDirectionIncrement = 250000 },
+			wantErr: "i direction spaced by 0.250000",
+		},
+		{
+			name: "wrong number of rows",
+			modify: func(t *gdt.Template40FixedPart) {
+				t.PointsPerRow = make([]int32, 639)
+				t.InterpretationOfNumberOfPoints = 1
+			},
+			wantErr: "quasi-regular grid has 640 parallels, but the number of points of 639 rows",
+		},
+		{
+			name: "latitudes of the rows",
+			modify: func(t *gdt.Template40FixedPart) {
+				t.PointsPerRow = make([]int32, 640)
+				t.InterpretationOfNumberOfPoints = 3
+			},
+			wantErr: "unsupported interpretation of list of numbers of points: 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpldef := valid
+			tt.modify(&tpldef)
+
+			err := gdt.Validate(tpldef.AsTemplate())
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
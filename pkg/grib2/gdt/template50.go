@@ -0,0 +1,126 @@
+package gdt
+
+import (
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) The pentagonal representation of resolution is general. Some common truncations are special cases of the pentagonal one: triangular M = J = K, rhomboidal K = J + M, trapezoidal K = J, K > M.
+
+( 2) The representation type (see Code Table 3.6) indicates the method used to define the normalization of the associated Legendre functions.
+
+( 3) The representation mode (see Code Table 3.7) indicates the order of the coefficients, whether global or hemispheric data are depicted, and the nature of the parameter stored (symmetric or antisymmetric).
+*/
+type Template50 struct {
+	Template50FixedPart `json:"template50"`
+	grid                *reducedGrid
+}
+
+// https://codes.ecmwf.int/grib/format/grib2/templates/3/50/
+type template50FixedPart struct {
+	J            uint32
+	K            uint32
+	M            uint32
+	SpectralType uint8
+	SpectralMode uint8
+}
+
+func (t template50FixedPart) Export() Template {
+	t50 := Template50FixedPart{
+		J:            regulation.ToInt32(t.J),
+		K:            regulation.ToInt32(t.K),
+		M:            regulation.ToInt32(t.M),
+		SpectralType: regulation.ToInt8(t.SpectralType),
+		SpectralMode: regulation.ToInt8(t.SpectralMode),
+	}
+
+	return t50.AsTemplate()
+}
+
+type Template50FixedPart struct {
+	J            int32 `json:"j"`            // Pentagonal resolution parameter J
+	K            int32 `json:"k"`            // Pentagonal resolution parameter K
+	M            int32 `json:"m"`            // Pentagonal resolution parameter M
+	SpectralType int8  `json:"spectralType"` // Representation type: https://codes.ecmwf.int/grib/format/grib2/ctables/3/6/
+	SpectralMode int8  `json:"spectralMode"` // Representation mode: https://codes.ecmwf.int/grib/format/grib2/ctables/3/7/
+	// Not coded in section 3: the grid the coefficients are synthesized onto, see WithGrid
+	Grid *SynthesisGrid `json:"grid,omitempty"`
+}
+
+// SynthesisGrid is a global grid whose rows of points are evenly spaced in longitude from the Greenwich
+// meridian eastward, grid point values are ordered row by row.
+type SynthesisGrid struct {
+	Latitudes []float64 `json:"latitudes"` // latitude of each row, in degrees
+	Ni        int32     `json:"ni"`        // number of points along each row
+}
+
+func (t *Template50FixedPart) AsTemplate() Template {
+	tpl := &Template50{
+		Template50FixedPart: *t,
+	}
+
+	if g := t.Grid; g != nil && g.Ni > 0 {
+		points := make([]int32, len(g.Latitudes))
+		for j := range points {
+			points[j] = g.Ni
+		}
+
+		tpl.grid = newReducedGrid(g.Latitudes, points, 0, 360-360/float64(g.Ni), 0, true)
+	}
+
+	return tpl
+}
+
+// WithGrid returns the template of the grid point values synthesized from the coefficients onto the
+// rows at latitudes, with ni points along each row.
+func (t *Template50FixedPart) WithGrid(latitudes []float64, ni int) Template {
+	fp := *t
+	fp.Grid = &SynthesisGrid{Latitudes: latitudes, Ni: int32(ni)}
+
+	return fp.AsTemplate()
+}
+
+// GetNi returns the number of points along a row of the synthesis grid, 0 for the coefficients.
+func (t *Template50FixedPart) GetNi() int32 {
+	if t.Grid == nil {
+		return 0
+	}
+
+	return t.Grid.Ni
+}
+
+// GetNj returns the number of rows of the synthesis grid, 0 for the coefficients.
+func (t *Template50FixedPart) GetNj() int32 {
+	if t.Grid == nil {
+		return 0
+	}
+
+	return int32(len(t.Grid.Latitudes))
+}
+
+// GetEarthShape returns the zero Ellipsoid, spherical harmonic coefficients do not define the shape of the Earth.
+func (t *Template50FixedPart) GetEarthShape() Ellipsoid {
+	return Ellipsoid{}
+}
+
+// GetGridIndex returns the point of the synthesis grid nearest to lat, lon, -1 for the coefficients.
+func (t *Template50) GetGridIndex(lat, lon float32) (n int) {
+	if t.grid == nil {
+		return -1
+	}
+
+	return t.grid.index(float64(lat), float64(lon))
+}
+
+// GetGridPoint returns the latitude and longitude of the nth point of the synthesis grid, false for the
+// coefficients which are not defined on a grid.
+func (t *Template50) GetGridPoint(n int) (float32, float32, bool) {
+	if t.grid == nil {
+		return 0, 0, false
+	}
+
+	lat, lon, ok := t.grid.point(n)
+
+	return float32(lat), float32(lon), ok
+}
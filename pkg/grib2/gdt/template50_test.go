@@ -0,0 +1,59 @@
+package gdt_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate50_WithGrid(t *testing.T) {
+	fp := &gdt.Template50FixedPart{J: 1, K: 1, M: 1, SpectralType: 1, SpectralMode: 1}
+
+	// the coefficients are not defined on a grid
+	coefficients := fp.AsTemplate()
+	assert.Equal(t, int32(0), coefficients.GetNi())
+	assert.Equal(t, -1, coefficients.GetGridIndex(0, 0))
+
+	_, _, ok := coefficients.GetGridPoint(0)
+	assert.False(t, ok)
+
+	tpl := fp.WithGrid([]float64{60, 0, -60}, 4)
+
+	bs, err := json.Marshal(tpl)
+	require.NoError(t, err)
+
+	restored, err := gdt.UnMarshalJSONTemplate(bs)
+	require.NoError(t, err)
+
+	tests := []struct {
+		n        int
+		lat, lon float32
+	}{
+		{n: 0, lat: 60, lon: 0},
+		{n: 3, lat: 60, lon: 270},
+		{n: 5, lat: 0, lon: 90},
+		{n: 11, lat: -60, lon: 270},
+	}
+
+	for _, tpl := range []gdt.Template{tpl, restored} {
+		assert.Equal(t, int32(4), tpl.GetNi())
+		assert.Equal(t, int32(3), tpl.GetNj())
+
+		for _, tt := range tests {
+			lat, lon, ok := tpl.GetGridPoint(tt.n)
+			require.True(t, ok)
+			assert.Equal(t, tt.lat, lat, "grid point %d", tt.n)
+			assert.Equal(t, tt.lon, lon, "grid point %d", tt.n)
+			assert.Equal(t, tt.n, tpl.GetGridIndex(tt.lat, tt.lon+10), "grid point %d", tt.n)
+		}
+
+		// the first point of a row is the nearest one west of the Greenwich meridian
+		assert.Equal(t, 4, tpl.GetGridIndex(0, -40))
+
+		_, _, ok := tpl.GetGridPoint(12)
+		assert.False(t, ok)
+	}
+}
@@ -0,0 +1,310 @@
+package gdt
+
+import (
+	"math"
+
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) The latitude and longitude of the sub-satellite point are in units of 10-6 degrees.
+
+( 2) Dx and Dy are the apparent diameter of the Earth in grid lengths, in the x and y directions respectively.
+
+( 3) Xp and Yp are the x and y coordinates of the sub-satellite point, in units of 10-3 grid length.
+
+( 4) The orientation of the grid is the angle between the increasing y-axis and the meridian of the sub-satellite point in the direction of increasing latitude, in units of 10-6 degrees.
+
+( 5) Nr is the altitude of the camera from the centre of the Earth, measured in units of the Earth's (equatorial) radius multiplied by a scale factor of 10^6.
+
+( 6) Xo and Yo are the x and y coordinates of the origin of the sector image, in grid lengths. The x-axis increases eastward and the y-axis northward.
+
+( 7) A scaled value of radius of spherical Earth, or major or minor axis of oblate spheroid Earth is derived from applying appropriate scale factor to the value expressed in metres.
+*/
+type Template90 struct {
+	Template90FixedPart `json:"template90"`
+	grid                *projectedGrid `json:"-"`
+	view                *spaceView     `json:"-"`
+}
+
+// https://codes.ecmwf.int/grib/format/grib2/templates/3/90/
+type template90FixedPart struct {
+	ShapeOfTheEarth                     uint8
+	ScaleFactorOfRadiusOfSphericalEarth uint8
+	ScaledValueOfRadiusOfSphericalEarth uint32
+	ScaleFactorOfEarthMajorAxis         uint8
+	ScaledValueOfEarthMajorAxis         uint32
+	ScaleFactorOfEarthMinorAxis         uint8
+	ScaledValueOfEarthMinorAxis         uint32
+	Nx                                  uint32
+	Ny                                  uint32
+	LatitudeOfSubSatellitePoint         uint32
+	LongitudeOfSubSatellitePoint        uint32
+	ResolutionAndComponentFlags         uint8
+	Dx                                  uint32
+	Dy                                  uint32
+	Xp                                  uint32
+	Yp                                  uint32
+	ScanningMode                        uint8
+	OrientationOfTheGrid                uint32
+	Nr                                  uint32
+	Xo                                  uint32
+	Yo                                  uint32
+}
+
+func (t template90FixedPart) Export() Template {
+	t90 := Template90FixedPart{
+		ShapeOfTheEarth:                     regulation.ToInt8(t.ShapeOfTheEarth),
+		ScaleFactorOfRadiusOfSphericalEarth: regulation.ToInt8(t.ScaleFactorOfRadiusOfSphericalEarth),
+		ScaledValueOfRadiusOfSphericalEarth: regulation.ToInt32(t.ScaledValueOfRadiusOfSphericalEarth),
+		ScaleFactorOfEarthMajorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMajorAxis),
+		ScaledValueOfEarthMajorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMajorAxis),
+		ScaleFactorOfEarthMinorAxis:         regulation.ToInt8(t.ScaleFactorOfEarthMinorAxis),
+		ScaledValueOfEarthMinorAxis:         regulation.ToInt32(t.ScaledValueOfEarthMinorAxis),
+		Nx:                                  regulation.ToInt32(t.Nx),
+		Ny:                                  regulation.ToInt32(t.Ny),
+		LatitudeOfSubSatellitePoint:         regulation.ToInt32(t.LatitudeOfSubSatellitePoint),
+		LongitudeOfSubSatellitePoint:        regulation.ToInt32(t.LongitudeOfSubSatellitePoint),
+		ResolutionAndComponentFlags:         regulation.FlagOctet(t.ResolutionAndComponentFlags),
+		Dx:                                  regulation.ToInt32(t.Dx),
+		Dy:                                  regulation.ToInt32(t.Dy),
+		Xp:                                  regulation.ToInt32(t.Xp),
+		Yp:                                  regulation.ToInt32(t.Yp),
+		ScanningMode:                        regulation.FlagOctet(t.ScanningMode),
+		OrientationOfTheGrid:                regulation.ToInt32(t.OrientationOfTheGrid),
+		Nr:                                  regulation.ToInt32(t.Nr),
+		Xo:                                  regulation.ToInt32(t.Xo),
+		Yo:                                  regulation.ToInt32(t.Yo),
+	}
+
+	return t90.AsTemplate()
+}
+
+type Template90FixedPart struct {
+	ShapeOfTheEarth                     int8  `json:"shapeOfTheEarth"`
+	ScaleFactorOfRadiusOfSphericalEarth int8  `json:"scaleFactorOfRadiusOfSphericalEarth"`
+	ScaledValueOfRadiusOfSphericalEarth int32 `json:"scaledValueOfRadiusOfSphericalEarth"`
+	ScaleFactorOfEarthMajorAxis         int8  `json:"scaleFactorOfEarthMajorAxis"`
+	ScaledValueOfEarthMajorAxis         int32 `json:"scaledValueOfEarthMajorAxis"`
+	ScaleFactorOfEarthMinorAxis         int8  `json:"scaleFactorOfEarthMinorAxis"`
+	ScaledValueOfEarthMinorAxis         int32 `json:"scaledValueOfEarthMinorAxis"`
+	Nx                                  int32 `json:"nx"`
+	Ny                                  int32 `json:"ny"`
+	LatitudeOfSubSatellitePoint         int32 `json:"latitudeOfSubSatellitePoint"`
+	LongitudeOfSubSatellitePoint        int32 `json:"longitudeOfSubSatellitePoint"`
+	ResolutionAndComponentFlags         int8  `json:"-"`
+	Dx                                  int32 `json:"dx"`
+	Dy                                  int32 `json:"dy"`
+	Xp                                  int32 `json:"xp"`
+	Yp                                  int32 `json:"yp"`
+	ScanningMode                        int8  `json:"scanningMode"`
+	OrientationOfTheGrid                int32 `json:"orientationOfTheGrid"`
+	Nr                                  int32 `json:"nr"`
+	Xo                                  int32 `json:"xo"`
+	Yo                                  int32 `json:"yo"`
+}
+
+func (t *Template90FixedPart) AsTemplate() Template {
+	earth := t.GetEarthShape()
+
+	var (
+		mode = scanMode(t.ScanningMode)
+		view = newSpaceView(
+			earth,
+			float64(t.LatitudeOfSubSatellitePoint)/1e6,
+			float64(t.LongitudeOfSubSatellitePoint)/1e6,
+			float64(t.Nr)/1e6,
+			float64(t.Dx),
+			float64(t.Dy),
+			float64(t.Xp)/1e3,
+			float64(t.Yp)/1e3,
+			float64(t.OrientationOfTheGrid)/1e6,
+		)
+		// the origin of the sector image is its lower left corner
+		x1 = float64(t.Xo)
+		y1 = float64(t.Yo)
+	)
+
+	if mode.iNegative() {
+		x1 += float64(t.Nx - 1)
+	}
+
+	if !mode.jPositive() {
+		y1 += float64(t.Ny - 1)
+	}
+
+	return &Template90{
+		Template90FixedPart: *t,
+		grid: &projectedGrid{
+			projection: view,
+			x1:         x1,
+			y1:         y1,
+			dx:         1,
+			dy:         1,
+			nx:         int(t.Nx),
+			ny:         int(t.Ny),
+			mode:       mode,
+		},
+		view: view,
+	}
+}
+
+func (t *Template90FixedPart) GetEarthShape() Ellipsoid {
+	return NewEllipsoid(
+		t.ShapeOfTheEarth,
+		t.ScaleFactorOfRadiusOfSphericalEarth, t.ScaledValueOfRadiusOfSphericalEarth,
+		t.ScaleFactorOfEarthMajorAxis, t.ScaledValueOfEarthMajorAxis,
+		t.ScaleFactorOfEarthMinorAxis, t.ScaledValueOfEarthMinorAxis,
+	)
+}
+
+func (t *Template90FixedPart) GetNi() int32 {
+	return t.Nx
+}
+
+func (t *Template90FixedPart) GetNj() int32 {
+	return t.Ny
+}
+
+// ScanAngles returns the scan angles in radians of the camera towards the geographic point lat, lon, x
+// eastward and y northward from the sub-satellite point. It reports false if the point is not visible.
+func (t *Template90) ScanAngles(lat, lon float64) (float64, float64, bool) {
+	x, y := t.view.scanAngles(lat, lon)
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return 0, 0, false
+	}
+
+	return x, y, true
+}
+
+// Geographic returns the latitude and longitude seen at the scan angles x, y in radians, the
+// longitude is in [0, 360). It reports false if the camera does not see the Earth at x, y.
+func (t *Template90) Geographic(x, y float64) (float64, float64, bool) {
+	lat, lon := t.view.geographic(x, y)
+	if math.IsNaN(lat) || math.IsNaN(lon) {
+		return 0, 0, false
+	}
+
+	return lat, normalizeLongitude(lon), true
+}
+
+func (t *Template90) GetGridIndex(lat, lon float32) (n int) {
+	return t.grid.index(float64(lat), float64(lon))
+}
+
+// GetGridPoint returns the latitude and longitude of the nth grid point, it reports false if the
+// grid point is off the Earth disk.
+func (t *Template90) GetGridPoint(n int) (float32, float32, bool) {
+	lat, lon, ok := t.grid.point(n)
+	return float32(lat), float32(lon), ok
+}
+
+// spaceView is the perspective of a camera looking at the centre of an oblate spheroid Earth, the
+// projection plane is in grid lengths, evenly spaced in scan angles from the sub-satellite point.
+type spaceView struct {
+	a, b       float64    // axes of the Earth
+	camera     [3]float64 // position in Earth-centred coordinates
+	f, e, n    [3]float64 // unit vectors towards the centre of the Earth, the east and the north
+	xp, yp     float64    // grid coordinates of the sub-satellite point
+	rx, ry     float64    // scan angles of a grid length
+	sin, cos   float64    // orientation of the grid
+	flattening float64    // a^2 / b^2
+}
+
+func newSpaceView(earth Ellipsoid, lap, lop, nr, dx, dy, xp, yp, orientation float64) *spaceView {
+	var (
+		a      = earth.SemiMajorAxis
+		b      = earth.SemiMinorAxis
+		phi    = lap * math.Pi / 180
+		lambda = lop * math.Pi / 180
+		h      = nr * a
+		// apparent diameter of the Earth seen from the camera
+		angularSize = 2 * math.Asin(1/nr)
+	)
+
+	return &spaceView{
+		a:          a,
+		b:          b,
+		camera:     [3]float64{h * math.Cos(phi) * math.Cos(lambda), h * math.Cos(phi) * math.Sin(lambda), h * math.Sin(phi)},
+		f:          [3]float64{-math.Cos(phi) * math.Cos(lambda), -math.Cos(phi) * math.Sin(lambda), -math.Sin(phi)},
+		e:          [3]float64{-math.Sin(lambda), math.Cos(lambda), 0},
+		n:          [3]float64{-math.Sin(phi) * math.Cos(lambda), -math.Sin(phi) * math.Sin(lambda), math.Cos(phi)},
+		xp:         xp,
+		yp:         yp,
+		rx:         angularSize / dx,
+		ry:         b / a * angularSize / dy,
+		sin:        math.Sin(orientation * math.Pi / 180),
+		cos:        math.Cos(orientation * math.Pi / 180),
+		flattening: a * a / (b * b),
+	}
+}
+
+func dot(u, v [3]float64) float64 {
+	return u[0]*v[0] + u[1]*v[1] + u[2]*v[2]
+}
+
+// scanAngles returns the scan angles towards lat, lon, NaN if the point is on the far side of the Earth.
+func (p *spaceView) scanAngles(lat, lon float64) (float64, float64) {
+	var (
+		phi    = lat * math.Pi / 180
+		lambda = lon * math.Pi / 180
+		e2     = 1 - p.b*p.b/(p.a*p.a)
+		// radius of curvature in the prime vertical
+		nu = p.a / math.Sqrt(1-e2*math.Sin(phi)*math.Sin(phi))
+
+		point = [3]float64{nu * math.Cos(phi) * math.Cos(lambda), nu * math.Cos(phi) * math.Sin(lambda), nu * (1 - e2) * math.Sin(phi)}
+		v     = [3]float64{point[0] - p.camera[0], point[1] - p.camera[1], point[2] - p.camera[2]}
+	)
+
+	// the line of sight crosses the surface from outside, i.e. against its normal
+	normal := [3]float64{point[0] / (p.a * p.a), point[1] / (p.a * p.a), point[2] / (p.b * p.b)}
+	if dot(v, normal) >= 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	return math.Atan2(dot(v, p.e), dot(v, p.f)), math.Asin(dot(v, p.n) / math.Sqrt(dot(v, v)))
+}
+
+// geographic returns the latitude and longitude seen at the scan angles x, y, NaN if the line of
+// sight misses the Earth.
+func (p *spaceView) geographic(x, y float64) (float64, float64) {
+	var d [3]float64
+	for k := range d {
+		d[k] = math.Cos(x)*math.Cos(y)*p.f[k] + math.Sin(x)*math.Cos(y)*p.e[k] + math.Sin(y)*p.n[k]
+	}
+
+	// intersection of the camera + t * d line with the spheroid
+	var (
+		qa   = d[0]*d[0] + d[1]*d[1] + p.flattening*d[2]*d[2]
+		qb   = 2 * (p.camera[0]*d[0] + p.camera[1]*d[1] + p.flattening*p.camera[2]*d[2])
+		qc   = p.camera[0]*p.camera[0] + p.camera[1]*p.camera[1] + p.flattening*p.camera[2]*p.camera[2] - p.a*p.a
+		disc = qb*qb - 4*qa*qc
+	)
+
+	if disc < 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	t := (-qb - math.Sqrt(disc)) / (2 * qa)
+	point := [3]float64{p.camera[0] + t*d[0], p.camera[1] + t*d[1], p.camera[2] + t*d[2]}
+
+	lat := math.Atan(p.flattening * point[2] / math.Hypot(point[0], point[1]))
+	lon := math.Atan2(point[1], point[0])
+
+	return lat * 180 / math.Pi, lon * 180 / math.Pi
+}
+
+// forward returns the grid coordinates of lat, lon.
+func (p *spaceView) forward(lat, lon float64) (float64, float64) {
+	x, y := p.scanAngles(lat, lon)
+
+	return p.xp + (p.cos*x+p.sin*y)/p.rx, p.yp + (p.cos*y-p.sin*x)/p.ry
+}
+
+// inverse returns the latitude and longitude of the grid coordinates x, y.
+func (p *spaceView) inverse(x, y float64) (float64, float64) {
+	u, v := (x-p.xp)*p.rx, (y-p.yp)*p.ry
+
+	return p.geographic(p.cos*u-p.sin*v, p.sin*u+p.cos*v)
+}
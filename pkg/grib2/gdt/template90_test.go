@@ -0,0 +1,186 @@
+package gdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// msgFullDisk is the full disk of a Meteosat Second Generation satellite at 0E.
+var msgFullDisk = gdt.Template90FixedPart{
+	ShapeOfTheEarth:             7,
+	ScaledValueOfEarthMajorAxis: 6378169,
+	ScaledValueOfEarthMinorAxis: 6356584,
+	Nx:                          3712,
+	Ny:                          3712,
+	Dx:                          3622,
+	Dy:                          3622,
+	Xp:                          1856000,
+	Yp:                          1856000,
+	Nr:                          6610689,
+}
+
+func TestTemplate90_GetGridPoint(t *testing.T) {
+	tpl := msgFullDisk.AsTemplate()
+
+	// the sub-satellite point is the 1857th point of the 1856th row from the north
+	n := 1855*3712 + 1856
+
+	lat, lon, ok := tpl.GetGridPoint(n)
+	require.True(t, ok)
+	assert.InDelta(t, 0, lat, 1e-6)
+	assert.InDelta(t, 0, lon, 1e-6)
+	assert.Equal(t, n, tpl.GetGridIndex(0, 0))
+
+	// eastward and northward along the axes
+	lat, lon, ok = tpl.GetGridPoint(n + 100)
+	require.True(t, ok)
+	assert.InDelta(t, 0, lat, 1e-6)
+	assert.Greater(t, lon, float32(0))
+
+	lat, lon, ok = tpl.GetGridPoint(n - 100*3712)
+	require.True(t, ok)
+	assert.Greater(t, lat, float32(0))
+	assert.InDelta(t, 0, lon, 1e-6)
+
+	// the corners of the image are in space
+	for _, n := range []int{0, 3711, 3712*3712 - 3712, 3712*3712 - 1} {
+		_, _, ok := tpl.GetGridPoint(n)
+		assert.False(t, ok, "grid point %d", n)
+	}
+
+	_, _, ok = tpl.GetGridPoint(3712 * 3712)
+	assert.False(t, ok)
+
+	for _, ll := range [][2]float32{{45, 10}, {-30, 340}, {60, 40}, {-50, 320}, {10, 70}} {
+		n := tpl.GetGridIndex(ll[0], ll[1])
+		require.GreaterOrEqual(t, n, 0, "lat: %f, lon: %f", ll[0], ll[1])
+
+		lat, lon, ok := tpl.GetGridPoint(n)
+		require.True(t, ok)
+		assert.InDelta(t, ll[0], lat, 0.5, "lat: %f, lon: %f", ll[0], ll[1])
+		assert.InDelta(t, 0, math.Remainder(float64(ll[1]-lon), 360), 0.5, "lat: %f, lon: %f", ll[0], ll[1])
+		assert.Equal(t, n, tpl.GetGridIndex(lat, lon))
+	}
+
+	// the far side of the Earth
+	assert.Equal(t, -1, tpl.GetGridIndex(0, 180))
+	assert.Equal(t, -1, tpl.GetGridIndex(0, 85))
+}
+
+func TestTemplate90_ScanAngles(t *testing.T) {
+	const (
+		radius = 6371229
+		nr     = 6.6
+	)
+
+	tpl := (&gdt.Template90FixedPart{
+		ShapeOfTheEarth:              6,
+		Nx:                           1000,
+		Ny:                           1000,
+		LongitudeOfSubSatellitePoint: 140000000,
+		Dx:                           1000,
+		Dy:                           1000,
+		Xp:                           500000,
+		Yp:                           500000,
+		Nr:                           nr * 1e6,
+	}).AsTemplate().(*gdt.Template90)
+
+	for _, d := range []float64{-60, -30, -1, 0, 1, 30, 60} {
+		phi := d * math.Pi / 180
+		want := math.Atan2(radius*math.Sin(phi), nr*radius-radius*math.Cos(phi))
+
+		// along the equator
+		x, y, ok := tpl.ScanAngles(0, 140+d)
+		require.True(t, ok)
+		assert.InDelta(t, want, x, 1e-12, "lon: %f", 140+d)
+		assert.InDelta(t, 0, y, 1e-12, "lon: %f", 140+d)
+
+		lat, lon, ok := tpl.Geographic(x, y)
+		require.True(t, ok)
+		assert.InDelta(t, 0, lat, 1e-9)
+		assert.InDelta(t, 140+d, lon, 1e-9)
+
+		// along the meridian of the sub-satellite point
+		x, y, ok = tpl.ScanAngles(d, 140)
+		require.True(t, ok)
+		assert.InDelta(t, 0, x, 1e-12, "lat: %f", d)
+		assert.InDelta(t, want, y, 1e-12, "lat: %f", d)
+
+		lat, lon, ok = tpl.Geographic(x, y)
+		require.True(t, ok)
+		assert.InDelta(t, d, lat, 1e-9)
+		assert.InDelta(t, 140, lon, 1e-9)
+	}
+
+	_, _, ok := tpl.ScanAngles(0, 320)
+	assert.False(t, ok)
+
+	// the Earth is seen within asin(1/nr) of the sub-satellite point
+	_, _, ok = tpl.Geographic(0, math.Asin(1/nr)-1e-6)
+	assert.True(t, ok)
+
+	_, _, ok = tpl.Geographic(0, math.Asin(1/nr)+1e-6)
+	assert.False(t, ok)
+}
+
+func TestTemplate90_Oblate(t *testing.T) {
+	tpl := msgFullDisk.AsTemplate().(*gdt.Template90)
+
+	for _, ll := range [][2]float64{{45, 10}, {-30, 340}, {60, 40}, {-70, 300}, {81, 0}} {
+		x, y, ok := tpl.ScanAngles(ll[0], ll[1])
+		require.True(t, ok)
+
+		lat, lon, ok := tpl.Geographic(x, y)
+		require.True(t, ok)
+		assert.InDelta(t, ll[0], lat, 1e-9)
+		assert.InDelta(t, ll[1], lon, 1e-9)
+	}
+
+	// on an oblate Earth, the geodetic latitude of a point is higher than its geocentric latitude
+	_, y, _ := tpl.ScanAngles(45, 0)
+	sphere := msgFullDisk
+	sphere.ScaledValueOfEarthMinorAxis = sphere.ScaledValueOfEarthMajorAxis
+	_, ySphere, _ := sphere.AsTemplate().(*gdt.Template90).ScanAngles(45, 0)
+	assert.Less(t, y, ySphere)
+}
+
+func TestReadTemplate90(t *testing.T) {
+	var buf bytes.Buffer
+
+	for _, v := range []any{
+		uint8(7), uint8(0), uint32(0), uint8(0), uint32(6378169), uint8(0), uint32(6356584), // shape of the earth
+		uint32(3712), uint32(3712), uint32(0), uint32(0), uint8(0), // nx, ny, sub-satellite point
+		uint32(3622), uint32(3622), uint32(1856000), uint32(1856000), // Dx, Dy, Xp, Yp
+		uint8(0xc0), uint32(0), uint32(6610689), uint32(0), uint32(0), // scanning mode, orientation, Nr, Xo, Yo
+	} {
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, v))
+	}
+
+	require.Equal(t, 66, buf.Len())
+
+	tpl, err := gdt.ReadTemplate(&buf, 90)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template90{}, tpl)
+
+	t90 := tpl.(*gdt.Template90)
+	assert.Equal(t, int32(3712), t90.GetNi())
+	assert.Equal(t, int32(3712), t90.GetNj())
+	assert.Equal(t, int32(6610689), t90.Nr)
+	assert.Equal(t, int8(-64), t90.ScanningMode)
+
+	// scanning from the east to the west and from the south to the north
+	lat, lon, ok := tpl.GetGridPoint(1856*3712 + 1855)
+	require.True(t, ok)
+	assert.InDelta(t, 0, lat, 1e-6)
+	assert.InDelta(t, 0, lon, 1e-6)
+
+	_, lon, ok = tpl.GetGridPoint(1856*3712 + 1855 + 100)
+	require.True(t, ok)
+	assert.Greater(t, lon, float32(180))
+}
@@ -34,6 +34,103 @@ func TestTemplateJSON(t *testing.T) {
 			want:    `{"template0":{"latitudeOfFirstGridPoint":90000000,"longitudeOfFirstGridPoint":0,"latitudeOfLastGridPoint":-90000000,"longitudeOfLastGridPoint":359000000,"iDirectionIncrement":1000000,"jDirectionIncrement":1000000,"scanningMode":0}}`,
 			wantErr: false,
 		},
+		{
+			name: "marshal template 0 with earth shape",
+			input: &gdt.Template0{
+				Template0FixedPart: gdt.Template0FixedPart{
+					ShapeOfTheEarth:                     1,
+					ScaledValueOfRadiusOfSphericalEarth: 6371229,
+					Ni:                                  360,
+					Nj:                                  181,
+					LatitudeOfFirstGridPoint:            90000000,
+					LatitudeOfLastGridPoint:             -90000000,
+					LongitudeOfLastGridPoint:            359000000,
+					IDirectionIncrement:                 1000000,
+					JDirectionIncrement:                 1000000,
+				},
+			},
+			want:    `{"template0":{"shapeOfTheEarth":1,"scaledValueOfRadiusOfSphericalEarth":6371229,"latitudeOfFirstGridPoint":90000000,"longitudeOfFirstGridPoint":0,"latitudeOfLastGridPoint":-90000000,"longitudeOfLastGridPoint":359000000,"iDirectionIncrement":1000000,"jDirectionIncrement":1000000,"scanningMode":0}}`,
+			wantErr: false,
+		},
+		{
+			name: "marshal template 1",
+			input: &gdt.Template1{
+				Template1FixedPart: gdt.Template1FixedPart{
+					Ni:                        421,
+					Nj:                        461,
+					LatitudeOfFirstGridPoint:  -20000000,
+					LongitudeOfFirstGridPoint: 350000000,
+					LatitudeOfLastGridPoint:   21500000,
+					LongitudeOfLastGridPoint:  27000000,
+					IDirectionIncrement:       62500,
+					JDirectionIncrement:       62500,
+					ScanningMode:              64,
+					LatitudeOfSouthernPole:    -40000000,
+					LongitudeOfSouthernPole:   10000000,
+				},
+			},
+			want:    `{"template1":{"ni":421,"nj":461,"latitudeOfFirstGridPoint":-20000000,"longitudeOfFirstGridPoint":350000000,"latitudeOfLastGridPoint":21500000,"longitudeOfLastGridPoint":27000000,"iDirectionIncrement":62500,"jDirectionIncrement":62500,"scanningMode":64,"latitudeOfSouthernPole":-40000000,"longitudeOfSouthernPole":10000000,"angleOfRotation":0}}`,
+			wantErr: false,
+		},
+		{
+			name: "marshal template 10",
+			input: &gdt.Template10{
+				Template10FixedPart: gdt.Template10FixedPart{
+					ShapeOfTheEarth:           6,
+					Ni:                        250,
+					Nj:                        100,
+					LongitudeOfFirstGridPoint: 350000000,
+					LaD:                       20000000,
+					LatitudeOfLastGridPoint:   16017338,
+					LongitudeOfLastGridPoint:  37500000,
+					ScanningMode:              64,
+					Di:                        20000000,
+					Dj:                        20000000,
+				},
+			},
+			want:    `{"template10":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"ni":250,"nj":100,"latitudeOfFirstGridPoint":0,"longitudeOfFirstGridPoint":350000000,"laD":20000000,"latitudeOfLastGridPoint":16017338,"longitudeOfLastGridPoint":37500000,"scanningMode":64,"orientationOfTheGrid":0,"di":20000000,"dj":20000000}}`,
+			wantErr: false,
+		},
+		{
+			name: "marshal template 20",
+			input: &gdt.Template20{
+				Template20FixedPart: gdt.Template20FixedPart{
+					ShapeOfTheEarth:           6,
+					Nx:                        304,
+					Ny:                        448,
+					LatitudeOfFirstGridPoint:  33920000,
+					LongitudeOfFirstGridPoint: 279260000,
+					LaD:                       70000000,
+					LoV:                       315000000,
+					Dx:                        25000000,
+					Dy:                        25000000,
+				},
+			},
+			want:    `{"template20":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"nx":304,"ny":448,"latitudeOfFirstGridPoint":33920000,"longitudeOfFirstGridPoint":279260000,"laD":70000000,"loV":315000000,"dx":25000000,"dy":25000000,"projectionCentreFlag":0,"scanningMode":0}}`,
+			wantErr: false,
+		},
+		{
+			name: "marshal template 30",
+			input: &gdt.Template30{
+				Template30FixedPart: gdt.Template30FixedPart{
+					ShapeOfTheEarth:           6,
+					Nx:                        1799,
+					Ny:                        1059,
+					LatitudeOfFirstGridPoint:  21138123,
+					LongitudeOfFirstGridPoint: 237280472,
+					LaD:                       38500000,
+					LoV:                       262500000,
+					Dx:                        3000000,
+					Dy:                        3000000,
+					ScanningMode:              64,
+					Latin1:                    38500000,
+					Latin2:                    38500000,
+					LatitudeOfSouthernPole:    -90000000,
+				},
+			},
+			want:    `{"template30":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"nx":1799,"ny":1059,"latitudeOfFirstGridPoint":21138123,"longitudeOfFirstGridPoint":237280472,"laD":38500000,"loV":262500000,"dx":3000000,"dy":3000000,"projectionCentreFlag":0,"scanningMode":64,"latin1":38500000,"latin2":38500000,"latitudeOfSouthernPole":-90000000,"longitudeOfSouthernPole":0}}`,
+			wantErr: false,
+		},
 		{
 			name: "marshal template 40",
 			input: &gdt.Template40{
@@ -45,6 +142,105 @@ func TestTemplateJSON(t *testing.T) {
 			want:    `{"template40":{"n":768,"scanningMode":0}}`,
 			wantErr: false,
 		},
+		{
+			name: "marshal template 50",
+			input: &gdt.Template50{
+				Template50FixedPart: gdt.Template50FixedPart{
+					J:            639,
+					K:            639,
+					M:            639,
+					SpectralType: 1,
+					SpectralMode: 1,
+				},
+			},
+			want:    `{"template50":{"j":639,"k":639,"m":639,"spectralType":1,"spectralMode":1}}`,
+			wantErr: false,
+		},
+		{
+			name: "marshal template 90",
+			input: &gdt.Template90{
+				Template90FixedPart: gdt.Template90FixedPart{
+					ShapeOfTheEarth:             7,
+					ScaledValueOfEarthMajorAxis: 6378169,
+					ScaledValueOfEarthMinorAxis: 6356584,
+					Nx:                          3712,
+					Ny:                          3712,
+					Dx:                          3622,
+					Dy:                          3622,
+					Xp:                          1856000,
+					Yp:                          1856000,
+					Nr:                          6610689,
+				},
+			},
+			want:    `{"template90":{"shapeOfTheEarth":7,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":6378169,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":6356584,"nx":3712,"ny":3712,"latitudeOfSubSatellitePoint":0,"longitudeOfSubSatellitePoint":0,"dx":3622,"dy":3622,"xp":1856000,"yp":1856000,"scanningMode":0,"orientationOfTheGrid":0,"nr":6610689,"xo":0,"yo":0}}`,
+			wantErr: false,
+		},
+		{
+			name: "marshal template 101",
+			input: &gdt.Template101{
+				Template101FixedPart: gdt.Template101FixedPart{
+					ShapeOfTheEarth:         6,
+					NumberOfGridUsed:        26,
+					NumberOfGridInReference: 1,
+					UUIDOfTheHorizontalGrid: "a27b8de6-18c4-11e4-820a-b5b098c6a5c0",
+				},
+			},
+			want:    `{"template101":{"shapeOfTheEarth":6,"numberOfGridUsed":26,"numberOfGridInReference":1,"uuidOfHGrid":"a27b8de6-18c4-11e4-820a-b5b098c6a5c0"}}`,
+			wantErr: false,
+		},
+		{
+			name: "marshal template 204",
+			input: &gdt.Template204{
+				Template204FixedPart: gdt.Template204FixedPart{
+					ShapeOfTheEarth: 6,
+					Ni:              1442,
+					Nj:              1021,
+					ScanningMode:    64,
+				},
+			},
+			want:    `{"template204":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"ni":1442,"nj":1021,"scanningMode":64}}`,
+			wantErr: false,
+		},
+		{
+			name: "marshal template 32768",
+			input: &gdt.Template32768{
+				Template32768FixedPart: gdt.Template32768FixedPart{
+					ShapeOfTheEarth:           6,
+					Nx:                        181,
+					Ny:                        121,
+					LatitudeOfFirstGridPoint:  12190000,
+					LongitudeOfFirstGridPoint: 226541000,
+					CentralLatitude:           50000000,
+					CentralLongitude:          253000000,
+					Di:                        126000,
+					Dj:                        108000,
+					ScanningMode:              64,
+				},
+			},
+			want:    `{"template32768":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"nx":181,"ny":121,"latitudeOfFirstGridPoint":12190000,"longitudeOfFirstGridPoint":226541000,"centralLatitude":50000000,"centralLongitude":253000000,"di":126000,"dj":108000,"scanningMode":64}}`,
+			wantErr: false,
+		},
+		{
+			name: "marshal template 32769",
+			input: &gdt.Template32769{
+				Template32769FixedPart: gdt.Template32769FixedPart{
+					ShapeOfTheEarth:           6,
+					Nx:                        181,
+					Ny:                        121,
+					LatitudeOfFirstGridPoint:  12190000,
+					LongitudeOfFirstGridPoint: 226541000,
+					CentralLatitude:           50000000,
+					CentralLongitude:          253000000,
+					Di:                        126000,
+					Dj:                        108000,
+					ScanningMode:              64,
+					LatitudeOfLastGridPoint:   61014000,
+					LongitudeOfLastGridPoint:  330385000,
+				},
+			},
+			want:    `{"template32769":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"nx":181,"ny":121,"latitudeOfFirstGridPoint":12190000,"longitudeOfFirstGridPoint":226541000,"centralLatitude":50000000,"centralLongitude":253000000,"di":126000,"dj":108000,"scanningMode":64,"latitudeOfLastGridPoint":61014000,"longitudeOfLastGridPoint":330385000}}`,
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -82,6 +278,102 @@ func TestTemplate0_UnmarshalJSON(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "unmarshal template 0 with basic angle",
+			input: `{"template0":{"basicAngleOfTheInitialProductionDomain":1,"subdivisionsOfBasicAngle":8,"latitudeOfFirstGridPoint":720,"longitudeOfFirstGridPoint":0,"latitudeOfLastGridPoint":-720,"longitudeOfLastGridPoint":2879,"iDirectionIncrement":1,"jDirectionIncrement":1,"scanningMode":0}}`,
+			want: &gdt.Template0{
+				Template0FixedPart: gdt.Template0FixedPart{
+					BasicAngleOfTheInitialProductionDomain: 1,
+					SubdivisionsOfBasicAngle:               8,
+					LatitudeOfFirstGridPoint:               720,
+					LongitudeOfFirstGridPoint:              0,
+					LatitudeOfLastGridPoint:                -720,
+					LongitudeOfLastGridPoint:               2879,
+					IDirectionIncrement:                    1,
+					JDirectionIncrement:                    1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unmarshal template 1",
+			input: `{"template1":{"ni":421,"nj":461,"latitudeOfFirstGridPoint":-20000000,"longitudeOfFirstGridPoint":350000000,"latitudeOfLastGridPoint":21500000,"longitudeOfLastGridPoint":27000000,"iDirectionIncrement":62500,"jDirectionIncrement":62500,"scanningMode":64,"latitudeOfSouthernPole":-40000000,"longitudeOfSouthernPole":10000000,"angleOfRotation":0}}`,
+			want: &gdt.Template1{
+				Template1FixedPart: gdt.Template1FixedPart{
+					Ni:                        421,
+					Nj:                        461,
+					LatitudeOfFirstGridPoint:  -20000000,
+					LongitudeOfFirstGridPoint: 350000000,
+					LatitudeOfLastGridPoint:   21500000,
+					LongitudeOfLastGridPoint:  27000000,
+					IDirectionIncrement:       62500,
+					JDirectionIncrement:       62500,
+					ScanningMode:              64,
+					LatitudeOfSouthernPole:    -40000000,
+					LongitudeOfSouthernPole:   10000000,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unmarshal template 10",
+			input: `{"template10":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"ni":250,"nj":100,"latitudeOfFirstGridPoint":0,"longitudeOfFirstGridPoint":350000000,"laD":20000000,"latitudeOfLastGridPoint":16017338,"longitudeOfLastGridPoint":37500000,"scanningMode":64,"orientationOfTheGrid":0,"di":20000000,"dj":20000000}}`,
+			want: &gdt.Template10{
+				Template10FixedPart: gdt.Template10FixedPart{
+					ShapeOfTheEarth:           6,
+					Ni:                        250,
+					Nj:                        100,
+					LongitudeOfFirstGridPoint: 350000000,
+					LaD:                       20000000,
+					LatitudeOfLastGridPoint:   16017338,
+					LongitudeOfLastGridPoint:  37500000,
+					ScanningMode:              64,
+					Di:                        20000000,
+					Dj:                        20000000,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unmarshal template 20",
+			input: `{"template20":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"nx":304,"ny":448,"latitudeOfFirstGridPoint":33920000,"longitudeOfFirstGridPoint":279260000,"laD":70000000,"loV":315000000,"dx":25000000,"dy":25000000,"projectionCentreFlag":0,"scanningMode":0}}`,
+			want: &gdt.Template20{
+				Template20FixedPart: gdt.Template20FixedPart{
+					ShapeOfTheEarth:           6,
+					Nx:                        304,
+					Ny:                        448,
+					LatitudeOfFirstGridPoint:  33920000,
+					LongitudeOfFirstGridPoint: 279260000,
+					LaD:                       70000000,
+					LoV:                       315000000,
+					Dx:                        25000000,
+					Dy:                        25000000,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unmarshal template 30",
+			input: `{"template30":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"nx":1799,"ny":1059,"latitudeOfFirstGridPoint":21138123,"longitudeOfFirstGridPoint":237280472,"laD":38500000,"loV":262500000,"dx":3000000,"dy":3000000,"projectionCentreFlag":0,"scanningMode":64,"latin1":38500000,"latin2":38500000,"latitudeOfSouthernPole":-90000000,"longitudeOfSouthernPole":0}}`,
+			want: &gdt.Template30{
+				Template30FixedPart: gdt.Template30FixedPart{
+					ShapeOfTheEarth:           6,
+					Nx:                        1799,
+					Ny:                        1059,
+					LatitudeOfFirstGridPoint:  21138123,
+					LongitudeOfFirstGridPoint: 237280472,
+					LaD:                       38500000,
+					LoV:                       262500000,
+					Dx:                        3000000,
+					Dy:                        3000000,
+					ScanningMode:              64,
+					Latin1:                    38500000,
+					Latin2:                    38500000,
+					LatitudeOfSouthernPole:    -90000000,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name:  "unmarshal template 40",
 			input: `{"template40":{"n":768,"scanningMode":0}}`,
@@ -93,6 +385,105 @@ func TestTemplate0_UnmarshalJSON(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "unmarshal template 50",
+			input: `{"template50":{"j":639,"k":639,"m":639,"spectralType":1,"spectralMode":1}}`,
+			want: &gdt.Template50{
+				Template50FixedPart: gdt.Template50FixedPart{
+					J:            639,
+					K:            639,
+					M:            639,
+					SpectralType: 1,
+					SpectralMode: 1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unmarshal template 90",
+			input: `{"template90":{"shapeOfTheEarth":7,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":6378169,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":6356584,"nx":3712,"ny":3712,"latitudeOfSubSatellitePoint":0,"longitudeOfSubSatellitePoint":0,"dx":3622,"dy":3622,"xp":1856000,"yp":1856000,"scanningMode":0,"orientationOfTheGrid":0,"nr":6610689,"xo":0,"yo":0}}`,
+			want: &gdt.Template90{
+				Template90FixedPart: gdt.Template90FixedPart{
+					ShapeOfTheEarth:             7,
+					ScaledValueOfEarthMajorAxis: 6378169,
+					ScaledValueOfEarthMinorAxis: 6356584,
+					Nx:                          3712,
+					Ny:                          3712,
+					Dx:                          3622,
+					Dy:                          3622,
+					Xp:                          1856000,
+					Yp:                          1856000,
+					Nr:                          6610689,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unmarshal template 101",
+			input: `{"template101":{"shapeOfTheEarth":6,"numberOfGridUsed":26,"numberOfGridInReference":1,"uuidOfHGrid":"a27b8de6-18c4-11e4-820a-b5b098c6a5c0"}}`,
+			want: &gdt.Template101{
+				Template101FixedPart: gdt.Template101FixedPart{
+					ShapeOfTheEarth:         6,
+					NumberOfGridUsed:        26,
+					NumberOfGridInReference: 1,
+					UUIDOfTheHorizontalGrid: "a27b8de6-18c4-11e4-820a-b5b098c6a5c0",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unmarshal template 204",
+			input: `{"template204":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"ni":1442,"nj":1021,"scanningMode":64}}`,
+			want: &gdt.Template204{
+				Template204FixedPart: gdt.Template204FixedPart{
+					ShapeOfTheEarth: 6,
+					Ni:              1442,
+					Nj:              1021,
+					ScanningMode:    64,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unmarshal template 32768",
+			input: `{"template32768":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"nx":181,"ny":121,"latitudeOfFirstGridPoint":12190000,"longitudeOfFirstGridPoint":226541000,"centralLatitude":50000000,"centralLongitude":253000000,"di":126000,"dj":108000,"scanningMode":64}}`,
+			want: &gdt.Template32768{
+				Template32768FixedPart: gdt.Template32768FixedPart{
+					ShapeOfTheEarth:           6,
+					Nx:                        181,
+					Ny:                        121,
+					LatitudeOfFirstGridPoint:  12190000,
+					LongitudeOfFirstGridPoint: 226541000,
+					CentralLatitude:           50000000,
+					CentralLongitude:          253000000,
+					Di:                        126000,
+					Dj:                        108000,
+					ScanningMode:              64,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unmarshal template 32769",
+			input: `{"template32769":{"shapeOfTheEarth":6,"scaleFactorOfRadiusOfSphericalEarth":0,"scaledValueOfRadiusOfSphericalEarth":0,"scaleFactorOfEarthMajorAxis":0,"scaledValueOfEarthMajorAxis":0,"scaleFactorOfEarthMinorAxis":0,"scaledValueOfEarthMinorAxis":0,"nx":181,"ny":121,"latitudeOfFirstGridPoint":12190000,"longitudeOfFirstGridPoint":226541000,"centralLatitude":50000000,"centralLongitude":253000000,"di":126000,"dj":108000,"scanningMode":64,"latitudeOfLastGridPoint":61014000,"longitudeOfLastGridPoint":330385000}}`,
+			want: &gdt.Template32769{
+				Template32769FixedPart: gdt.Template32769FixedPart{
+					ShapeOfTheEarth:           6,
+					Nx:                        181,
+					Ny:                        121,
+					LatitudeOfFirstGridPoint:  12190000,
+					LongitudeOfFirstGridPoint: 226541000,
+					CentralLatitude:           50000000,
+					CentralLongitude:          253000000,
+					Di:                        126000,
+					Dj:                        108000,
+					ScanningMode:              64,
+					LatitudeOfLastGridPoint:   61014000,
+					LongitudeOfLastGridPoint:  330385000,
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,111 @@
+package gdt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// UnstructuredGridSource returns the latitudes and longitudes in degrees of the points of an
+// unstructured grid, in the order of the data.
+type UnstructuredGridSource func() (lats, lons []float64, err error)
+
+type unstructuredGrid struct {
+	source UnstructuredGridSource
+
+	once       sync.Once
+	lats, lons []float64
+	tree       *kdTree
+	err        error
+}
+
+func (g *unstructuredGrid) load() error {
+	g.once.Do(func() {
+		lats, lons, err := g.source()
+		if err != nil {
+			g.err = err
+			return
+		}
+
+		if len(lats) != len(lons) {
+			g.err = fmt.Errorf("%d latitudes and %d longitudes", len(lats), len(lons))
+			return
+		}
+
+		g.lats, g.lons = lats, lons
+		g.tree = newKDTree(lats, lons)
+	})
+
+	return g.err
+}
+
+var unstructuredGrids sync.Map // uuid -> *unstructuredGrid
+
+// RegisterUnstructuredGridSource registers the coordinates of the unstructured grid uuid, see
+// template 3.101. The source is called once, when the coordinates are first needed.
+func RegisterUnstructuredGridSource(uuid string, source UnstructuredGridSource) {
+	unstructuredGrids.Store(strings.ToLower(uuid), &unstructuredGrid{source: source})
+}
+
+// RegisterUnstructuredGrid registers the latitudes and longitudes in degrees of the points of the
+// unstructured grid uuid.
+func RegisterUnstructuredGrid(uuid string, lats, lons []float64) error {
+	if len(lats) != len(lons) {
+		return fmt.Errorf("register unstructured grid %s: %d latitudes and %d longitudes", uuid, len(lats), len(lons))
+	}
+
+	RegisterUnstructuredGridSource(uuid, func() ([]float64, []float64, error) {
+		return lats, lons, nil
+	})
+
+	return nil
+}
+
+// RegisterUnstructuredGridFile registers the JSON file filename as the coordinates of the unstructured
+// grid uuid, the file is read when the coordinates are first needed. It holds the latitudes and
+// longitudes in degrees of the points of the grid:
+//
+//	{"uuid": "...", "latitudes": [...], "longitudes": [...]}
+//
+// The uuid of the file is optional, if present it must be the one of the grid.
+func RegisterUnstructuredGridFile(uuid string, filename string) {
+	RegisterUnstructuredGridSource(uuid, func() ([]float64, []float64, error) {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var coords struct {
+			UUID       string    `json:"uuid"`
+			Latitudes  []float64 `json:"latitudes"`
+			Longitudes []float64 `json:"longitudes"`
+		}
+
+		if err := json.Unmarshal(data, &coords); err != nil {
+			return nil, nil, fmt.Errorf("read %s: %w", filename, err)
+		}
+
+		if coords.UUID != "" && !strings.EqualFold(coords.UUID, uuid) {
+			return nil, nil, fmt.Errorf("read %s: coordinates of grid %s, not %s", filename, coords.UUID, uuid)
+		}
+
+		return coords.Latitudes, coords.Longitudes, nil
+	})
+}
+
+// lookupUnstructuredGrid returns the loaded coordinates of the unstructured grid uuid.
+func lookupUnstructuredGrid(uuid string) (*unstructuredGrid, error) {
+	v, ok := unstructuredGrids.Load(strings.ToLower(uuid))
+	if !ok {
+		return nil, fmt.Errorf("unstructured grid %s is not registered", uuid)
+	}
+
+	g := v.(*unstructuredGrid)
+	if err := g.load(); err != nil {
+		return nil, fmt.Errorf("load unstructured grid %s: %w", uuid, err)
+	}
+
+	return g, nil
+}
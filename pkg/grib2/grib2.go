@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 
+	"github.com/scorix/grib-go/pkg/grib2/drt/spectral"
+	"github.com/scorix/grib-go/pkg/grib2/gdt"
 	"github.com/scorix/grib-go/pkg/gribio"
 )
 
@@ -12,6 +15,9 @@ var (
 	ErrNotWellFormed     = errors.New("grib file is not well-formed")
 	ErrEditionNotMatched = errors.New("grib edition number does not match expected value")
 	ErrUnknownSection    = errors.New("encountered an unknown grib section")
+	ErrNoCoordinates     = errors.New("coordinate fields of the grid not found")
+	ErrInvalidGrid       = errors.New("grid definition is not consistent")
+	ErrFieldNotFound     = errors.New("field not found in the message")
 )
 
 // SectionFactory uses the factory pattern to create Section instances
@@ -44,20 +50,68 @@ func (f *DefaultSectionFactory) CreateSection(number int) (Section, error) {
 type Grib2Reader interface {
 	Reader() io.ReaderAt
 	ReadSectionAt(offset int64) (Section, error)
-	ReadMessageAt(offset int64) (IndexedMessage, error)
+	// ReadMessageAt returns the field at subIndex of the message at offset, 0 for single-field messages.
+	ReadMessageAt(offset int64, subIndex int) (IndexedMessage, error)
+	// ReadMessagesAt returns all fields of the message at offset, in the order of their data sections.
+	ReadMessagesAt(offset int64) ([]IndexedMessage, error)
 	EachMessage(f func(m IndexedMessage) (next bool, err error)) error
+	AttachCoordinates(ms ...IndexedMessage) error
 }
 
 type grib2 struct {
 	io.ReaderAt
 	sectionFactory SectionFactory
+	missingValue   float32
+	spectral       spectralOptions
+	validateGrid   bool
 }
 
-func NewGrib2(r io.ReaderAt) Grib2Reader {
-	return &grib2{
+type Grib2Option func(g *grib2)
+
+// WithMissingValue sets the value of grid points that are missing in the bit-map or packed as missing, NaN by default.
+func WithMissingValue(v float32) Grib2Option {
+	return func(g *grib2) {
+		g.missingValue = v
+	}
+}
+
+// WithSpectralGrid sets the grid the spherical harmonic coefficients of spectral data are synthesized onto,
+// by default the linear regular Gaussian grid of the truncation.
+func WithSpectralGrid(grid spectral.Grid) Grib2Option {
+	return func(g *grib2) {
+		g.spectral.grid = &grid
+	}
+}
+
+// WithSpectralCoefficients makes ReadData return the spherical harmonic coefficients of spectral data
+// instead of grid point values.
+func WithSpectralCoefficients() Grib2Option {
+	return func(g *grib2) {
+		g.spectral.coefficients = true
+	}
+}
+
+// WithGridValidation makes the messages fail to read with ErrInvalidGrid when their grid definition is
+// not consistent, see gdt.Validate. Grids whose points are given by other fields or files, curvilinear
+// and unstructured grids, are not validated while their points are not available.
+func WithGridValidation() Grib2Option {
+	return func(g *grib2) {
+		g.validateGrid = true
+	}
+}
+
+func NewGrib2(r io.ReaderAt, opts ...Grib2Option) Grib2Reader {
+	g := &grib2{
 		ReaderAt:       r,
 		sectionFactory: &DefaultSectionFactory{},
+		missingValue:   float32(math.NaN()),
+	}
+
+	for _, opt := range opts {
+		opt(g)
 	}
+
+	return g
 }
 
 func (g *grib2) ReadSectionAt(offset int64) (Section, error) {
@@ -83,18 +137,40 @@ func (g *grib2) ReadSectionAt(offset int64) (Section, error) {
 	return s, nil
 }
 
-func (g *grib2) ReadMessageAt(offset int64) (IndexedMessage, error) {
-	m, err := g.readIndexedMessageAt(offset)
+// ReadMessageAt reads the message at offset and returns its field at subIndex.
+// Use ReadMessagesAt to get all fields of a multi-field message.
+func (g *grib2) ReadMessageAt(offset int64, subIndex int) (IndexedMessage, error) {
+	ms, err := g.readIndexedMessagesAt(offset)
 	if err != nil {
 		return nil, fmt.Errorf("read indexed message at offset %d: %w", offset, err)
 	}
 
-	return m, nil
+	if subIndex < 0 || subIndex >= len(ms) {
+		return nil, fmt.Errorf("%w: message at offset %d has %d fields, got sub-index %d", ErrFieldNotFound, offset, len(ms), subIndex)
+	}
+
+	return ms[subIndex], nil
 }
 
-func (g *grib2) readIndexedMessageAt(offset int64) (IndexedMessage, error) {
-	m := &message{offset: offset}
-	cursor := offset
+// ReadMessagesAt reads the message at offset and returns one IndexedMessage per field.
+func (g *grib2) ReadMessagesAt(offset int64) ([]IndexedMessage, error) {
+	ms, err := g.readIndexedMessagesAt(offset)
+	if err != nil {
+		return nil, fmt.Errorf("read indexed message at offset %d: %w", offset, err)
+	}
+
+	return ms, nil
+}
+
+// readIndexedMessagesAt splits a message into fields. Sections 2 to 7, 3 to 7 or 4 to 7 may be
+// repeated within a message, a field ends with section 7 and inherits the most recent sections
+// that are not repeated.
+func (g *grib2) readIndexedMessagesAt(offset int64) ([]IndexedMessage, error) {
+	var (
+		fields []*message
+		m      = &message{offset: offset, missingValue: g.missingValue, spectral: g.spectral}
+		cursor = offset
+	)
 
 	for {
 		sec, err := g.ReadSectionAt(cursor)
@@ -106,6 +182,13 @@ func (g *grib2) readIndexedMessageAt(offset int64) (IndexedMessage, error) {
 			return nil, fmt.Errorf("assign section %d: %w", sec.Number(), err)
 		}
 
+		if sec.Number() == 7 {
+			field := *m
+			field.subIndex = len(fields)
+			field.grid = field.gridDefinition()
+			fields = append(fields, &field)
+		}
+
 		if sec.Number() == 8 {
 			break
 		}
@@ -113,14 +196,32 @@ func (g *grib2) readIndexedMessageAt(offset int64) (IndexedMessage, error) {
 		cursor += int64(sec.Length())
 	}
 
-	return m, nil
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no data section", ErrNotWellFormed)
+	}
+
+	ms := make([]IndexedMessage, len(fields))
+	for i, field := range fields {
+		field.sec8 = m.sec8
+		ms[i] = field
+
+		if !g.validateGrid || field.sec3 == nil {
+			continue
+		}
+
+		if err := gdt.Validate(field.GetGridDefinitionTemplate()); err != nil && !errors.Is(err, gdt.ErrNoGridPoints) {
+			return nil, fmt.Errorf("%w: field %d: %w", ErrInvalidGrid, i, err)
+		}
+	}
+
+	return ms, nil
 }
 
 func (g *grib2) EachMessage(f func(m IndexedMessage) (next bool, err error)) error {
 	var offset int64
 
 	for {
-		m, err := g.ReadMessageAt(offset)
+		ms, err := g.ReadMessagesAt(offset)
 
 		if errors.Is(err, io.EOF) {
 			return nil
@@ -130,19 +231,103 @@ func (g *grib2) EachMessage(f func(m IndexedMessage) (next bool, err error)) err
 			return fmt.Errorf("read message at offset %d: %w", offset, err)
 		}
 
-		next, err := f(m)
-		if err != nil {
-			return fmt.Errorf("process message at offset %d: %w", offset, err)
-		}
+		for _, m := range ms {
+			next, err := f(m)
+			if err != nil {
+				return fmt.Errorf("process message at offset %d, field %d: %w", offset, m.GetSubIndex(), err)
+			}
 
-		if !next {
-			return nil
+			if !next {
+				return nil
+			}
 		}
 
-		offset += m.GetSize()
+		offset += ms[0].GetSize()
 	}
 }
 
 func (g *grib2) Reader() io.ReaderAt {
 	return g.ReaderAt
 }
+
+// AttachCoordinates attaches to the curvilinear grids (template 3.204) of ms the latitudes and longitudes
+// of their points, read from the first geographical latitude (0.191.1) and longitude (0.191.2) fields
+// of the same grid in the file. The other messages are left as they are.
+func (g *grib2) AttachCoordinates(ms ...IndexedMessage) error {
+	type coordinates struct {
+		lats, lons []float32
+	}
+
+	grids := make(map[gdt.Template204FixedPart]*coordinates)
+
+	for _, m := range ms {
+		if tpl, ok := m.GetGridDefinitionTemplate().(*gdt.Template204); ok && !tpl.HasCoordinates() {
+			grids[tpl.Template204FixedPart] = &coordinates{}
+		}
+	}
+
+	if len(grids) == 0 {
+		return nil
+	}
+
+	pending := len(grids)
+
+	err := g.EachMessage(func(m IndexedMessage) (bool, error) {
+		tpl, ok := m.GetGridDefinitionTemplate().(*gdt.Template204)
+		if !ok || m.GetDiscipline() != 0 || m.GetParameterCategory() != 191 {
+			return true, nil
+		}
+
+		c, ok := grids[tpl.Template204FixedPart]
+		if !ok {
+			return true, nil
+		}
+
+		var dst *[]float32
+
+		switch m.GetParameterNumber() {
+		case 1:
+			dst = &c.lats
+		case 2:
+			dst = &c.lons
+		}
+
+		if dst == nil || *dst != nil {
+			return true, nil
+		}
+
+		data, err := m.ReadData()
+		if err != nil {
+			return false, fmt.Errorf("read coordinates: %w", err)
+		}
+
+		*dst = data
+
+		if c.lats != nil && c.lons != nil {
+			pending--
+		}
+
+		return pending > 0, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, m := range ms {
+		tpl, ok := m.GetGridDefinitionTemplate().(*gdt.Template204)
+		if !ok || tpl.HasCoordinates() {
+			continue
+		}
+
+		c := grids[tpl.Template204FixedPart]
+		if c.lats == nil || c.lons == nil {
+			return fmt.Errorf("message at offset %d, field %d: %w", m.GetOffset(), m.GetSubIndex(), ErrNoCoordinates)
+		}
+
+		if err := tpl.SetCoordinates(c.lats, c.lons); err != nil {
+			return fmt.Errorf("message at offset %d, field %d: %w", m.GetOffset(), m.GetSubIndex(), err)
+		}
+	}
+
+	return nil
+}
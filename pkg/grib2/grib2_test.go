@@ -1,21 +1,27 @@
 package grib2_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"image/png"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	ossio "github.com/scorix/aliyun-oss-io"
+	"github.com/scorix/grib-go/internal/pkg/aec"
 	"github.com/scorix/grib-go/internal/pkg/bitio"
 	"github.com/scorix/grib-go/pkg/grib2"
 	grib "github.com/scorix/grib-go/pkg/grib2"
 	"github.com/scorix/grib-go/pkg/grib2/drt"
 	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
+	"github.com/scorix/grib-go/pkg/grib2/drt/spectral"
 	"github.com/scorix/grib-go/pkg/grib2/gdt"
 	"github.com/scorix/grib-go/pkg/grib2/pdt"
 	"github.com/scorix/grib-go/pkg/grib2/regulation"
@@ -728,6 +734,22 @@ func TestGrib_ReadSectionAt_tmax(t *testing.T) {
 						ScaleFactorOfSecondFixedSurface:               0,
 						ScaledValueOfSecondFixedSurface:               0,
 					},
+					TimeInterval: &pdt.TimeInterval{
+						Year:               2024,
+						Month:              8,
+						Day:                22,
+						Hour:               8,
+						NumberOfTimeRanges: 1,
+						TimeRanges: []pdt.TimeRange{
+							{
+								StatisticalProcess:                2,
+								TypeOfTimeIncrement:               2,
+								IndicatorOfUnitOfTimeForTimeRange: pdt.IndicatorOfUnitForTimeHour,
+								LengthOfTimeRange:                 2,
+								IndicatorOfUnitOfTimeForIncrement: 255,
+							},
+						},
+					},
 				})
 			},
 		},
@@ -1197,6 +1219,45 @@ func TestSection7_ReadData_PortableNetworkGraphics(t *testing.T) {
 	}
 }
 
+func TestSection7_ReadData_JPEG2000(t *testing.T) {
+	t.Parallel()
+
+	// dirpw_surface_1.grib2 of github.com/scorix/go-eccodes test data
+	f, err := os.Open("../testdata/grid_jpeg.grib2")
+	require.NoError(t, err)
+	defer f.Close()
+
+	g := grib.NewGrib2(f)
+
+	msg, err := g.ReadMessageAt(0, 0)
+	require.NoError(t, err)
+
+	tpl, ok := msg.GetDataRepresentationTemplate().(*gridpoint.JPEG2000)
+	require.True(t, ok)
+	require.Equal(t, gridpoint.JPEG2000Lossless, tpl.CompressionType)
+	require.Equal(t, 574159, tpl.GetNumVals())
+
+	data, err := msg.ReadData()
+	require.NoError(t, err)
+	require.Len(t, data, 1440*721)
+
+	count := 0
+	for _, v := range data {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+
+		require.GreaterOrEqual(t, v, float32(0))
+		require.LessOrEqual(t, v, float32(360))
+		count++
+	}
+
+	assert.Equal(t, tpl.GetNumVals(), count)
+
+	// grib_get -l 77.25,10,1 pkg/testdata/grid_jpeg.grib2
+	assert.InDelta(t, 206.98, data[msg.GetGridPointFromLL(77.25, 10)], 1e-4)
+}
+
 func TestSection7_ReadData_RegularGG(t *testing.T) {
 	t.Parallel()
 
@@ -1280,7 +1341,7 @@ func TestGrib2_ReadMessages(t *testing.T) {
 		var offset int64
 
 		for i := 0; ; i++ {
-			msg, err := g.ReadMessageAt(offset)
+			msg, err := g.ReadMessageAt(offset, 0)
 			if errors.Is(err, io.EOF) {
 				break
 			}
@@ -1322,7 +1383,7 @@ func TestGrib2_ReadMessageAt_cwat(t *testing.T) {
 
 		g := grib.NewGrib2(f)
 
-		msg, err := g.ReadMessageAt(0)
+		msg, err := g.ReadMessageAt(0, 0)
 		require.NoError(t, err)
 		require.NotNil(t, msg)
 
@@ -1338,7 +1399,7 @@ func TestGrib2_ReadMessageAt_cwat(t *testing.T) {
 
 		g := grib.NewGrib2(mm)
 
-		msg, err := g.ReadMessageAt(0)
+		msg, err := g.ReadMessageAt(0, 0)
 		require.NoError(t, err)
 		require.NotNil(t, msg)
 
@@ -1404,12 +1465,12 @@ func TestGrib2_ReadMessageAt(t *testing.T) {
 		require.Equal(t, 8, sec8.Number())
 		require.Equal(t, 4, sec8.Length())
 
-		msg1, err := g.ReadMessageAt(0)
+		msg1, err := g.ReadMessageAt(0, 0)
 		require.NoError(t, err)
 		require.NotNil(t, msg1)
 
 		{
-			_, err := g.ReadMessageAt(msg1.GetOffset() + msg1.GetSize())
+			_, err := g.ReadMessageAt(msg1.GetOffset()+msg1.GetSize(), 0)
 			require.ErrorIs(t, err, io.EOF)
 		}
 	})
@@ -1469,12 +1530,12 @@ func TestGrib2_ReadMessageAt(t *testing.T) {
 		require.Equal(t, 8, sec8.Number())
 		require.Equal(t, 4, sec8.Length())
 
-		msg1, err := g.ReadMessageAt(0)
+		msg1, err := g.ReadMessageAt(0, 0)
 		require.NoError(t, err)
 		require.NotNil(t, msg1)
 
 		{
-			_, err := g.ReadMessageAt(msg1.GetOffset() + msg1.GetSize())
+			_, err := g.ReadMessageAt(msg1.GetOffset()+msg1.GetSize(), 0)
 			require.ErrorIs(t, err, io.EOF)
 		}
 	})
@@ -1563,7 +1624,7 @@ func TestGrib2_ReadMessageAt(t *testing.T) {
 		require.Equal(t, 8, sec8.Number())
 		require.Equal(t, 4, sec8.Length())
 
-		msg1, err := g.ReadMessageAt(303462693)
+		msg1, err := g.ReadMessageAt(303462693, 0)
 		require.NoError(t, err)
 		require.NotNil(t, msg1)
 
@@ -1615,11 +1676,11 @@ func TestGrib2_ReadMessageAt(t *testing.T) {
 		}
 
 		{
-			lastMsg, err := g.ReadMessageAt(offset)
+			lastMsg, err := g.ReadMessageAt(offset, 0)
 			require.NoError(t, err)
 			require.NotNil(t, lastMsg)
 
-			_, err = g.ReadMessageAt(lastMsg.GetOffset() + lastMsg.GetSize())
+			_, err = g.ReadMessageAt(lastMsg.GetOffset()+lastMsg.GetSize(), 0)
 			require.ErrorIs(t, err, io.EOF)
 		}
 	})
@@ -1786,3 +1847,689 @@ func TestGrib2_ReadImage(t *testing.T) {
 	// 	require.NotNil(t, img)
 	// })
 }
+
+func TestGrib2_ReadMessagesAt_MultiField(t *testing.T) {
+	t.Parallel()
+
+	// u and v share section 3, v repeats sections 4 to 7 and reuses the bit-map of u
+	data := testMessage(
+		testField{ni: 3, nj: 2, parameterNumber: 2, bitmap: []byte{0b1101_1100}, values: []uint8{1, 2, 3, 4, 5}},
+		testField{ni: 3, nj: 2, parameterNumber: 3, bitMapIndicator: 254, values: []uint8{6, 7, 8, 9, 10}, omitSection3: true},
+	)
+	g := grib.NewGrib2(bytes.NewReader(data), grib.WithMissingValue(0))
+
+	msgs, err := g.ReadMessagesAt(0)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	want := [][]float32{
+		{1, 2, 0, 3, 4, 5},
+		{6, 7, 0, 8, 9, 10},
+	}
+
+	for i, msg := range msgs {
+		assert.Equal(t, i, msg.GetSubIndex())
+		assert.Equal(t, 2+i, msg.GetParameterNumber())
+		assert.Equal(t, int64(len(data)), msg.GetSize())
+		assert.Equal(t, 3, msg.GetNi())
+		assert.Equal(t, 2, msg.GetNj())
+
+		values, err := msg.ReadData()
+		require.NoError(t, err)
+		assert.Equal(t, want[i], values)
+
+		mi, err := msg.DumpMessageIndex()
+		require.NoError(t, err)
+		assert.Equal(t, i, mi.SubIndex)
+		assert.Equal(t, msg.GetDataOffset(), mi.DataOffset)
+	}
+
+	assert.Less(t, msgs[0].GetDataOffset(), msgs[1].GetDataOffset())
+
+	for i := range msgs {
+		msg, err := g.ReadMessageAt(0, i)
+		require.NoError(t, err)
+		assert.Equal(t, i, msg.GetSubIndex())
+		assert.Equal(t, 2+i, msg.GetParameterNumber())
+	}
+
+	_, err = g.ReadMessageAt(0, len(msgs))
+	require.ErrorIs(t, err, grib.ErrFieldNotFound)
+
+	var params []int
+	require.NoError(t, g.EachMessage(func(m grib2.IndexedMessage) (bool, error) {
+		params = append(params, m.GetParameterNumber())
+		return true, nil
+	}))
+	assert.Equal(t, []int{2, 3}, params)
+}
+
+func TestGrib2_EachMessage_CCSDS(t *testing.T) {
+	t.Parallel()
+
+	ccsds := testField{
+		ni:              3,
+		nj:              2,
+		bitMapIndicator: 255,
+		representation: representationSection(6, 42,
+			float32(0), uint16(0), uint16(0), uint8(8), uint8(0),
+			uint8(0), uint8(8), uint16(1),
+		),
+		// no compression option, samples 1 to 6 and the padding of the block
+		values: []byte{0xe0, 0x20, 0x40, 0x60, 0x80, 0xa0, 0xc0, 0xc0, 0xc0},
+	}
+
+	data := append(testMessage(ccsds), testMessage(testField{ni: 3, nj: 2, bitMapIndicator: 255, values: []uint8{7, 8, 9, 10, 11, 12}})...)
+
+	var got [][]float32
+
+	err := grib.NewGrib2(bytes.NewReader(data)).EachMessage(func(msg grib2.IndexedMessage) (bool, error) {
+		values, err := msg.ReadData()
+		if err != nil {
+			return false, err
+		}
+
+		got = append(got, values)
+
+		return true, nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]float32{{1, 2, 3, 4, 5, 6}, {7, 8, 9, 10, 11, 12}}, got)
+}
+
+func TestSection7_ReadData_CCSDS(t *testing.T) {
+	t.Parallel()
+
+	// 2.5 degree global field packed by libaec 1.0.2 with the options of ECMWF open data, see value
+	f, err := os.Open("../testdata/ccsds.grib2")
+	require.NoError(t, err)
+	defer f.Close()
+
+	msg, err := grib.NewGrib2(f).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+
+	tpl, ok := msg.GetDataRepresentationTemplate().(*gridpoint.CCSDS)
+	require.True(t, ok)
+	assert.Equal(t, uint8(16), tpl.Bits)
+	assert.Equal(t, aec.DataMSB|aec.DataPreprocess|aec.Data3Byte, tpl.Flags)
+	assert.Equal(t, uint8(32), tpl.BlockSize)
+	assert.Equal(t, uint16(128), tpl.ReferenceSampleInterval)
+
+	// the field which was packed with a decimal scale factor of 2: a zonal temperature profile with a
+	// wave, noise between the equator and 30N and a constant value south of 60S
+	value := func(i, j int) float64 {
+		lat, lon := 90-2.5*float64(j), 2.5*float64(i)
+		if lat <= -60 {
+			return 233.15
+		}
+
+		v := 273.15 + 30*math.Cos(lat*math.Pi/180) + 10*math.Cos(2*lon*math.Pi/180)*math.Cos(lat*math.Pi/180)
+		if lat >= 0 && lat <= 30 {
+			v += float64((i*7919+j*104729)%997) / 997 * 4
+		}
+
+		return v
+	}
+
+	data, err := msg.ReadData()
+	require.NoError(t, err)
+	require.Len(t, data, 144*73)
+
+	for n, v := range data {
+		require.InDelta(t, value(n%144, n/144), v, 0.006, "grid point %d", n)
+	}
+}
+
+func TestGrib2_ReadData_Spectral(t *testing.T) {
+	t.Parallel()
+
+	// f = 3 + sqrt(3) * sin(lat), coefficients of triangular truncation 1: (0,0) (0,1) (1,1)
+	data := testMessage(testField{
+		bitMapIndicator: 255,
+		values:          []byte{0, 1, 0, 0, 0},
+		grid:            gridSection(6, 50, uint32(1), uint32(1), uint32(1), uint8(1), uint8(1)),
+		representation:  representationSection(6, 50, float32(0), uint16(0), uint16(0), uint8(8), float32(3)),
+	})
+
+	s3 := float32(math.Sqrt(3))
+
+	// latitude of the Gaussian parallels of the linear grid of truncation 1
+	gaussian := float32(math.Asin(1/math.Sqrt(3)) * 180 / math.Pi)
+
+	tests := []struct {
+		name   string
+		opts   []grib2.Grib2Option
+		want   []float32
+		ni, nj int
+		lats   []float32 // of the rows of the synthesis grid
+	}{
+		{
+			name: "gaussian grid of the truncation",
+			want: []float32{4, 4, 4, 4, 2, 2, 2, 2},
+			ni:   4,
+			nj:   2,
+			lats: []float32{gaussian, -gaussian},
+		},
+		{
+			name: "lat/lon grid",
+			opts: []grib2.Grib2Option{grib2.WithSpectralGrid(spectral.RegularLatLon(2, 3))},
+			want: []float32{3 + s3, 3 + s3, 3, 3, 3 - s3, 3 - s3},
+			ni:   2,
+			nj:   3,
+			lats: []float32{90, 0, -90},
+		},
+		{
+			name: "coefficients",
+			opts: []grib2.Grib2Option{grib2.WithSpectralCoefficients()},
+			want: []float32{3, 0, 1, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := grib.NewGrib2(bytes.NewReader(data), tt.opts...).ReadMessageAt(0, 0)
+			require.NoError(t, err)
+			require.Equal(t, int(drt.SpectralDataSimplePacking), msg.GetDataRepresentationTemplateNumber())
+			require.IsType(t, &gdt.Template50{}, msg.GetGridDefinitionTemplate())
+
+			values, err := msg.ReadData()
+			require.NoError(t, err)
+			assert.InDeltaSlice(t, tt.want, values, 1e-5)
+
+			assert.Equal(t, tt.ni, msg.GetNi())
+			assert.Equal(t, tt.nj, msg.GetNj())
+
+			if tt.lats == nil {
+				_, _, ok := msg.GetGridPointLL(0)
+				assert.False(t, ok)
+
+				_, err := grib2.NewMessageReaderFromMessage(bytes.NewReader(data), msg)
+				require.Error(t, err)
+
+				return
+			}
+
+			mi, err := msg.DumpMessageIndex()
+			require.NoError(t, err)
+
+			bs, err := json.Marshal(mi)
+			require.NoError(t, err)
+
+			var restored grib2.MessageIndex
+			require.NoError(t, json.Unmarshal(bs, &restored))
+
+			fromMessage, err := grib2.NewMessageReaderFromMessage(bytes.NewReader(data), msg)
+			require.NoError(t, err)
+
+			fromIndex, err := grib2.NewMessageReaderFromMessageIndex(bytes.NewReader(data), &restored)
+			require.NoError(t, err)
+
+			for n, want := range values {
+				lat, lon, ok := msg.GetGridPointLL(n)
+				require.True(t, ok)
+				assert.InDelta(t, tt.lats[n/tt.ni], lat, 1e-5, "grid point %d", n)
+				assert.InDelta(t, 360*float32(n%tt.ni)/float32(tt.ni), lon, 1e-5, "grid point %d", n)
+				assert.Equal(t, n, msg.GetGridPointFromLL(lat, lon))
+
+				for _, r := range []grib2.MessageReader{fromMessage, fromIndex} {
+					gotLat, gotLon, v, err := r.ReadLL(context.TODO(), lat, lon)
+					require.NoError(t, err)
+					assert.Equal(t, lat, gotLat)
+					assert.Equal(t, lon, gotLon)
+					assert.Equal(t, want, v, "grid point %d", n)
+				}
+			}
+		})
+	}
+}
+
+func TestGrib2_ReadData_RunLength(t *testing.T) {
+	t.Parallel()
+
+	data := testMessage(testField{
+		ni:              3,
+		nj:              2,
+		bitMapIndicator: 255,
+		// 4 bits values, levels 0.5, 1 and 2.5
+		representation: representationSection(6, 200, uint8(4), uint16(3), uint16(3), uint8(1), []uint16{5, 10, 25}),
+		// level 3 run 3, missing level, level 1 run 2 and padding
+		values: []byte{0x36, 0x01, 0x50},
+	})
+
+	msg, err := grib.NewGrib2(bytes.NewReader(data), grib.WithMissingValue(-1)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, int(drt.RunLengthPackingWithLevelValues), msg.GetDataRepresentationTemplateNumber())
+
+	values, err := msg.ReadData()
+	require.NoError(t, err)
+	assert.Equal(t, []float32{2.5, 2.5, 2.5, -1, 0.5, 0.5}, values)
+}
+
+func TestGrib2_ValidateGridDefinition(t *testing.T) {
+	t.Parallel()
+
+	for _, filename := range []string{"temp.grib2", "tmax.grib2", "cwat.grib2", "hpbl.grib2", "grid_complex.grib2", "grid_png.grib2", "grid_jpeg.grib2", "ccsds.grib2"} {
+		t.Run(filename, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(filepath.Join("../testdata", filename))
+			require.NoError(t, err)
+			defer f.Close()
+
+			g := grib.NewGrib2(f)
+
+			var offset int64
+
+			for {
+				msg, err := g.ReadMessageAt(offset, 0)
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				require.NoError(t, err, "failed to read message at offset %d", offset)
+				require.NoError(t, gdt.Validate(msg.GetGridDefinitionTemplate()), "message at offset %d", offset)
+
+				offset += msg.GetSize()
+			}
+		})
+	}
+}
+
+func TestGrib2_WithGridValidation(t *testing.T) {
+	t.Parallel()
+
+	// the last longitude is 5 degrees, but 3 points spaced by 1 degree span 2 degrees
+	data := testMessage(testField{
+		ni:              3,
+		nj:              2,
+		bitMapIndicator: 255,
+		values:          []uint8{1, 2, 3, 4, 5, 6},
+		grid: gridSection(6, 0,
+			uint8(6), uint8(0xff), uint32(math.MaxUint32), uint8(0xff), uint32(math.MaxUint32), uint8(0xff), uint32(math.MaxUint32),
+			uint32(3), uint32(2), uint32(0), uint32(math.MaxUint32),
+			uint32(1e6), uint32(0), uint8(48), uint32(0), uint32(5e6),
+			uint32(1e6), uint32(1e6), uint8(0),
+		),
+	})
+
+	_, err := grib.NewGrib2(bytes.NewReader(data)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+
+	_, err = grib.NewGrib2(bytes.NewReader(data), grib.WithGridValidation()).ReadMessageAt(0, 0)
+	require.ErrorIs(t, err, grib.ErrInvalidGrid)
+
+	// the coordinates of a curvilinear grid are not available until they are attached
+	curvilinear := testMessage(testField{
+		bitMapIndicator: 255,
+		values:          []uint8{1, 2, 3, 4, 5, 6},
+		grid: gridSection(6, 204,
+			uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0),
+			uint32(3), uint32(2), [16]byte{}, uint8(0x30), [16]byte{}, uint8(0x40),
+		),
+	})
+
+	msg, err := grib.NewGrib2(bytes.NewReader(curvilinear), grib.WithGridValidation()).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	assert.ErrorIs(t, gdt.Validate(msg.GetGridDefinitionTemplate()), gdt.ErrNoGridPoints)
+}
+
+func TestGrib2_AttachCoordinates(t *testing.T) {
+	t.Parallel()
+
+	// a curvilinear grid of 3 x 2 points, the coordinates are packed with a precision of 0.1 degree
+	curvilinear := func(category, number uint8, reference float32, values ...uint8) testField {
+		return testField{
+			parameterCategory: category,
+			parameterNumber:   number,
+			bitMapIndicator:   255,
+			values:            values,
+			grid: gridSection(6, 204,
+				uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0),
+				uint32(3), uint32(2), [16]byte{}, uint8(0), [16]byte{}, uint8(0x40),
+			),
+			representation: representationSection(6, 0, reference, uint16(0), uint16(1), uint8(8), uint8(0)),
+		}
+	}
+
+	var (
+		lat  = testMessage(curvilinear(191, 1, 400, 0, 2, 4, 10, 12, 14))
+		lon  = testMessage(curvilinear(191, 2, 2500, 0, 10, 20, 3, 13, 23))
+		temp = testMessage(curvilinear(0, 0, 2730, 1, 2, 3, 4, 5, 6))
+	)
+
+	data := append(append(append([]byte{}, temp...), lat...), lon...)
+	g := grib.NewGrib2(bytes.NewReader(data))
+
+	msg, err := g.ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template204{}, msg.GetGridDefinitionTemplate())
+	assert.Equal(t, 3, msg.GetNi())
+	assert.Equal(t, 2, msg.GetNj())
+
+	assert.Equal(t, -1, msg.GetGridPointFromLL(41.2, 251.3))
+	assert.Error(t, gdt.Validate(msg.GetGridDefinitionTemplate()))
+
+	require.NoError(t, g.AttachCoordinates(msg))
+	require.NoError(t, gdt.Validate(msg.GetGridDefinitionTemplate()))
+
+	lt, ln, ok := msg.GetGridPointLL(4)
+	require.True(t, ok)
+	assert.InDelta(t, 41.2, lt, 1e-4)
+	assert.InDelta(t, 251.3, ln, 1e-4)
+
+	assert.Equal(t, 4, msg.GetGridPointFromLL(41.15, 251.35))
+	assert.Equal(t, 2, msg.GetGridPointFromLL(40.3, -108.1))
+
+	reader, err := grib2.NewMessageReaderFromMessage(bytes.NewReader(data), msg)
+	require.NoError(t, err)
+
+	_, _, v, err := reader.ReadLL(context.TODO(), 41.05, 250.25)
+	require.NoError(t, err)
+	assert.InDelta(t, 273.4, v, 1e-4)
+
+	// messages on other grids are left as they are
+	other := testMessage(testField{ni: 3, nj: 2, bitMapIndicator: 255, values: []uint8{1, 2, 3, 4, 5, 6}})
+	msg, err = grib.NewGrib2(bytes.NewReader(other)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	require.NoError(t, g.AttachCoordinates(msg))
+
+	// the longitudes are missing
+	g = grib.NewGrib2(bytes.NewReader(append(append([]byte{}, temp...), lat...)))
+
+	msg, err = g.ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	require.ErrorIs(t, g.AttachCoordinates(msg), grib.ErrNoCoordinates)
+}
+
+func TestGrib2_ReadMessageAt_Ensemble(t *testing.T) {
+	t.Parallel()
+
+	// the 12th of 31 forecasts of a positively perturbed ensemble
+	data := testMessage(testField{
+		ni:              3,
+		nj:              2,
+		bitMapIndicator: 255,
+		values:          []uint8{1, 2, 3, 4, 5, 6},
+		product:         productSection(1, uint8(0), uint8(8), forecast, uint8(3), uint8(12), uint8(31)),
+	})
+
+	msg, err := grib.NewGrib2(bytes.NewReader(data)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, msg.GetProductDefinitionTemplateNumber())
+	assert.Equal(t, 8, msg.GetParameterNumber())
+
+	e, ok := msg.AsEnsemble()
+	require.True(t, ok)
+	assert.Equal(t, 3, e.GetTypeOfEnsembleForecast())
+	assert.Equal(t, 12, e.GetPerturbationNumber())
+	assert.Equal(t, 31, e.GetNumberOfForecastsInEnsemble())
+	assert.Equal(t, -1, e.GetDerivedForecast())
+
+	values, err := msg.ReadData()
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1, 2, 3, 4, 5, 6}, values)
+
+	// deterministic forecasts are not ensembles
+	data = testMessage(testField{ni: 3, nj: 2, bitMapIndicator: 255, values: []uint8{1, 2, 3, 4, 5, 6}})
+
+	msg, err = grib.NewGrib2(bytes.NewReader(data)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+
+	_, ok = msg.AsEnsemble()
+	assert.False(t, ok)
+}
+
+func TestGrib2_ReadMessageAt_ProbabilityAndPercentile(t *testing.T) {
+	t.Parallel()
+
+	// probability between -2.5 and 2.5, and the 90th percentile
+	var (
+		probability = testMessage(testField{
+			ni:              3,
+			nj:              2,
+			bitMapIndicator: 255,
+			values:          []uint8{1, 2, 3, 4, 5, 6},
+			product:         productSection(5, uint8(0), uint8(8), forecast, uint8(0), uint8(1), uint8(2), uint8(1), uint32(0x80000000|25), uint8(1), uint32(25)),
+		})
+		percentile = testMessage(testField{
+			ni:              3,
+			nj:              2,
+			bitMapIndicator: 255,
+			values:          []uint8{1, 2, 3, 4, 5, 6},
+			product:         productSection(6, uint8(0), uint8(8), forecast, uint8(90)),
+		})
+	)
+
+	msg, err := grib.NewGrib2(bytes.NewReader(probability)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 5, msg.GetProductDefinitionTemplateNumber())
+	assert.Equal(t, 2, msg.GetProbabilityType())
+
+	lower, ok := msg.GetLowerLimit()
+	require.True(t, ok)
+	assert.Equal(t, -2.5, lower)
+
+	upper, ok := msg.GetUpperLimit()
+	require.True(t, ok)
+	assert.Equal(t, 2.5, upper)
+
+	_, ok = msg.GetPercentileValue()
+	assert.False(t, ok)
+
+	msg, err = grib.NewGrib2(bytes.NewReader(percentile)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 6, msg.GetProductDefinitionTemplateNumber())
+	assert.Equal(t, -1, msg.GetProbabilityType())
+
+	value, ok := msg.GetPercentileValue()
+	require.True(t, ok)
+	assert.Equal(t, 90, value)
+
+	_, ok = msg.GetLowerLimit()
+	assert.False(t, ok)
+}
+
+func TestGrib2_ReadMessageAt_StatisticalProcessing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tmax", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := os.Open("../testdata/tmax.grib2")
+		require.NoError(t, err)
+		defer f.Close()
+
+		msg, err := grib.NewGrib2(f).ReadMessageAt(0, 0)
+		require.NoError(t, err)
+
+		s, ok := msg.AsStatisticalProcessing()
+		require.True(t, ok)
+
+		// maximum over the 2 hours before the 44th hour
+		assert.Equal(t, 2, s.GetStatisticalProcess())
+		assert.Equal(t, 0, s.GetNumberOfMissingInStatisticalProcess())
+		require.Len(t, s.GetTimeRanges(), 1)
+		assert.Equal(t, 2*time.Hour, s.GetTimeRanges()[0].GetLength())
+		assert.Equal(t, time.Date(2024, 8, 22, 6, 0, 0, 0, time.UTC), s.GetIntervalStart(time.UTC))
+		assert.Equal(t, time.Date(2024, 8, 22, 8, 0, 0, 0, time.UTC), s.GetIntervalEnd(time.UTC))
+		assert.Equal(t, time.Date(2024, 8, 22, 8, 0, 0, 0, time.UTC), msg.GetForecastTime(time.UTC))
+
+		_, ok = msg.AsEnsemble()
+		assert.False(t, ok)
+	})
+
+	t.Run("nested time ranges of an ensemble member", func(t *testing.T) {
+		t.Parallel()
+
+		// daily averages of 6 hourly fields over 30 days, ending 30 days after the reference time
+		data := testMessage(testField{
+			ni:              3,
+			nj:              2,
+			bitMapIndicator: 255,
+			values:          []uint8{1, 2, 3, 4, 5, 6},
+			product: productSection(11,
+				uint8(0), uint8(0), forecast,
+				uint8(3), uint8(4), uint8(51),
+				uint16(2024), uint8(9), uint8(19), uint8(12), uint8(0), uint8(0), uint8(2), uint32(3),
+				uint8(0), uint8(1), uint8(2), uint32(30), uint8(2), uint32(1),
+				uint8(0), uint8(1), uint8(1), uint32(24), uint8(1), uint32(6),
+			),
+		})
+
+		msg, err := grib.NewGrib2(bytes.NewReader(data)).ReadMessageAt(0, 0)
+		require.NoError(t, err)
+
+		s, ok := msg.AsStatisticalProcessing()
+		require.True(t, ok)
+
+		assert.Equal(t, 0, s.GetStatisticalProcess())
+		assert.Equal(t, 3, s.GetNumberOfMissingInStatisticalProcess())
+		assert.Equal(t, []pdt.TimeRange{
+			{
+				StatisticalProcess:                0,
+				TypeOfTimeIncrement:               1,
+				IndicatorOfUnitOfTimeForTimeRange: pdt.IndicatorOfUnitForTimeDay,
+				LengthOfTimeRange:                 30,
+				IndicatorOfUnitOfTimeForIncrement: pdt.IndicatorOfUnitForTimeDay,
+				TimeIncrement:                     1,
+			},
+			{
+				StatisticalProcess:                0,
+				TypeOfTimeIncrement:               1,
+				IndicatorOfUnitOfTimeForTimeRange: pdt.IndicatorOfUnitForTimeHour,
+				LengthOfTimeRange:                 24,
+				IndicatorOfUnitOfTimeForIncrement: pdt.IndicatorOfUnitForTimeHour,
+				TimeIncrement:                     6,
+			},
+		}, s.GetTimeRanges())
+		assert.Equal(t, 6*time.Hour, s.GetTimeRanges()[1].GetIncrement())
+
+		assert.Equal(t, time.Date(2024, 8, 20, 12, 0, 0, 0, time.UTC), s.GetIntervalStart(time.UTC))
+		assert.Equal(t, time.Date(2024, 9, 19, 12, 0, 0, 0, time.UTC), s.GetIntervalEnd(time.UTC))
+		assert.Equal(t, time.Date(2024, 9, 19, 12, 0, 0, 0, time.UTC), msg.GetForecastTime(time.UTC))
+
+		e, ok := msg.AsEnsemble()
+		require.True(t, ok)
+		assert.Equal(t, 4, e.GetPerturbationNumber())
+
+		values, err := msg.ReadData()
+		require.NoError(t, err)
+		assert.Equal(t, []float32{1, 2, 3, 4, 5, 6}, values)
+	})
+
+	t.Run("point in time", func(t *testing.T) {
+		t.Parallel()
+
+		data := testMessage(testField{ni: 3, nj: 2, bitMapIndicator: 255, values: []uint8{1, 2, 3, 4, 5, 6}})
+
+		msg, err := grib.NewGrib2(bytes.NewReader(data)).ReadMessageAt(0, 0)
+		require.NoError(t, err)
+
+		_, ok := msg.AsStatisticalProcessing()
+		assert.False(t, ok)
+		assert.Equal(t, time.Date(2024, 8, 20, 12, 0, 0, 0, time.UTC), msg.GetForecastTime(time.UTC))
+	})
+}
+
+func TestGrib2_ReadMessageAt_Constituent(t *testing.T) {
+	t.Parallel()
+
+	// daily average of pm10 of an ensemble member: total aerosol smaller than or equal to 1e-5 m
+	data := testMessage(testField{
+		ni:              3,
+		nj:              2,
+		bitMapIndicator: 255,
+		values:          []uint8{1, 2, 3, 4, 5, 6},
+		product: productSection(47,
+			uint8(20), uint8(2),
+			uint16(62000), uint8(9), uint8(0xff), uint32(math.MaxUint32), uint8(5), uint32(1),
+			forecast,
+			uint8(3), uint8(2), uint8(10),
+			uint16(2024), uint8(8), uint8(21), uint8(0), uint8(0), uint8(0), uint8(1), uint32(0),
+			uint8(0), uint8(1), uint8(1), uint32(24), uint8(1), uint32(1),
+		),
+	})
+
+	msg, err := grib.NewGrib2(bytes.NewReader(data)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 47, msg.GetProductDefinitionTemplateNumber())
+	assert.Equal(t, 20, msg.GetParameterCategory())
+	assert.Equal(t, 2, msg.GetParameterNumber())
+	assert.Equal(t, 1, msg.GetTypeOfFirstFixedSurface())
+
+	c, ok := msg.AsConstituent()
+	require.True(t, ok)
+	assert.Equal(t, 62000, c.GetConstituentType())
+	assert.Equal(t, [5]int{9, -1, -1, 5, 1}, [5]int{
+		c.GetTypeOfSizeInterval(),
+		c.GetScaleFactorOfFirstSize(),
+		c.GetScaledValueOfFirstSize(),
+		c.GetScaleFactorOfSecondSize(),
+		c.GetScaledValueOfSecondSize(),
+	})
+	assert.Equal(t, -1, c.GetTypeOfWavelengthInterval())
+
+	_, ok = msg.AsEnsemble()
+	assert.True(t, ok)
+
+	_, ok = msg.AsStatisticalProcessing()
+	assert.True(t, ok)
+
+	values, err := msg.ReadData()
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1, 2, 3, 4, 5, 6}, values)
+
+	// not a constituent
+	data = testMessage(testField{ni: 3, nj: 2, bitMapIndicator: 255, values: []uint8{1, 2, 3, 4, 5, 6}})
+
+	msg, err = grib.NewGrib2(bytes.NewReader(data)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+
+	_, ok = msg.AsConstituent()
+	assert.False(t, ok)
+}
+
+func TestGrib2_ReadMessageAt_Satellite(t *testing.T) {
+	t.Parallel()
+
+	// simulated brightness temperature of channels 13 and 8 of the ABI of GOES-16, 3 hours after the
+	// reference time
+	data := testMessage(testField{
+		ni:              3,
+		nj:              2,
+		bitMapIndicator: 255,
+		values:          []uint8{1, 2, 3, 4, 5, 6},
+		product: productSection(32,
+			uint8(4), uint8(4), uint8(2), uint8(0xff), uint8(83), uint16(0), uint8(0), uint8(1), uint32(3), uint8(2),
+			uint16(333), uint16(16), uint16(617), uint8(0), uint32(96805),
+			uint16(333), uint16(16), uint16(617), uint8(1), uint32(1615510),
+		),
+	})
+
+	msg, err := grib.NewGrib2(bytes.NewReader(data)).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 32, msg.GetProductDefinitionTemplateNumber())
+	assert.Equal(t, 4, msg.GetParameterCategory())
+	assert.Equal(t, 4, msg.GetParameterNumber())
+	assert.Equal(t, msg.GetTimestamp(time.UTC).Add(3*time.Hour), msg.GetForecastTime(time.UTC))
+
+	// no fixed surface
+	assert.Equal(t, 255, msg.GetTypeOfFirstFixedSurface())
+	assert.Equal(t, 0, msg.GetLevel())
+
+	s, ok := msg.AsSatellite()
+	require.True(t, ok)
+	require.Len(t, s.GetSpectralBands(), 2)
+	assert.InDelta(t, 96805, s.GetSpectralBands()[0].GetCentralWaveNumber(), 1e-9)
+	assert.InDelta(t, 161551, s.GetSpectralBands()[1].GetCentralWaveNumber(), 1e-9)
+
+	_, ok = msg.AsEnsemble()
+	assert.False(t, ok)
+
+	values, err := msg.ReadData()
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1, 2, 3, 4, 5, 6}, values)
+}
@@ -5,15 +5,38 @@ import (
 	"fmt"
 
 	"github.com/scorix/grib-go/pkg/grib2/drt"
+	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
 	"github.com/scorix/grib-go/pkg/grib2/gdt"
 )
 
 type MessageIndex struct {
 	Offset         int64        `json:"offset"`
+	SubIndex       int          `json:"sub_index,omitempty"`
 	Size           int64        `json:"size"`
 	DataOffset     int64        `json:"data_offset"`
 	GridDefinition gdt.Template `json:"grid_definition"`
 	Packing        drt.Template `json:"packing"`
+	Bitmap         *Bitmap      `json:"bitmap,omitempty"`
+
+	// Groups is the group directory of complex packing, which locates the packed data of each group in
+	// section 7. It is only stored by WithGroupDirectory: without it, the readers of the index read and
+	// decode the whole section 7 at the first read of a grid point to build the directory.
+	Groups *gridpoint.GroupDirectory `json:"groups,omitempty"`
+}
+
+type messageIndexOptions struct {
+	groups bool
+}
+
+type MessageIndexOption func(o *messageIndexOptions)
+
+// WithGroupDirectory stores the group directory of complex packing in the index, so that the readers
+// of the index, also after a JSON round-trip, only read the group of each grid point from section 7.
+// Section 7 is read and decoded once to build it.
+func WithGroupDirectory() MessageIndexOption {
+	return func(o *messageIndexOptions) {
+		o.groups = true
+	}
 }
 
 func (mi MessageIndex) MarshalJSON() ([]byte, error) {
@@ -23,26 +46,37 @@ func (mi MessageIndex) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(struct {
 		Offset         int64                 `json:"offset"`
+		SubIndex       int                   `json:"sub_index,omitempty"`
 		Size           int64                 `json:"size"`
 		DataOffset     int64                 `json:"data_offset"`
 		GridDefinition gdt.Template          `json:"grid_definition"`
 		Packing        drt.TemplateMarshaler `json:"packing"`
+		Bitmap         *Bitmap               `json:"bitmap,omitempty"`
+
+		Groups *gridpoint.GroupDirectory `json:"groups,omitempty"`
 	}{
 		Offset:         mi.Offset,
+		SubIndex:       mi.SubIndex,
 		Size:           mi.Size,
 		DataOffset:     mi.DataOffset,
 		GridDefinition: mi.GridDefinition,
 		Packing:        tm,
+		Bitmap:         mi.Bitmap,
+		Groups:         mi.Groups,
 	})
 }
 
 func (mi *MessageIndex) UnmarshalJSON(data []byte) error {
 	var temp struct {
 		Offset         int64                 `json:"offset"`
+		SubIndex       int                   `json:"sub_index"`
 		Size           int64                 `json:"size"`
 		DataOffset     int64                 `json:"data_offset"`
 		GridDefinition json.RawMessage       `json:"grid_definition"`
 		Packing        drt.TemplateMarshaler `json:"packing"`
+		Bitmap         *Bitmap               `json:"bitmap"`
+
+		Groups *gridpoint.GroupDirectory `json:"groups"`
 	}
 
 	if err := json.Unmarshal(data, &temp); err != nil {
@@ -50,9 +84,12 @@ func (mi *MessageIndex) UnmarshalJSON(data []byte) error {
 	}
 
 	mi.Offset = temp.Offset
+	mi.SubIndex = temp.SubIndex
 	mi.Size = temp.Size
 	mi.DataOffset = temp.DataOffset
 	mi.Packing = temp.Packing.Template
+	mi.Bitmap = temp.Bitmap
+	mi.Groups = temp.Groups
 
 	tpl, err := gdt.UnMarshalJSONTemplate(temp.GridDefinition)
 	if err != nil {
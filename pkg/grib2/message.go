@@ -5,14 +5,20 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"image/color"
 	"io"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/scorix/grib-go/internal/pkg/bitio"
 	"github.com/scorix/grib-go/pkg/grib2/cache"
+	"github.com/scorix/grib-go/pkg/grib2/definition"
 	"github.com/scorix/grib-go/pkg/grib2/drt"
 	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
+	"github.com/scorix/grib-go/pkg/grib2/drt/spectral"
 	"github.com/scorix/grib-go/pkg/grib2/gdt"
+	"github.com/scorix/grib-go/pkg/grib2/pdt"
 )
 
 type Message interface {
@@ -24,6 +30,8 @@ type Message interface {
 	GetDataRepresentationTemplate() drt.Template
 	GetGridDefinitionTemplate() gdt.Template
 
+	GetBitmap() (*Bitmap, error)
+
 	ReadData() ([]float32, error)
 	Image() (image.Image, error)
 	Step() int
@@ -33,14 +41,22 @@ type Message interface {
 	GetNi() int
 	GetNj() int
 	GetSize() int64
-	DumpMessageIndex() (*MessageIndex, error)
+	DumpMessageIndex(opts ...MessageIndexOption) (*MessageIndex, error)
 }
 
 type IndexedMessage interface {
 	Message
 
 	GetOffset() int64
+	GetSubIndex() int
 	GetDataOffset() int64
+
+	// the parts of the product definition template which only some templates have, false if the
+	// template has not
+	AsEnsemble() (Ensemble, bool)
+	AsStatisticalProcessing() (StatisticalProcessing, bool)
+	AsConstituent() (Constituent, bool)
+	AsSatellite() (Satellite, bool)
 }
 
 type Parameter interface {
@@ -49,6 +65,14 @@ type Parameter interface {
 	GetParameterNumber() int
 	GetTimestamp(loc *time.Location) time.Time
 	GetForecastTime(loc *time.Location) time.Time
+
+	// the probability (code table 4.9) and its limits, and the percentile of probability and percentile
+	// forecasts, product definition templates 4.5, 4.6, 4.9 and 4.10; the type is -1 and the limits and
+	// the percentile are false for other products or when missing
+	GetProbabilityType() int
+	GetLowerLimit() (float64, bool)
+	GetUpperLimit() (float64, bool)
+	GetPercentileValue() (int, bool)
 }
 
 type HasLevel interface {
@@ -61,17 +85,127 @@ type HasLevel interface {
 	GetScaledValueOfSecondFixedSurface() int
 }
 
+// Ensemble is the ensemble forecast of a message, product definition templates 4.1, 4.2, 4.11, 4.12,
+// 4.41, 4.43, 4.45 and 4.47, see IndexedMessage.AsEnsemble. The values which do not apply to the
+// template, or are missing, are -1.
+type Ensemble interface {
+	GetTypeOfEnsembleForecast() int
+	GetPerturbationNumber() int
+	GetNumberOfForecastsInEnsemble() int
+	GetDerivedForecast() int
+}
+
+// StatisticalProcessing is the statistical processing of the values of a message, such as accumulations,
+// averages and extremes, product definition templates 4.8 to 4.12, 4.42, 4.43, 4.46 and 4.47, see
+// IndexedMessage.AsStatisticalProcessing.
+type StatisticalProcessing interface {
+	// GetStatisticalProcess returns the statistical process of the outermost time range, code table 4.10.
+	GetStatisticalProcess() int
+	// GetTimeRanges returns the time ranges over which the values are processed, the outermost first.
+	GetTimeRanges() []pdt.TimeRange
+	GetNumberOfMissingInStatisticalProcess() int
+	GetIntervalStart(loc *time.Location) time.Time
+	GetIntervalEnd(loc *time.Location) time.Time
+}
+
+// Constituent is the atmospheric chemical constituent or aerosol of a message, product definition
+// templates 4.40 to 4.48, see IndexedMessage.AsConstituent. The sizes and wavelengths are in metres,
+// scaled as the fixed surfaces; the values which do not apply to the template, or are missing, are -1.
+type Constituent interface {
+	// GetConstituentType returns the type of the chemical constituent, code table 4.230, or of the
+	// aerosol, code table 4.233.
+	GetConstituentType() int
+	// GetTypeOfSizeInterval returns which of the size limits of an aerosol are given, code table 4.91.
+	GetTypeOfSizeInterval() int
+	GetScaleFactorOfFirstSize() int
+	GetScaledValueOfFirstSize() int
+	GetScaleFactorOfSecondSize() int
+	GetScaledValueOfSecondSize() int
+	// GetTypeOfWavelengthInterval returns which of the optical wavelength limits of an aerosol are given,
+	// code table 4.91.
+	GetTypeOfWavelengthInterval() int
+	GetScaleFactorOfFirstWavelength() int
+	GetScaledValueOfFirstWavelength() int
+	GetScaleFactorOfSecondWavelength() int
+	GetScaledValueOfSecondWavelength() int
+}
+
+// Satellite is the observed or simulated satellite product of a message, product definition templates
+// 4.31 and 4.32, see IndexedMessage.AsSatellite. The spectral bands tell the channels of the product,
+// for example a channel of an imager is given by its satellite, instrument and central wave number.
+type Satellite interface {
+	GetSpectralBands() []pdt.SpectralBand
+}
+
 type message struct {
-	offset int64
-	sec0   *section0
-	sec1   *section1
-	sec2   *section2
-	sec3   *section3
-	sec4   *section4
-	sec5   *section5
-	sec6   *section6
-	sec7   *section7
-	sec8   *section8
+	offset   int64
+	subIndex int // index of the field within a multi-field message
+	sec0     *section0
+	sec1     *section1
+	sec2     *section2
+	sec3     *section3
+	sec4     *section4
+	sec5     *section5
+	sec6     *section6
+	sec7     *section7
+	sec8     *section8
+
+	missingValue float32
+	spectral     spectralOptions
+	grid         gdt.Template // grid of the values, see gridDefinition
+}
+
+type spectralOptions struct {
+	grid         *spectral.Grid
+	coefficients bool
+}
+
+// statisticalProcessing is the statistical processing of the values of a message.
+type statisticalProcessing struct {
+	pdt.StatisticalProcessing
+	m *message
+}
+
+// GetIntervalStart returns the start of the overall time interval, the forecast time.
+func (s *statisticalProcessing) GetIntervalStart(loc *time.Location) time.Time {
+	return s.m.GetTimestamp(loc).Add(s.m.sec4.GetProductDefinitionTemplate().GetForecastDuration())
+}
+
+// GetIntervalEnd returns the end of the overall time interval.
+func (s *statisticalProcessing) GetIntervalEnd(loc *time.Location) time.Time {
+	return s.GetEndOfOverallTimeInterval(loc)
+}
+
+// AsEnsemble returns the ensemble forecast of the product definition template, false if the values
+// are not an ensemble forecast.
+func (m *message) AsEnsemble() (Ensemble, bool) {
+	e, ok := m.sec4.GetProductDefinitionTemplate().(Ensemble)
+	return e, ok
+}
+
+// AsStatisticalProcessing returns the statistical processing of the values, false if they are not
+// statistically processed.
+func (m *message) AsStatisticalProcessing() (StatisticalProcessing, bool) {
+	s, ok := m.sec4.GetProductDefinitionTemplate().(pdt.StatisticalProcessing)
+	if !ok {
+		return nil, false
+	}
+
+	return &statisticalProcessing{StatisticalProcessing: s, m: m}, true
+}
+
+// AsSatellite returns the spectral bands of a satellite product, false if the values are not a
+// satellite product.
+func (m *message) AsSatellite() (Satellite, bool) {
+	sat, ok := m.sec4.GetProductDefinitionTemplate().(Satellite)
+	return sat, ok
+}
+
+// AsConstituent returns the atmospheric chemical constituent or aerosol of the product definition
+// template, false if the values are not of a constituent.
+func (m *message) AsConstituent() (Constituent, bool) {
+	c, ok := m.sec4.GetProductDefinitionTemplate().(Constituent)
+	return c, ok
 }
 
 func (m message) GetDiscipline() int {
@@ -90,8 +224,31 @@ func (m message) GetTimestamp(loc *time.Location) time.Time {
 	return m.sec1.GetTime(loc)
 }
 
+// GetForecastTime returns the time the values are valid at, the end of the overall time interval of
+// statistically processed values.
 func (m message) GetForecastTime(loc *time.Location) time.Time {
-	return m.GetTimestamp(loc).Add(m.sec4.GetProductDefinitionTemplate().GetForecastDuration())
+	tpl := m.sec4.GetProductDefinitionTemplate()
+	if s, ok := tpl.(pdt.StatisticalProcessing); ok {
+		return s.GetEndOfOverallTimeInterval(loc)
+	}
+
+	return m.GetTimestamp(loc).Add(tpl.GetForecastDuration())
+}
+
+func (m message) GetProbabilityType() int {
+	return m.sec4.GetProductDefinitionTemplate().GetProbabilityType()
+}
+
+func (m message) GetLowerLimit() (float64, bool) {
+	return m.sec4.GetProductDefinitionTemplate().GetLowerLimit()
+}
+
+func (m message) GetUpperLimit() (float64, bool) {
+	return m.sec4.GetProductDefinitionTemplate().GetUpperLimit()
+}
+
+func (m message) GetPercentileValue() (int, bool) {
+	return m.sec4.GetProductDefinitionTemplate().GetPercentileValue()
 }
 
 func (m *message) GetLevel() int {
@@ -108,9 +265,135 @@ func (m *message) ReadData() ([]float32, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read data using template %T: %w", tpl, err)
 	}
+
+	switch tpl.(type) {
+	case *spectral.SimplePacking, *spectral.ComplexPacking:
+		if m.spectral.coefficients {
+			return data, nil
+		}
+
+		return m.synthesize(data)
+
+	case *gridpoint.RunLengthPacking:
+		// the missing level decodes as NaN
+		if !math.IsNaN(float64(m.missingValue)) {
+			for i, v := range data {
+				if math.IsNaN(float64(v)) {
+					data[i] = m.missingValue
+				}
+			}
+		}
+	}
+
+	bm, err := m.GetBitmap()
+	if err != nil {
+		return nil, err
+	}
+
+	if bm != nil {
+		data, err = bm.Expand(data, m.missingValue)
+		if err != nil {
+			return nil, fmt.Errorf("apply bit-map: %w", err)
+		}
+	}
+
 	return data, nil
 }
 
+// synthesisGrid returns the grid the spherical harmonic coefficients of m are synthesized onto by
+// ReadData, false if the values of m are not synthesized.
+func (m *message) synthesisGrid() (spectral.Grid, bool) {
+	tpl := m.GetDataRepresentationTemplate()
+
+	switch tpl.(type) {
+	case *spectral.SimplePacking, *spectral.ComplexPacking:
+	default:
+		return spectral.Grid{}, false
+	}
+
+	if m.spectral.coefficients {
+		return spectral.Grid{}, false
+	}
+
+	if m.spectral.grid != nil {
+		return *m.spectral.grid, true
+	}
+
+	j, err := spectral.Truncation(tpl.GetNumVals())
+	if err != nil {
+		// ReadData fails on the coefficients of other truncations
+		return spectral.Grid{}, false
+	}
+
+	return spectral.DefaultGrid(j), true
+}
+
+// gridDefinition returns the grid of the values of m, which is the grid of section 3 unless spherical
+// harmonic coefficients are synthesized onto another grid.
+func (m *message) gridDefinition() gdt.Template {
+	if m.sec3 == nil {
+		return nil
+	}
+
+	tpl := m.sec3.GetGridDefinitionTemplate()
+
+	t50, ok := tpl.(*gdt.Template50)
+	if !ok {
+		return tpl
+	}
+
+	grid, ok := m.synthesisGrid()
+	if !ok {
+		return tpl
+	}
+
+	return t50.WithGrid(grid.Latitudes, grid.Ni)
+}
+
+// synthesize computes the grid point values of spherical harmonic coefficients.
+func (m *message) synthesize(coefficients []float32) ([]float32, error) {
+	var grid spectral.Grid
+
+	if m.spectral.grid != nil {
+		grid = *m.spectral.grid
+	} else {
+		j, err := spectral.Truncation(len(coefficients))
+		if err != nil {
+			return nil, err
+		}
+
+		grid = spectral.DefaultGrid(j)
+	}
+
+	data, err := spectral.Synthesize(coefficients, grid)
+	if err != nil {
+		return nil, fmt.Errorf("synthesize spectral data: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetBitmap returns the bit-map of section 6, or nil if the bit-map does not apply to the message.
+func (m *message) GetBitmap() (*Bitmap, error) {
+	if m.sec6 == nil {
+		return nil, nil
+	}
+
+	switch m.sec6.BitMapIndicator {
+	case definition.BitMapIndicatorMissing:
+		return nil, nil
+	case definition.BitMapIndicatorSpecified, definition.BitMapIndicatorPreviouslyDefined:
+		bm, err := NewBitmap(m.sec6.Bitmap, int(m.sec3.NumberOfDataPoints))
+		if err != nil {
+			return nil, fmt.Errorf("bit-map: %w", err)
+		}
+
+		return bm, nil
+	}
+
+	return nil, fmt.Errorf("predetermined bit-map is not supported: %d", m.sec6.BitMapIndicator)
+}
+
 func (m *message) Step() int {
 	return m.sec4.GetProductDefinitionTemplate().GetForecast()
 }
@@ -152,36 +435,48 @@ func (m *message) GetDataRepresentationTemplate() drt.Template {
 }
 
 func (m *message) GetGridPointLL(n int) (float32, float32, bool) {
-	tpl := m.sec3.GetGridDefinitionTemplate()
+	tpl := m.GetGridDefinitionTemplate()
 	return tpl.GetGridPoint(n)
 }
 
 func (m *message) GetGridPointFromLL(lat float32, lon float32) int {
-	tpl := m.sec3.GetGridDefinitionTemplate()
+	tpl := m.GetGridDefinitionTemplate()
 	return tpl.GetGridIndex(lat, lon)
 }
 
 func (m *message) GetNi() int {
-	return int(m.sec3.GridDefinitionTemplate.GetNi())
+	return int(m.GetGridDefinitionTemplate().GetNi())
 }
 
 func (m *message) GetNj() int {
-	return int(m.sec3.GridDefinitionTemplate.GetNj())
+	return int(m.GetGridDefinitionTemplate().GetNj())
 }
 
 func (m *message) GetOffset() int64 {
 	return m.offset
 }
 
+// GetSubIndex returns the index of the field within its message, 0 for single-field messages.
+func (m *message) GetSubIndex() int {
+	return m.subIndex
+}
+
 func (m *message) GetDataOffset() int64 {
 	return m.sec7.GetDataOffset()
 }
 
+// GetSize returns the size of the whole message, which is shared by all its fields.
 func (m *message) GetSize() int64 {
 	return int64(m.sec0.GribLength)
 }
 
+// GetGridDefinitionTemplate returns the grid of the values, for spectral data the grid the coefficients
+// are synthesized onto, see gdt.Template50.WithGrid.
 func (m *message) GetGridDefinitionTemplate() gdt.Template {
+	if m.grid != nil {
+		return m.grid
+	}
+
 	return m.sec3.GridDefinitionTemplate
 }
 
@@ -200,7 +495,12 @@ func (m *message) assignSection(sec Section) error {
 	case 5:
 		m.sec5 = sec.(*section5)
 	case 6:
-		m.sec6 = sec.(*section6)
+		sec6 := sec.(*section6)
+		if err := sec6.inherit(m.sec6); err != nil {
+			return err
+		}
+
+		m.sec6 = sec6
 	case 7:
 		m.sec7 = sec.(*section7)
 	case 8:
@@ -211,13 +511,46 @@ func (m *message) assignSection(sec Section) error {
 	return nil
 }
 
-func (m *message) DumpMessageIndex() (*MessageIndex, error) {
+// DumpMessageIndex returns the index of the headers of m, the data of section 7 is only read for the
+// options which need it.
+func (m *message) DumpMessageIndex(opts ...MessageIndexOption) (*MessageIndex, error) {
+	var o messageIndexOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	bm, err := m.GetBitmap()
+	if err != nil {
+		return nil, err
+	}
+
+	var groups *gridpoint.GroupDirectory
+
+	switch tpl := m.GetDataRepresentationTemplate().(type) {
+	case *gridpoint.ComplexPacking, *gridpoint.ComplexPackingAndSpatialDifferencing:
+		if !o.groups {
+			break
+		}
+
+		if err := m.sec7.LoadData(); err != nil {
+			return nil, fmt.Errorf("load data from section 7: %w", err)
+		}
+
+		groups, err = groupDirectory(tpl, m.sec7.Data)
+		if err != nil {
+			return nil, fmt.Errorf("read group directory: %w", err)
+		}
+	}
+
 	return &MessageIndex{
 		Offset:         m.offset,
+		SubIndex:       m.subIndex,
 		Size:           m.GetSize(),
 		DataOffset:     m.GetDataOffset(),
 		GridDefinition: m.GetGridDefinitionTemplate(),
 		Packing:        m.GetDataRepresentationTemplate(),
+		Bitmap:         bm,
+		Groups:         groups,
 	}, nil
 }
 
@@ -229,92 +562,467 @@ func (m *message) Image() (image.Image, error) {
 		if err := m.sec7.LoadData(); err != nil {
 			return nil, fmt.Errorf("load data from section 7: %w", err)
 		}
-		return t.Image(bitio.NewReader(bytes.NewReader(m.sec7.Data)))
+
+		img, err := t.Image(bitio.NewReader(bytes.NewReader(m.sec7.Data)))
+		if err != nil {
+			return nil, err
+		}
+
+		bm, err := m.GetBitmap()
+		if err != nil {
+			return nil, err
+		}
+
+		if bm == nil {
+			return img, nil
+		}
+
+		return expandImage(img, bm, m.GetNi(), m.GetNj())
 	default:
 		return nil, fmt.Errorf("data is not an image: %T", tpl)
 	}
 }
 
+// expandImage places the pixels of a bit-mapped image on the full ni x nj grid,
+// pixels of missing grid points are left transparent.
+func expandImage(img image.Image, bm *Bitmap, ni int, nj int) (image.Image, error) {
+	if ni*nj != bm.Len() {
+		return nil, fmt.Errorf("bit-map of %d points does not match grid %dx%d", bm.Len(), ni, nj)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx()*bounds.Dy() < bm.Count() {
+		return nil, fmt.Errorf("image has %d pixels, bit-map expects %d", bounds.Dx()*bounds.Dy(), bm.Count())
+	}
+
+	expanded := image.NewNRGBA64(image.Rect(0, 0, ni, nj))
+	idx := 0
+
+	for n := 0; n < bm.Len(); n++ {
+		if !bm.IsSet(n) {
+			continue
+		}
+
+		x, y := bounds.Min.X+idx%bounds.Dx(), bounds.Min.Y+idx/bounds.Dx()
+		expanded.Set(n%ni, n/ni, color.NRGBA64Model.Convert(img.At(x, y)))
+		idx++
+	}
+
+	return expanded, nil
+}
+
 type MessageReader interface {
 	ReadLL(ctx context.Context, lat float32, lon float32) (float32, float32, float32, error)
 	GetGridIndex(lat float32, lon float32) int
 	GetGridPoint(n int) (float32, float32, bool)
 }
 
-type simplePackingMessageReader struct {
-	sp    *gridpoint.SimplePacking
-	spr   *gridpoint.SimplePackingReader
-	gdt   gdt.Template
-	cache cache.GridCache
+// NewMessageReaderFromMessage returns the reader of the data representation template of m. The reader of
+// complex packing reads and decodes the whole section 7 at the first read of a grid point to build the
+// group directory, use NewMessageReaderFromMessageIndex with an index dumped WithGroupDirectory to only
+// read the group of each grid point.
+func NewMessageReaderFromMessage(r io.ReaderAt, m IndexedMessage, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	switch m.GetDataRepresentationTemplate().(type) {
+	case *gridpoint.SimplePacking, *gridpoint.SimplePackingWithLogPreProcessing:
+		return NewSimplePackingMessageReaderFromMessage(r, m, opts...)
+	case *gridpoint.ComplexPacking, *gridpoint.ComplexPackingAndSpatialDifferencing:
+		return NewComplexPackingMessageReaderFromMessage(r, m, opts...)
+	case *gridpoint.PortableNetworkGraphics:
+		return NewPortableNetworkGraphicsMessageReaderFromMessage(r, m, opts...)
+	case *gridpoint.IEEEFloat:
+		return NewIEEEFloatMessageReaderFromMessage(r, m, opts...)
+	case *spectral.SimplePacking, *spectral.ComplexPacking:
+		return NewSpectralMessageReaderFromMessage(r, m, opts...)
+	}
+
+	return nil, fmt.Errorf("unsupported data representation template: %T", m.GetDataRepresentationTemplate())
 }
 
-func NewSimplePackingMessageReaderFromMessage(r io.ReaderAt, m IndexedMessage, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
-	sp, ok := m.GetDataRepresentationTemplate().(*gridpoint.SimplePacking)
-	if !ok {
-		return nil, fmt.Errorf("unsupported data representation template: %T", m.GetDataRepresentationTemplate())
+// NewMessageReaderFromMessageIndex returns the reader of the packing of mi.
+func NewMessageReaderFromMessageIndex(r io.ReaderAt, mi *MessageIndex, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	switch mi.Packing.(type) {
+	case *gridpoint.SimplePacking, *gridpoint.SimplePackingWithLogPreProcessing:
+		return NewSimplePackingMessageReaderFromMessageIndex(r, mi, opts...)
+	case *gridpoint.ComplexPacking, *gridpoint.ComplexPackingAndSpatialDifferencing:
+		return NewComplexPackingMessageReaderFromMessageIndex(r, mi, opts...)
+	case *gridpoint.PortableNetworkGraphics:
+		return NewPortableNetworkGraphicsMessageReaderFromMessageIndex(r, mi, opts...)
+	case *gridpoint.IEEEFloat:
+		return NewIEEEFloatMessageReaderFromMessageIndex(r, mi, opts...)
+	case *spectral.SimplePacking, *spectral.ComplexPacking:
+		return NewSpectralMessageReaderFromMessageIndex(r, mi, opts...)
 	}
 
+	return nil, fmt.Errorf("unsupported packing: %T", mi.Packing)
+}
+
+type messageReader struct {
+	gdt          gdt.Template
+	cache        cache.GridCache
+	newCache     func(datasource cache.GridDataSource) cache.GridCache
+	bitmap       *Bitmap
+	missingValue float32
+}
+
+func NewSimplePackingMessageReaderFromMessage(r io.ReaderAt, m IndexedMessage, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
 	gdt := m.GetGridDefinitionTemplate()
 
-	return NewSimplePackingMessageReader(r, m.GetOffset(), m.GetSize(), m.GetDataOffset(), sp, gdt, opts...)
+	bm, err := m.GetBitmap()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]SimplePackingMessageReaderOptions{WithBitmap(bm)}, opts...)
+
+	switch sp := m.GetDataRepresentationTemplate().(type) {
+	case *gridpoint.SimplePacking:
+		return NewSimplePackingMessageReader(r, m.GetOffset(), m.GetSize(), m.GetDataOffset(), sp, gdt, opts...)
+	case *gridpoint.SimplePackingWithLogPreProcessing:
+		return NewSimplePackingWithLogPreProcessingMessageReader(r, m.GetOffset(), m.GetSize(), m.GetDataOffset(), sp, gdt, opts...)
+	}
+
+	return nil, fmt.Errorf("unsupported data representation template: %T", m.GetDataRepresentationTemplate())
 }
 
-type SimplePackingMessageReaderOptions func(r *simplePackingMessageReader)
+type SimplePackingMessageReaderOptions func(r *messageReader)
 
 func WithBoundaryCache(minLat, maxLat, minLon, maxLon float32, newStore func() cache.Store) SimplePackingMessageReaderOptions {
-	return func(r *simplePackingMessageReader) {
-		r.cache = cache.NewBoundary(minLat, maxLat, minLon, maxLon, r.spr, newStore())
+	return func(r *messageReader) {
+		r.newCache = func(datasource cache.GridDataSource) cache.GridCache {
+			return cache.NewBoundary(minLat, maxLat, minLon, maxLon, datasource, newStore())
+		}
 	}
 }
 
 func WithCustomCacheStrategy(inCache func(lat, lon float32) bool, newStore func() cache.Store) SimplePackingMessageReaderOptions {
-	return func(r *simplePackingMessageReader) {
-		r.cache = cache.NewCustom(inCache, r.spr, newStore())
+	return func(r *messageReader) {
+		r.newCache = func(datasource cache.GridDataSource) cache.GridCache {
+			return cache.NewCustom(inCache, datasource, newStore())
+		}
+	}
+}
+
+// WithBitmap resolves grid points through the bit-map of section 6, a nil bit-map is ignored.
+func WithBitmap(bm *Bitmap) SimplePackingMessageReaderOptions {
+	return func(r *messageReader) {
+		r.bitmap = bm
+	}
+}
+
+// WithReaderMissingValue sets the value returned for grid points that are missing in the bit-map, NaN by default.
+func WithReaderMissingValue(v float32) SimplePackingMessageReaderOptions {
+	return func(r *messageReader) {
+		r.missingValue = v
 	}
 }
 
 func NewSimplePackingMessageReader(r io.ReaderAt, messageOffset int64, messageSize int64, dataOffset int64, sp *gridpoint.SimplePacking, gdt gdt.Template, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
 	spr := gridpoint.NewSimplePackingReader(r, dataOffset, messageOffset+messageSize, sp)
 
-	mr := &simplePackingMessageReader{
-		spr:   spr,
-		sp:    sp,
-		gdt:   gdt,
-		cache: cache.NewNoCache(spr),
+	return newMessageReader(spr, sp.NumVals, gdt, opts...)
+}
+
+func NewSimplePackingWithLogPreProcessingMessageReader(r io.ReaderAt, messageOffset int64, messageSize int64, dataOffset int64, sp *gridpoint.SimplePackingWithLogPreProcessing, gdt gdt.Template, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	spr := gridpoint.NewSimplePackingWithLogPreProcessingReader(r, dataOffset, messageOffset+messageSize, sp)
+
+	return newMessageReader(spr, sp.NumVals, gdt, opts...)
+}
+
+// newMessageReader reads the numVals packed values of datasource at the grid points of gdt.
+func newMessageReader(datasource cache.GridDataSource, numVals int, gdt gdt.Template, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	mr := &messageReader{
+		gdt:          gdt,
+		newCache:     cache.NewNoCache,
+		missingValue: float32(math.NaN()),
 	}
 
 	for _, opt := range opts {
 		opt(mr)
 	}
 
+	if mr.bitmap != nil {
+		if mr.bitmap.Count() != numVals {
+			return nil, fmt.Errorf("bit-map expects %d values, packing has %d", mr.bitmap.Count(), numVals)
+		}
+
+		datasource = &bitmapDataSource{
+			bitmap:     mr.bitmap,
+			datasource: datasource,
+			missing:    mr.missingValue,
+		}
+	}
+
+	mr.cache = mr.newCache(datasource)
+
 	return mr, nil
 }
 
 func NewSimplePackingMessageReaderFromMessageIndex(r io.ReaderAt, mi *MessageIndex, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
-	sp, ok := mi.Packing.(*gridpoint.SimplePacking)
+	opts = append([]SimplePackingMessageReaderOptions{WithBitmap(mi.Bitmap)}, opts...)
+
+	switch sp := mi.Packing.(type) {
+	case *gridpoint.SimplePacking:
+		return NewSimplePackingMessageReader(r, mi.Offset, mi.Size, mi.DataOffset, sp, mi.GridDefinition, opts...)
+	case *gridpoint.SimplePackingWithLogPreProcessing:
+		return NewSimplePackingWithLogPreProcessingMessageReader(r, mi.Offset, mi.Size, mi.DataOffset, sp, mi.GridDefinition, opts...)
+	}
+
+	return nil, fmt.Errorf("unsupported packing: %T", mi.Packing)
+}
+
+// NewComplexPackingMessageReaderFromMessage reads grid points of complex packing, the group
+// directory is built from the data of m at the first read of a grid point.
+// NewComplexPackingMessageReaderFromMessage reads grid points of complex packing, the whole section 7 is
+// read and decoded at the first read of a grid point to build the group directory.
+func NewComplexPackingMessageReaderFromMessage(r io.ReaderAt, m IndexedMessage, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	mi, err := m.DumpMessageIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewComplexPackingMessageReaderFromMessageIndex(r, mi, opts...)
+}
+
+// NewComplexPackingMessageReaderFromMessageIndex reads grid points of complex packing with the group
+// directory of mi, which is built from the data of section 7 at the first read of a grid point if
+// missing, see WithGroupDirectory.
+func NewComplexPackingMessageReaderFromMessageIndex(r io.ReaderAt, mi *MessageIndex, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	opts = append([]SimplePackingMessageReaderOptions{WithBitmap(mi.Bitmap)}, opts...)
+
+	if mi.Groups != nil {
+		return NewComplexPackingMessageReader(r, mi.Offset, mi.Size, mi.DataOffset, mi.Packing, mi.Groups, mi.GridDefinition, opts...)
+	}
+
+	cp, err := complexPacking(mi.Packing)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := &lazyDataSource{
+		load: func() (cache.GridDataSource, error) {
+			data := make([]byte, mi.Offset+mi.Size-mi.DataOffset)
+			if _, err := r.ReadAt(data, mi.DataOffset); err != nil {
+				return nil, fmt.Errorf("read data at offset %d: %w", mi.DataOffset, err)
+			}
+
+			dir, err := groupDirectory(mi.Packing, data)
+			if err != nil {
+				return nil, err
+			}
+
+			return gridpoint.NewComplexPackingReader(r, mi.DataOffset, mi.Offset+mi.Size, cp, dir)
+		},
+	}
+
+	return newMessageReader(ds, cp.NumVals, mi.GridDefinition, opts...)
+}
+
+// NewComplexPackingMessageReader reads grid points of complex packing, with or without spatial
+// differencing, located by dir.
+func NewComplexPackingMessageReader(r io.ReaderAt, messageOffset int64, messageSize int64, dataOffset int64, packing drt.Template, dir *gridpoint.GroupDirectory, gdt gdt.Template, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	cp, err := complexPacking(packing)
+	if err != nil {
+		return nil, err
+	}
+
+	cpr, err := gridpoint.NewComplexPackingReader(r, dataOffset, messageOffset+messageSize, cp, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMessageReader(cpr, cp.NumVals, gdt, opts...)
+}
+
+// complexPacking returns the complex packing of packing, with or without spatial differencing.
+func complexPacking(packing drt.Template) (*gridpoint.ComplexPacking, error) {
+	switch tpl := packing.(type) {
+	case *gridpoint.ComplexPacking:
+		return tpl, nil
+	case *gridpoint.ComplexPackingAndSpatialDifferencing:
+		return tpl.ComplexPacking, nil
+	}
+
+	return nil, fmt.Errorf("unsupported packing: %T", packing)
+}
+
+func NewSpectralMessageReaderFromMessage(r io.ReaderAt, m IndexedMessage, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	mi, err := m.DumpMessageIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSpectralMessageReaderFromMessageIndex(r, mi, opts...)
+}
+
+// NewSpectralMessageReaderFromMessageIndex reads the grid point values of spectral data at the points of
+// the synthesis grid of mi, all the coefficients are read and synthesized at the first read of a grid point.
+func NewSpectralMessageReaderFromMessageIndex(r io.ReaderAt, mi *MessageIndex, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	tpl, ok := mi.GridDefinition.(*gdt.Template50)
+	if !ok || tpl.Grid == nil {
+		return nil, fmt.Errorf("spectral data are not synthesized onto a grid: %T", mi.GridDefinition)
+	}
+
+	grid := spectral.Grid{Latitudes: tpl.Grid.Latitudes, Ni: int(tpl.Grid.Ni)}
+
+	ds := &lazyDataSource{
+		load: func() (cache.GridDataSource, error) {
+			data := make([]byte, mi.Offset+mi.Size-mi.DataOffset)
+			if _, err := r.ReadAt(data, mi.DataOffset); err != nil {
+				return nil, fmt.Errorf("read data at offset %d: %w", mi.DataOffset, err)
+			}
+
+			coefficients, err := mi.Packing.ReadAllData(bitio.NewReader(bytes.NewReader(data)))
+			if err != nil {
+				return nil, fmt.Errorf("read coefficients using template %T: %w", mi.Packing, err)
+			}
+
+			values, err := spectral.Synthesize(coefficients, grid)
+			if err != nil {
+				return nil, fmt.Errorf("synthesize spectral data: %w", err)
+			}
+
+			return valuesDataSource(values), nil
+		},
+	}
+
+	return newMessageReader(ds, grid.Len(), mi.GridDefinition, opts...)
+}
+
+// valuesDataSource reads grid points from the decoded values.
+type valuesDataSource []float32
+
+func (ds valuesDataSource) ReadGridAt(ctx context.Context, grid int) (float32, error) {
+	if grid < 0 || grid >= len(ds) {
+		return 0, fmt.Errorf("grid point %d is out of %d values", grid, len(ds))
+	}
+
+	return ds[grid], nil
+}
+
+// lazyDataSource loads its data source at the first read of a grid point.
+type lazyDataSource struct {
+	once sync.Once
+	load func() (cache.GridDataSource, error)
+	ds   cache.GridDataSource
+	err  error
+}
+
+func (ds *lazyDataSource) ReadGridAt(ctx context.Context, grid int) (float32, error) {
+	ds.once.Do(func() {
+		ds.ds, ds.err = ds.load()
+	})
+
+	if ds.err != nil {
+		return 0, ds.err
+	}
+
+	return ds.ds.ReadGridAt(ctx, grid)
+}
+
+func NewPortableNetworkGraphicsMessageReaderFromMessage(r io.ReaderAt, m IndexedMessage, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	p, ok := m.GetDataRepresentationTemplate().(*gridpoint.PortableNetworkGraphics)
+	if !ok {
+		return nil, fmt.Errorf("unsupported data representation template: %T", m.GetDataRepresentationTemplate())
+	}
+
+	bm, err := m.GetBitmap()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]SimplePackingMessageReaderOptions{WithBitmap(bm)}, opts...)
+
+	return NewPortableNetworkGraphicsMessageReader(r, m.GetOffset(), m.GetSize(), m.GetDataOffset(), p, m.GetGridDefinitionTemplate(), opts...)
+}
+
+func NewPortableNetworkGraphicsMessageReaderFromMessageIndex(r io.ReaderAt, mi *MessageIndex, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	p, ok := mi.Packing.(*gridpoint.PortableNetworkGraphics)
 	if !ok {
 		return nil, fmt.Errorf("unsupported packing: %T", mi.Packing)
 	}
 
-	return NewSimplePackingMessageReader(r, mi.Offset, mi.Size, mi.DataOffset, sp, mi.GridDefinition, opts...)
+	opts = append([]SimplePackingMessageReaderOptions{WithBitmap(mi.Bitmap)}, opts...)
+
+	return NewPortableNetworkGraphicsMessageReader(r, mi.Offset, mi.Size, mi.DataOffset, p, mi.GridDefinition, opts...)
+}
+
+// NewPortableNetworkGraphicsMessageReader reads grid points of PNG packing, the image is decoded
+// at the first read of a grid point that is not cached.
+func NewPortableNetworkGraphicsMessageReader(r io.ReaderAt, messageOffset int64, messageSize int64, dataOffset int64, p *gridpoint.PortableNetworkGraphics, gdt gdt.Template, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	pr := gridpoint.NewPortableNetworkGraphicsReader(r, dataOffset, messageOffset+messageSize, p)
+
+	return newMessageReader(pr, p.NumVals, gdt, opts...)
+}
+
+func NewIEEEFloatMessageReaderFromMessage(r io.ReaderAt, m IndexedMessage, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	f, ok := m.GetDataRepresentationTemplate().(*gridpoint.IEEEFloat)
+	if !ok {
+		return nil, fmt.Errorf("unsupported data representation template: %T", m.GetDataRepresentationTemplate())
+	}
+
+	bm, err := m.GetBitmap()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]SimplePackingMessageReaderOptions{WithBitmap(bm)}, opts...)
+
+	return NewIEEEFloatMessageReader(r, m.GetOffset(), m.GetSize(), m.GetDataOffset(), f, m.GetGridDefinitionTemplate(), opts...)
 }
 
-func (r *simplePackingMessageReader) ReadLL(ctx context.Context, lat float32, lon float32) (float32, float32, float32, error) {
+func NewIEEEFloatMessageReaderFromMessageIndex(r io.ReaderAt, mi *MessageIndex, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	f, ok := mi.Packing.(*gridpoint.IEEEFloat)
+	if !ok {
+		return nil, fmt.Errorf("unsupported packing: %T", mi.Packing)
+	}
+
+	opts = append([]SimplePackingMessageReaderOptions{WithBitmap(mi.Bitmap)}, opts...)
+
+	return NewIEEEFloatMessageReader(r, mi.Offset, mi.Size, mi.DataOffset, f, mi.GridDefinition, opts...)
+}
+
+// NewIEEEFloatMessageReader reads grid points of IEEE floating point data, each value is read at
+// its own offset.
+func NewIEEEFloatMessageReader(r io.ReaderAt, messageOffset int64, messageSize int64, dataOffset int64, f *gridpoint.IEEEFloat, gdt gdt.Template, opts ...SimplePackingMessageReaderOptions) (MessageReader, error) {
+	fr := gridpoint.NewIEEEFloatReader(r, dataOffset, messageOffset+messageSize, f)
+
+	return newMessageReader(fr, f.NumVals, gdt, opts...)
+}
+
+// groupDirectory locates the groups of complex packing in the data of section 7.
+func groupDirectory(packing drt.Template, data []byte) (*gridpoint.GroupDirectory, error) {
+	r := bitio.NewReader(bytes.NewReader(data))
+
+	switch tpl := packing.(type) {
+	case *gridpoint.ComplexPacking:
+		return tpl.GroupDirectory(r)
+	case *gridpoint.ComplexPackingAndSpatialDifferencing:
+		return tpl.GroupDirectory(r)
+	}
+
+	return nil, fmt.Errorf("unsupported packing: %T", packing)
+}
+
+func (r *messageReader) ReadLL(ctx context.Context, lat float32, lon float32) (float32, float32, float32, error) {
 	grid := r.gdt.GetGridIndex(lat, lon)
-	lat, lng, _ := r.gdt.GetGridPoint(grid)
 
-	v, err := r.cache.ReadGridAt(ctx, grid, lat, lng)
+	gridLat, gridLon, ok := r.gdt.GetGridPoint(grid)
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("point (lat: %f, lon: %f) is out of the grid", lat, lon)
+	}
+
+	v, err := r.cache.ReadGridAt(ctx, grid, gridLat, gridLon)
 	if err != nil {
-		return 0, 0, 0, fmt.Errorf("read grid at point %d (lat: %f, lon: %f): %w", grid, lat, lng, err)
+		return 0, 0, 0, fmt.Errorf("read grid at point %d (lat: %f, lon: %f): %w", grid, gridLat, gridLon, err)
 	}
 
-	return lat, lng, v, nil
+	return gridLat, gridLon, v, nil
 }
 
-func (r *simplePackingMessageReader) GetGridIndex(lat float32, lon float32) int {
+func (r *messageReader) GetGridIndex(lat float32, lon float32) int {
 	return r.gdt.GetGridIndex(lat, lon)
 }
 
-func (r *simplePackingMessageReader) GetGridPoint(n int) (float32, float32, bool) {
+func (r *messageReader) GetGridPoint(n int) (float32, float32, bool) {
 	return r.gdt.GetGridPoint(n)
 }
@@ -1,18 +1,25 @@
 package grib2_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"math"
 	"os"
+	"sort"
 	"testing"
 
 	codes "github.com/scorix/go-eccodes"
 	cio "github.com/scorix/go-eccodes/io"
 	"github.com/scorix/grib-go/pkg/grib2"
 	grib "github.com/scorix/grib-go/pkg/grib2"
+	"github.com/scorix/grib-go/pkg/grib2/cache"
 	gridpoint "github.com/scorix/grib-go/pkg/grib2/drt/grid_point"
 	"github.com/scorix/grib-go/pkg/grib2/gdt"
 	"github.com/scorix/grib-go/pkg/grib2/regulation"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/mmap"
 )
@@ -55,7 +62,7 @@ func TestMessageReader_ReadLL(t *testing.T) {
 
 		g := grib.NewGrib2(f)
 
-		msg, err := g.ReadMessageAt(0)
+		msg, err := g.ReadMessageAt(0, 0)
 		require.NoError(t, err)
 		require.NotNil(t, msg)
 
@@ -132,7 +139,7 @@ func TestMessageReader_ReadLL(t *testing.T) {
 
 		g := grib.NewGrib2(f)
 
-		msg, err := g.ReadMessageAt(0)
+		msg, err := g.ReadMessageAt(0, 0)
 		require.NoError(t, err)
 		require.NotNil(t, msg)
 
@@ -173,6 +180,525 @@ func TestMessageReader_ReadLL(t *testing.T) {
 	})
 }
 
+func TestMessage_ReadData_JPEG2000(t *testing.T) {
+	t.Parallel()
+
+	const filename = "../testdata/grid_jpeg.grib2"
+
+	f, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skipf("%s not exist", filename)
+	}
+
+	require.NoError(t, err)
+	defer f.Close()
+
+	cf, err := cio.OpenFile(f.Name(), "r")
+	require.NoError(t, err)
+	defer cf.Close()
+
+	cgrib, err := codes.OpenFile(cf)
+	require.NoError(t, err)
+	defer cgrib.Close()
+
+	handle, err := cgrib.Handle()
+	require.NoError(t, err)
+	defer handle.Close()
+
+	cmsg := handle.Message()
+	defer cmsg.Close()
+
+	iter, err := cmsg.Iterator()
+	require.NoError(t, err)
+	defer iter.Close()
+
+	// eccodes reports the grid points missing in the bit-map as 9999
+	g := grib.NewGrib2(f, grib.WithMissingValue(9999))
+
+	msg, err := g.ReadMessageAt(0, 0)
+	require.NoError(t, err)
+
+	values, err := msg.ReadData()
+	require.NoError(t, err)
+
+	i := 0
+	for ; iter.HasNext(); i++ {
+		lat, lng, val, _ := iter.Next()
+		require.InDeltaf(t, float32(val), values[i], 1e-4, "grid point %d (%f,%f)", i, lat, lng)
+	}
+
+	require.Equal(t, len(values), i)
+}
+
+func TestMessageReader_ReadLL_LogPreProcessing(t *testing.T) {
+	t.Parallel()
+
+	data := testMessage(testField{
+		ni:              3,
+		nj:              2,
+		bitMapIndicator: 255,
+		values:          []uint8{0, 1, 2, 3, 4, 5},
+		// X = x / 2, Y = exp(X) - 0.25
+		representation: representationSection(6, 61, float32(0), uint16(0x8001), uint16(0), uint8(8), float32(0.25)),
+	})
+	r := bytes.NewReader(data)
+
+	msg, err := grib2.NewGrib2(r).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	require.IsType(t, &gridpoint.SimplePackingWithLogPreProcessing{}, msg.GetDataRepresentationTemplate())
+	assert.Equal(t, float32(0.25), msg.GetDataRepresentationTemplate().(*gridpoint.SimplePackingWithLogPreProcessing).PreProcessingParameter)
+
+	// exp(x / 2) - 0.25
+	want := []float32{0.75, 1.3987213, 2.4682817, 4.2316890, 7.1390561, 11.932494}
+
+	values, err := msg.ReadData()
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, want, values, 1e-6)
+
+	mi, err := msg.DumpMessageIndex()
+	require.NoError(t, err)
+
+	bs, err := json.Marshal(mi)
+	require.NoError(t, err)
+
+	var restored grib2.MessageIndex
+	require.NoError(t, json.Unmarshal(bs, &restored))
+
+	fromMessage, err := grib2.NewSimplePackingMessageReaderFromMessage(r, msg)
+	require.NoError(t, err)
+
+	fromIndex, err := grib2.NewSimplePackingMessageReaderFromMessageIndex(r, &restored)
+	require.NoError(t, err)
+
+	for _, reader := range []grib2.MessageReader{fromMessage, fromIndex} {
+		for i, v := range want {
+			lat, lon, _ := reader.GetGridPoint(i)
+
+			_, _, got, err := reader.ReadLL(context.TODO(), lat, lon)
+			require.NoError(t, err)
+			assert.InDelta(t, v, got, 1e-6, "grid point %d", i)
+		}
+	}
+}
+
+func TestMessageReader_ReadLL_ComplexPacking(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filename string
+		packing  any
+	}{
+		{filename: "../testdata/grid_complex.grib2", packing: &gridpoint.ComplexPacking{}},
+		{filename: "../testdata/hpbl.grib2", packing: &gridpoint.ComplexPackingAndSpatialDifferencing{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(tt.filename)
+			require.NoError(t, err)
+			defer f.Close()
+
+			msg, err := grib2.NewGrib2(f).ReadMessageAt(0, 0)
+			require.NoError(t, err)
+			require.IsType(t, tt.packing, msg.GetDataRepresentationTemplate())
+
+			// the index is limited to the headers by default
+			headers, err := msg.DumpMessageIndex()
+			require.NoError(t, err)
+			require.Nil(t, headers.Groups)
+
+			want, err := msg.ReadData()
+			require.NoError(t, err)
+
+			mi, err := msg.DumpMessageIndex(grib2.WithGroupDirectory())
+			require.NoError(t, err)
+			require.NotNil(t, mi.Groups)
+
+			bs, err := json.Marshal(mi)
+			require.NoError(t, err)
+
+			var restored grib2.MessageIndex
+			require.NoError(t, json.Unmarshal(bs, &restored))
+			require.Equal(t, mi.Groups, restored.Groups)
+
+			fromMessage, err := grib2.NewComplexPackingMessageReaderFromMessage(f, msg)
+			require.NoError(t, err)
+
+			fromIndex, err := grib2.NewComplexPackingMessageReaderFromMessageIndex(f, &restored)
+			require.NoError(t, err)
+
+			// the group directory is rebuilt from section 7 without the index
+			restored.Groups = nil
+			fromData, err := grib2.NewComplexPackingMessageReaderFromMessageIndex(f, &restored)
+			require.NoError(t, err)
+
+			for _, reader := range []grib2.MessageReader{fromMessage, fromIndex, fromData} {
+				for i := 0; i < len(want); i += 97 {
+					lat, lon, _ := reader.GetGridPoint(i)
+
+					_, _, got, err := reader.ReadLL(context.TODO(), lat, lon)
+					require.NoError(t, err)
+
+					if math.IsNaN(float64(want[i])) {
+						assert.True(t, math.IsNaN(float64(got)), "grid point %d", i)
+					} else {
+						assert.Equal(t, want[i], got, "grid point %d", i)
+					}
+				}
+
+				lat, lon, _ := reader.GetGridPoint(len(want) - 1)
+
+				_, _, got, err := reader.ReadLL(context.TODO(), lat, lon)
+				require.NoError(t, err)
+				assert.Equal(t, want[len(want)-1], got)
+			}
+		})
+	}
+}
+
+// rangeRecorder records the ranges of bytes read from an io.ReaderAt.
+type rangeRecorder struct {
+	io.ReaderAt
+	ranges [][2]int64
+}
+
+func (r *rangeRecorder) ReadAt(p []byte, off int64) (int, error) {
+	r.ranges = append(r.ranges, [2]int64{off, off + int64(len(p))})
+	return r.ReaderAt.ReadAt(p, off)
+}
+
+func TestMessageReader_ReadLL_GroupDirectory(t *testing.T) {
+	t.Parallel()
+
+	for _, filename := range []string{"../testdata/grid_complex.grib2", "../testdata/hpbl.grib2"} {
+		t.Run(filename, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(filename)
+			require.NoError(t, err)
+			defer f.Close()
+
+			msg, err := grib2.NewGrib2(f).ReadMessageAt(0, 0)
+			require.NoError(t, err)
+
+			want, err := msg.ReadData()
+			require.NoError(t, err)
+
+			mi, err := msg.DumpMessageIndex(grib2.WithGroupDirectory())
+			require.NoError(t, err)
+			require.Nil(t, mi.Bitmap)
+
+			bs, err := json.Marshal(mi)
+			require.NoError(t, err)
+
+			var restored grib2.MessageIndex
+			require.NoError(t, json.Unmarshal(bs, &restored))
+
+			// first grid point of each group
+			dir := restored.Groups
+			starts := make([]int, len(dir.Lengths)+1)
+			for k, l := range dir.Lengths {
+				starts[k+1] = starts[k] + int(l)
+			}
+
+			for _, n := range []int{0, 1, len(want) / 3, len(want) / 2, len(want) - 1} {
+				rec := &rangeRecorder{ReaderAt: f}
+
+				reader, err := grib2.NewMessageReaderFromMessageIndex(rec, &restored)
+				require.NoError(t, err)
+
+				lat, lon, _ := reader.GetGridPoint(n)
+
+				_, _, got, err := reader.ReadLL(context.TODO(), lat, lon)
+				require.NoError(t, err)
+				assert.Equal(t, want[n], got, "grid point %d", n)
+
+				// only the packed data of the group of the grid point is read
+				k := sort.SearchInts(starts, n+1) - 1
+				first := restored.DataOffset + int64(dir.Offsets[k]/8)
+				last := restored.DataOffset + int64((dir.Offsets[k]+uint64(dir.Widths[k])*uint64(dir.Lengths[k])+7)/8)
+
+				for _, r := range rec.ranges {
+					assert.GreaterOrEqual(t, r[0], first, "grid point %d in group %d", n, k)
+					assert.LessOrEqual(t, r[1], last, "grid point %d in group %d", n, k)
+				}
+			}
+		})
+	}
+}
+
+func TestMessageReader_ReadLL_PNG(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../testdata/grid_png.grib2")
+	require.NoError(t, err)
+	defer f.Close()
+
+	msg, err := grib2.NewGrib2(f).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	require.IsType(t, &gridpoint.PortableNetworkGraphics{}, msg.GetDataRepresentationTemplate())
+
+	want, err := msg.ReadData()
+	require.NoError(t, err)
+
+	mi, err := msg.DumpMessageIndex()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		opts []grib2.SimplePackingMessageReaderOptions
+	}{
+		{name: "no cache"},
+		{
+			name: "boundary cache",
+			opts: []grib2.SimplePackingMessageReaderOptions{
+				grib2.WithBoundaryCache(-30, 30, 0, 180, func() cache.Store { return cache.NewMapStore() }),
+			},
+		},
+		{
+			name: "custom cache",
+			opts: []grib2.SimplePackingMessageReaderOptions{
+				grib2.WithCustomCacheStrategy(func(lat, lon float32) bool { return lat > 0 }, func() cache.Store { return cache.NewLRUStore(1000) }),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fromMessage, err := grib2.NewMessageReaderFromMessage(f, msg, tt.opts...)
+			require.NoError(t, err)
+
+			fromIndex, err := grib2.NewMessageReaderFromMessageIndex(f, mi, tt.opts...)
+			require.NoError(t, err)
+
+			for _, reader := range []grib2.MessageReader{fromMessage, fromIndex} {
+				for i := 0; i < len(want); i += 7 {
+					lat, lon, _ := reader.GetGridPoint(i)
+
+					_, _, got, err := reader.ReadLL(context.TODO(), lat, lon)
+					require.NoError(t, err)
+					assert.Equal(t, want[i], got, "grid point %d", i)
+				}
+			}
+		})
+	}
+}
+
+func TestMessageReader_ReadLL_IEEEFloat(t *testing.T) {
+	t.Parallel()
+
+	want := []float32{-1.5, 0, 2.25, 101325, 273.15, float32(math.Inf(1))}
+
+	tests := []struct {
+		name      string
+		precision uint8
+		data      []byte
+	}{
+		{name: "32 bits", precision: 1, data: fields(want)},
+		{name: "64 bits", precision: 2, data: fields([]float64{-1.5, 0, 2.25, 101325, 273.15, math.Inf(1)})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := testMessage(testField{
+				ni:              3,
+				nj:              2,
+				bitMapIndicator: 255,
+				values:          tt.data,
+				representation:  representationSection(6, 4, tt.precision),
+			})
+			r := bytes.NewReader(data)
+
+			msg, err := grib2.NewGrib2(r).ReadMessageAt(0, 0)
+			require.NoError(t, err)
+			require.IsType(t, &gridpoint.IEEEFloat{}, msg.GetDataRepresentationTemplate())
+
+			mi, err := msg.DumpMessageIndex()
+			require.NoError(t, err)
+
+			bs, err := json.Marshal(mi)
+			require.NoError(t, err)
+
+			var restored grib2.MessageIndex
+			require.NoError(t, json.Unmarshal(bs, &restored))
+
+			fromMessage, err := grib2.NewMessageReaderFromMessage(r, msg)
+			require.NoError(t, err)
+
+			fromIndex, err := grib2.NewMessageReaderFromMessageIndex(r, &restored)
+			require.NoError(t, err)
+
+			for _, reader := range []grib2.MessageReader{fromMessage, fromIndex} {
+				for i, v := range want {
+					lat, lon, _ := reader.GetGridPoint(i)
+
+					_, _, got, err := reader.ReadLL(context.TODO(), lat, lon)
+					require.NoError(t, err)
+					assert.Equal(t, v, got, "grid point %d", i)
+				}
+			}
+		})
+	}
+}
+
+func TestMessageReader_ReadLL_LambertConformal(t *testing.T) {
+	t.Parallel()
+
+	// 4 x 3 points of 3 km on the HRRR projection
+	values := []uint8{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	data := testMessage(testField{
+		bitMapIndicator: 255,
+		values:          values,
+		grid: gridSection(12, 30,
+			uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0),
+			uint32(4), uint32(3), uint32(38500000), uint32(262500000), uint8(8),
+			uint32(38500000), uint32(262500000), uint32(3000000), uint32(3000000),
+			uint8(0), uint8(0x40), uint32(38500000), uint32(38500000),
+			uint32(0x80000000|90000000), uint32(0),
+		),
+	})
+	r := bytes.NewReader(data)
+
+	msg, err := grib2.NewGrib2(r).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template30{}, msg.GetGridDefinitionTemplate())
+
+	reader, err := grib2.NewMessageReaderFromMessage(r, msg)
+	require.NoError(t, err)
+
+	for i, v := range values {
+		lat, lon, ok := reader.GetGridPoint(i)
+		require.True(t, ok)
+
+		_, _, got, err := reader.ReadLL(context.TODO(), lat, lon)
+		require.NoError(t, err)
+		assert.Equal(t, float32(v), got, "grid point %d", i)
+	}
+
+	// the first grid point is at the tangent latitude on LoV, the second one 3 km east
+	lat, lon, _ := reader.GetGridPoint(1)
+	assert.InDelta(t, 38.5, lat, 1e-3)
+	assert.InDelta(t, 262.5+3/(111.2*math.Cos(38.5*math.Pi/180)), lon, 1e-3)
+
+	_, _, _, err = reader.ReadLL(context.TODO(), 0, 0)
+	assert.Error(t, err)
+}
+
+func TestMessageReader_ReadLL_Mercator(t *testing.T) {
+	t.Parallel()
+
+	// 4 x 3 points of 20 km from 0N 350E, true at 20N
+	values := []uint8{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	data := testMessage(testField{
+		bitMapIndicator: 255,
+		values:          values,
+		grid: gridSection(12, 10,
+			uint8(6), uint8(0), uint32(0), uint8(0), uint32(0), uint8(0), uint32(0),
+			uint32(4), uint32(3), uint32(0), uint32(350000000), uint8(48),
+			uint32(20000000), uint32(320000), uint32(350570000),
+			uint8(0x40), uint32(0), uint32(20000000), uint32(20000000),
+		),
+	})
+	r := bytes.NewReader(data)
+
+	msg, err := grib2.NewGrib2(r).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template10{}, msg.GetGridDefinitionTemplate())
+
+	mi, err := msg.DumpMessageIndex()
+	require.NoError(t, err)
+
+	bs, err := json.Marshal(mi)
+	require.NoError(t, err)
+
+	var restored grib2.MessageIndex
+	require.NoError(t, json.Unmarshal(bs, &restored))
+	require.IsType(t, &gdt.Template10{}, restored.GridDefinition)
+
+	reader, err := grib2.NewMessageReaderFromMessageIndex(r, &restored)
+	require.NoError(t, err)
+
+	for i, v := range values {
+		lat, lon, ok := msg.GetGridDefinitionTemplate().GetGridPoint(i)
+		require.True(t, ok)
+
+		gotLat, gotLon, got, err := reader.ReadLL(context.TODO(), lat, lon)
+		require.NoError(t, err)
+		assert.Equal(t, float32(v), got, "grid point %d", i)
+		assert.Equal(t, lat, gotLat)
+		assert.Equal(t, lon, gotLon)
+	}
+
+	// 20 km at 20N is 0.19 degrees of longitude
+	_, lon, _ := reader.GetGridPoint(1)
+	assert.InDelta(t, 350+20/(111.2*math.Cos(20*math.Pi/180)), lon, 1e-3)
+}
+
+func TestMessageReader_ReadLL_QuasiRegular(t *testing.T) {
+	t.Parallel()
+
+	// octahedral reduced Gaussian grid O2
+	values := make([]uint8, 88)
+	for i := range values {
+		values[i] = uint8(i)
+	}
+
+	data := testMessage(testField{
+		bitMapIndicator: 255,
+		values:          values,
+		// the numbers of points of the full parallels in 2 octets follow the template
+		grid: fields(
+			uint8(0), uint32(88), uint8(2), uint8(1), uint16(40),
+			uint8(6), uint8(0xff), uint32(math.MaxUint32), uint8(0xff), uint32(math.MaxUint32), uint8(0xff), uint32(math.MaxUint32),
+			uint32(math.MaxUint32), uint32(4), uint32(0), uint32(math.MaxUint32),
+			uint32(59444410), uint32(0), uint8(48), uint32(0x80000000|59444410), uint32(345000000),
+			uint32(math.MaxUint32), uint32(2), uint8(0),
+			[]uint16{20, 24, 24, 20},
+		),
+	})
+	r := bytes.NewReader(data)
+
+	msg, err := grib2.NewGrib2(r).ReadMessageAt(0, 0)
+	require.NoError(t, err)
+	require.IsType(t, &gdt.Template40{}, msg.GetGridDefinitionTemplate())
+	assert.Equal(t, []int32{20, 24, 24, 20}, msg.GetGridDefinitionTemplate().(*gdt.Template40).PointsPerRow)
+
+	mi, err := msg.DumpMessageIndex()
+	require.NoError(t, err)
+
+	bs, err := json.Marshal(mi)
+	require.NoError(t, err)
+
+	var restored grib2.MessageIndex
+	require.NoError(t, json.Unmarshal(bs, &restored))
+
+	fromMessage, err := grib2.NewMessageReaderFromMessage(r, msg)
+	require.NoError(t, err)
+
+	fromIndex, err := grib2.NewMessageReaderFromMessageIndex(r, &restored)
+	require.NoError(t, err)
+
+	for _, reader := range []grib2.MessageReader{fromMessage, fromIndex} {
+		for i, v := range values {
+			lat, lon, ok := reader.GetGridPoint(i)
+			require.True(t, ok)
+
+			_, _, got, err := reader.ReadLL(context.TODO(), lat, lon)
+			require.NoError(t, err)
+			assert.Equal(t, float32(v), got, "grid point %d", i)
+		}
+
+		// second point of the second row
+		_, lon, _, err := reader.ReadLL(context.TODO(), 20, 16)
+		require.NoError(t, err)
+		assert.Equal(t, float32(15), lon)
+	}
+}
+
 func TestMessage_DumpMessageIndex(t *testing.T) {
 	t.Parallel()
 
@@ -190,7 +716,7 @@ func TestMessage_DumpMessageIndex(t *testing.T) {
 
 	g := grib.NewGrib2(mm)
 
-	msg, err := g.ReadMessageAt(0)
+	msg, err := g.ReadMessageAt(0, 0)
 	require.NoError(t, err)
 	require.NotNil(t, msg)
 
@@ -272,7 +798,7 @@ func BenchmarkMessageReader_ReadLL(b *testing.B) {
 
 		g := grib.NewGrib2(mm)
 
-		msg, err := g.ReadMessageAt(0)
+		msg, err := g.ReadMessageAt(0, 0)
 		require.NoError(b, err)
 		require.NotNil(b, msg)
 
@@ -297,7 +823,7 @@ func BenchmarkMessageReader_ReadLL(b *testing.B) {
 
 		g := grib.NewGrib2(mm)
 
-		msg, err := g.ReadMessageAt(0)
+		msg, err := g.ReadMessageAt(0, 0)
 		require.NoError(b, err)
 		require.NotNil(b, msg)
 
@@ -326,7 +852,7 @@ func BenchmarkReadMessageAt(b *testing.B) {
 
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
-			_, err := g.ReadMessageAt(0)
+			_, err := g.ReadMessageAt(0, 0)
 			require.NoError(b, err)
 		}
 	})
@@ -342,7 +868,7 @@ func BenchmarkReadMessageAt(b *testing.B) {
 
 		b.ResetTimer()
 		for i := 0; i < b.N; i++ {
-			_, err := g.ReadMessageAt(0)
+			_, err := g.ReadMessageAt(0, 0)
 			require.NoError(b, err)
 		}
 	})
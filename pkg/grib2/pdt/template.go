@@ -1,7 +1,6 @@
 package pdt
 
 import (
-	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -20,6 +19,10 @@ type Template interface {
 	GetTypeOfSecondFixedSurface() int
 	GetScaleFactorOfSecondFixedSurface() int
 	GetScaledValueOfSecondFixedSurface() int
+	GetProbabilityType() int
+	GetLowerLimit() (float64, bool)
+	GetUpperLimit() (float64, bool)
+	GetPercentileValue() (int, bool)
 }
 
 type MissingTemplate struct{}
@@ -35,6 +38,10 @@ func (m MissingTemplate) GetScaledValueOfFirstFixedSurface() int  { return -1 }
 func (m MissingTemplate) GetTypeOfSecondFixedSurface() int        { return -1 }
 func (m MissingTemplate) GetScaleFactorOfSecondFixedSurface() int { return -1 }
 func (m MissingTemplate) GetScaledValueOfSecondFixedSurface() int { return -1 }
+func (m MissingTemplate) GetProbabilityType() int                 { return -1 }
+func (m MissingTemplate) GetLowerLimit() (float64, bool)          { return 0, false }
+func (m MissingTemplate) GetUpperLimit() (float64, bool)          { return 0, false }
+func (m MissingTemplate) GetPercentileValue() (int, bool)         { return 0, false }
 
 func ReadTemplate(r io.Reader, n uint16) (Template, error) {
 	switch n {
@@ -46,6 +53,58 @@ func ReadTemplate(r io.Reader, n uint16) (Template, error) {
 
 		return t0.Export(), nil
 
+	case 1:
+		t0, err := readTemplate0(r)
+		if err != nil {
+			return nil, fmt.Errorf("template0: %w", err)
+		}
+
+		t1, err := readTemplate1(r, t0)
+		if err != nil {
+			return nil, fmt.Errorf("template1: %w", err)
+		}
+
+		return t1.Export(), nil
+
+	case 2:
+		t0, err := readTemplate0(r)
+		if err != nil {
+			return nil, fmt.Errorf("template0: %w", err)
+		}
+
+		t2, err := readTemplate2(r, t0)
+		if err != nil {
+			return nil, fmt.Errorf("template2: %w", err)
+		}
+
+		return t2.Export(), nil
+
+	case 5:
+		t0, err := readTemplate0(r)
+		if err != nil {
+			return nil, fmt.Errorf("template0: %w", err)
+		}
+
+		t5, err := readTemplate5(r, t0)
+		if err != nil {
+			return nil, fmt.Errorf("template5: %w", err)
+		}
+
+		return t5.Export(), nil
+
+	case 6:
+		t0, err := readTemplate0(r)
+		if err != nil {
+			return nil, fmt.Errorf("template0: %w", err)
+		}
+
+		t6, err := readTemplate6(r, t0)
+		if err != nil {
+			return nil, fmt.Errorf("template6: %w", err)
+		}
+
+		return t6.Export(), nil
+
 	case 8:
 		t0, err := readTemplate0(r)
 		if err != nil {
@@ -61,6 +120,146 @@ func ReadTemplate(r io.Reader, n uint16) (Template, error) {
 
 		return t8.Export(), nil
 
+	case 9:
+		t0, err := readTemplate0(r)
+		if err != nil {
+			return nil, fmt.Errorf("template0: %w", err)
+		}
+
+		t9, err := readTemplate9(r, t0)
+		if err != nil {
+			return nil, fmt.Errorf("template9: %w", err)
+		}
+
+		return t9.Export(), nil
+
+	case 10:
+		t0, err := readTemplate0(r)
+		if err != nil {
+			return nil, fmt.Errorf("template0: %w", err)
+		}
+
+		t10, err := readTemplate10(r, t0)
+		if err != nil {
+			return nil, fmt.Errorf("template10: %w", err)
+		}
+
+		return t10.Export(), nil
+
+	case 11:
+		t0, err := readTemplate0(r)
+		if err != nil {
+			return nil, fmt.Errorf("template0: %w", err)
+		}
+
+		t11, err := readTemplate11(r, t0)
+		if err != nil {
+			return nil, fmt.Errorf("template11: %w", err)
+		}
+
+		return t11.Export(), nil
+
+	case 12:
+		t0, err := readTemplate0(r)
+		if err != nil {
+			return nil, fmt.Errorf("template0: %w", err)
+		}
+
+		t12, err := readTemplate12(r, t0)
+		if err != nil {
+			return nil, fmt.Errorf("template12: %w", err)
+		}
+
+		return t12.Export(), nil
+
+	case 31:
+		t31, err := readTemplate31(r)
+		if err != nil {
+			return nil, fmt.Errorf("template31: %w", err)
+		}
+
+		return t31.Export(), nil
+
+	case 32:
+		t32, err := readTemplate32(r)
+		if err != nil {
+			return nil, fmt.Errorf("template32: %w", err)
+		}
+
+		return t32.Export(), nil
+
+	case 40:
+		t40, err := readTemplate40(r)
+		if err != nil {
+			return nil, fmt.Errorf("template40: %w", err)
+		}
+
+		return t40.Export(), nil
+
+	case 41:
+		t41, err := readTemplate41(r)
+		if err != nil {
+			return nil, fmt.Errorf("template41: %w", err)
+		}
+
+		return t41.Export(), nil
+
+	case 42:
+		t42, err := readTemplate42(r)
+		if err != nil {
+			return nil, fmt.Errorf("template42: %w", err)
+		}
+
+		return t42.Export(), nil
+
+	case 43:
+		t43, err := readTemplate43(r)
+		if err != nil {
+			return nil, fmt.Errorf("template43: %w", err)
+		}
+
+		return t43.Export(), nil
+
+	case 44:
+		t44, err := readTemplate44(r)
+		if err != nil {
+			return nil, fmt.Errorf("template44: %w", err)
+		}
+
+		return t44.Export(), nil
+
+	case 45:
+		t45, err := readTemplate45(r)
+		if err != nil {
+			return nil, fmt.Errorf("template45: %w", err)
+		}
+
+		return t45.Export(), nil
+
+	case 46:
+		t46, err := readTemplate46(r)
+		if err != nil {
+			return nil, fmt.Errorf("template46: %w", err)
+		}
+
+		return t46.Export(), nil
+
+	case 47:
+		t47, err := readTemplate47(r)
+		if err != nil {
+			return nil, fmt.Errorf("template47: %w", err)
+		}
+
+		return t47.Export(), nil
+
+	case 48:
+		t48, err := readTemplate48(r)
+		if err != nil {
+			return nil, fmt.Errorf("template48: %w", err)
+		}
+
+		return t48.Export(), nil
+
 	case 255:
 		return &MissingTemplate{}, nil
 
@@ -78,18 +277,303 @@ func readTemplate0(r io.Reader) (*template0, error) {
 	return &tpl, nil
 }
 
+func readTemplate1(r io.Reader, t0 *template0) (*template1, error) {
+	tpl := template1{template0: t0}
+	if err := binary.Read(r, binary.BigEndian, &tpl.template1fields); err != nil {
+		return nil, err
+	}
+
+	return &tpl, nil
+}
+
+func readTemplate2(r io.Reader, t0 *template0) (*template2, error) {
+	tpl := template2{template0: t0}
+	if err := binary.Read(r, binary.BigEndian, &tpl.template2fields); err != nil {
+		return nil, err
+	}
+
+	return &tpl, nil
+}
+
+func readTemplate5(r io.Reader, t0 *template0) (*template5, error) {
+	tpl := template5{template0: t0}
+	if err := binary.Read(r, binary.BigEndian, &tpl.template5fields); err != nil {
+		return nil, err
+	}
+
+	return &tpl, nil
+}
+
+func readTemplate6(r io.Reader, t0 *template0) (*template6, error) {
+	tpl := template6{template0: t0}
+	if err := binary.Read(r, binary.BigEndian, &tpl.template6fields); err != nil {
+		return nil, err
+	}
+
+	return &tpl, nil
+}
+
 func readTemplate8(r io.Reader, t0 *template0) (*template8, error) {
-	var tpl template8
-	if err := binary.Read(r, binary.BigEndian, &tpl.template8fields); err != nil {
+	tpl := template8{template0: t0}
+	if err := binary.Read(r, binary.BigEndian, &tpl.template8header); err != nil {
+		return nil, err
+	}
+
+	tpl.TimeRanges = make([]timeRange, tpl.NumberOfTimeRanges)
+	if err := binary.Read(r, binary.BigEndian, tpl.TimeRanges); err != nil {
+		return nil, fmt.Errorf("time ranges: %w", err)
+	}
+
+	return &tpl, nil
+}
+
+func readTemplate9(r io.Reader, t0 *template0) (*template9, error) {
+	t5, err := readTemplate5(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	t8, err := readTemplate8(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template9{template0: t0, template5fields: t5.template5fields, template8fields: t8.template8fields}, nil
+}
+
+func readTemplate10(r io.Reader, t0 *template0) (*template10, error) {
+	t6, err := readTemplate6(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	t8, err := readTemplate8(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template10{template0: t0, template6fields: t6.template6fields, template8fields: t8.template8fields}, nil
+}
+
+func readTemplate11(r io.Reader, t0 *template0) (*template11, error) {
+	t1, err := readTemplate1(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	t8, err := readTemplate8(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template11{template0: t0, template1fields: t1.template1fields, template8fields: t8.template8fields}, nil
+}
+
+func readTemplate12(r io.Reader, t0 *template0) (*template12, error) {
+	t2, err := readTemplate2(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	t8, err := readTemplate8(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template12{template0: t0, template2fields: t2.template2fields, template8fields: t8.template8fields}, nil
+}
+
+func readTemplate31(r io.Reader) (*template31, error) {
+	var tpl template31
+	if err := binary.Read(r, binary.BigEndian, &tpl.template31header); err != nil {
+		return nil, err
+	}
+
+	tpl.SpectralBands = make([]spectralBand, tpl.NumberOfContributingSpectralBands)
+	if err := binary.Read(r, binary.BigEndian, tpl.SpectralBands); err != nil {
+		return nil, fmt.Errorf("spectral bands: %w", err)
+	}
+
+	return &tpl, nil
+}
+
+func readTemplate32(r io.Reader) (*template32, error) {
+	var tpl template32
+	if err := binary.Read(r, binary.BigEndian, &tpl.template32header); err != nil {
+		return nil, err
+	}
+
+	tpl.SpectralBands = make([]spectralBand, tpl.NumberOfContributingSpectralBands)
+	if err := binary.Read(r, binary.BigEndian, tpl.SpectralBands); err != nil {
+		return nil, fmt.Errorf("spectral bands: %w", err)
+	}
+
+	return &tpl, nil
+}
+
+// readTemplate0With reads template 4.0 with the fields inserted after the parameter number, as in the
+// templates of atmospheric chemical constituents and aerosols.
+func readTemplate0With(r io.Reader, fields ...any) (*template0, error) {
+	var tpl template0
+	if err := binary.Read(r, binary.BigEndian, &tpl.template0parameter); err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		if err := binary.Read(r, binary.BigEndian, f); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &tpl.template0fields); err != nil {
+		return nil, err
+	}
+
+	return &tpl, nil
+}
+
+func readTemplate40(r io.Reader) (*template40, error) {
+	var tpl template40
+
+	t0, err := readTemplate0With(r, &tpl.template40fields)
+	if err != nil {
 		return nil, err
 	}
 
 	tpl.template0 = t0
 
-	bs := tpl.template8fields.GetAdditionalTimeRangeSpecifications()
-	if _, err := io.Copy(bytes.NewBuffer(bs), r); err != nil {
+	return &tpl, nil
+}
+
+func readTemplate41(r io.Reader) (*template41, error) {
+	t40, err := readTemplate40(r)
+	if err != nil {
+		return nil, err
+	}
+
+	t1, err := readTemplate1(r, t40.template0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template41{template0: t40.template0, template40fields: t40.template40fields, template1fields: t1.template1fields}, nil
+}
+
+func readTemplate42(r io.Reader) (*template42, error) {
+	t40, err := readTemplate40(r)
+	if err != nil {
 		return nil, err
 	}
 
+	t8, err := readTemplate8(r, t40.template0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template42{template0: t40.template0, template40fields: t40.template40fields, template8fields: t8.template8fields}, nil
+}
+
+func readTemplate43(r io.Reader) (*template43, error) {
+	t41, err := readTemplate41(r)
+	if err != nil {
+		return nil, err
+	}
+
+	t8, err := readTemplate8(r, t41.template0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template43{
+		template0:        t41.template0,
+		template40fields: t41.template40fields,
+		template1fields:  t41.template1fields,
+		template8fields:  t8.template8fields,
+	}, nil
+}
+
+func readTemplate44(r io.Reader) (*template44, error) {
+	var (
+		t0   template0
+		tpl  = template44{template0: &t0}
+		tail template44tail
+	)
+
+	for _, f := range []any{&t0.template0parameter, &tpl.template44fields, &tail} {
+		if err := binary.Read(r, binary.BigEndian, f); err != nil {
+			return nil, err
+		}
+	}
+
+	t0.template0fields = tail.template0fields()
+
+	return &tpl, nil
+}
+
+func readTemplate45(r io.Reader) (*template45, error) {
+	var tpl template45
+
+	t0, err := readTemplate0With(r, &tpl.template44fields)
+	if err != nil {
+		return nil, err
+	}
+
+	t1, err := readTemplate1(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl.template0, tpl.template1fields = t0, t1.template1fields
+
+	return &tpl, nil
+}
+
+func readTemplate46(r io.Reader) (*template46, error) {
+	var tpl template46
+
+	t0, err := readTemplate0With(r, &tpl.template44fields)
+	if err != nil {
+		return nil, err
+	}
+
+	t8, err := readTemplate8(r, t0)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl.template0, tpl.template8fields = t0, t8.template8fields
+
+	return &tpl, nil
+}
+
+func readTemplate47(r io.Reader) (*template47, error) {
+	t45, err := readTemplate45(r)
+	if err != nil {
+		return nil, err
+	}
+
+	t8, err := readTemplate8(r, t45.template0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template47{
+		template0:        t45.template0,
+		template44fields: t45.template44fields,
+		template1fields:  t45.template1fields,
+		template8fields:  t8.template8fields,
+	}, nil
+}
+
+func readTemplate48(r io.Reader) (*template48, error) {
+	var tpl template48
+
+	t0, err := readTemplate0With(r, &tpl.template44fields, &tpl.template48fields)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl.template0 = t0
+
 	return &tpl, nil
 }
@@ -7,8 +7,16 @@ import (
 )
 
 type template0 struct {
-	ParameterCategory                             uint8  // 10
-	ParameterNumber                               uint8  // 11
+	template0parameter
+	template0fields
+}
+
+type template0parameter struct {
+	ParameterCategory uint8 // 10
+	ParameterNumber   uint8 // 11
+}
+
+type template0fields struct {
 	TypeOfGeneratingProcess                       uint8  // 12
 	BackgroundProcess                             uint8  // 13
 	AnalysisOrForecastGeneratingProcessIdentified uint8  // 14
@@ -100,3 +108,12 @@ func (t Template0) GetScaleFactorOfSecondFixedSurface() int {
 func (t Template0) GetScaledValueOfSecondFixedSurface() int {
 	return int(t.ScaledValueOfSecondFixedSurface)
 }
+
+// GetProbabilityType returns -1, the product is not a probability forecast.
+func (t Template0) GetProbabilityType() int { return -1 }
+
+func (t Template0) GetLowerLimit() (float64, bool) { return 0, false }
+func (t Template0) GetUpperLimit() (float64, bool) { return 0, false }
+
+// GetPercentileValue returns false, the product is not a percentile forecast.
+func (t Template0) GetPercentileValue() (int, bool) { return 0, false }
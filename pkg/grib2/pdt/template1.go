@@ -0,0 +1,59 @@
+package pdt
+
+type template1 struct {
+	*template0 // 10-34
+	template1fields
+}
+
+type template1fields struct {
+	TypeOfEnsembleForecast      uint8 // 35
+	PerturbationNumber          uint8 // 36
+	NumberOfForecastsInEnsemble uint8 // 37
+}
+
+func (fields template1fields) Export() EnsembleMember {
+	return EnsembleMember{
+		TypeOfEnsembleForecast:      fields.TypeOfEnsembleForecast,
+		PerturbationNumber:          fields.PerturbationNumber,
+		NumberOfForecastsInEnsemble: fields.NumberOfForecastsInEnsemble,
+	}
+}
+
+func (t template1) Export() *Template1 {
+	return &Template1{
+		Template0:      t.template0.Export(),
+		EnsembleMember: t.template1fields.Export(),
+	}
+}
+
+// Template1 is an individual ensemble forecast, control and perturbed, at a horizontal level or in a
+// horizontal layer at a point in time.
+type Template1 struct {
+	*Template0
+	EnsembleMember
+}
+
+// EnsembleMember is an individual ensemble forecast, as in templates 4.1, 4.11, 4.41, 4.43, 4.45 and 4.47.
+type EnsembleMember struct {
+	TypeOfEnsembleForecast      uint8 // https://codes.ecmwf.int/grib/format/grib2/ctables/4/6/
+	PerturbationNumber          uint8
+	NumberOfForecastsInEnsemble uint8
+}
+
+func (e EnsembleMember) GetTypeOfEnsembleForecast() int { return octet(e.TypeOfEnsembleForecast) }
+func (e EnsembleMember) GetPerturbationNumber() int     { return octet(e.PerturbationNumber) }
+func (e EnsembleMember) GetNumberOfForecastsInEnsemble() int {
+	return octet(e.NumberOfForecastsInEnsemble)
+}
+
+// GetDerivedForecast returns -1, an individual ensemble forecast is not derived.
+func (e EnsembleMember) GetDerivedForecast() int { return -1 }
+
+// octet returns v, -1 if it is missing.
+func octet(v uint8) int {
+	if v == 0xff {
+		return -1
+	}
+
+	return int(v)
+}
@@ -0,0 +1,21 @@
+package pdt
+
+type template10 struct {
+	*template0 // 10-34
+	template6fields
+	template8fields // 36-
+}
+
+func (t template10) Export() *Template10 {
+	return &Template10{
+		Template6:    template6{template0: t.template0, template6fields: t.template6fields}.Export(),
+		TimeInterval: t.template8fields.Export(),
+	}
+}
+
+// Template10 is a percentile forecast at a horizontal level or in a horizontal layer in a continuous
+// or non-continuous time interval.
+type Template10 struct {
+	*Template6
+	*TimeInterval
+}
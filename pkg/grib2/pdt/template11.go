@@ -0,0 +1,21 @@
+package pdt
+
+type template11 struct {
+	*template0 // 10-34
+	template1fields
+	template8fields // 38-
+}
+
+func (t template11) Export() *Template11 {
+	return &Template11{
+		Template1:    template1{template0: t.template0, template1fields: t.template1fields}.Export(),
+		TimeInterval: t.template8fields.Export(),
+	}
+}
+
+// Template11 is an individual ensemble forecast, control and perturbed, at a horizontal level or in a
+// horizontal layer in a continuous or non-continuous time interval.
+type Template11 struct {
+	*Template1
+	*TimeInterval
+}
@@ -0,0 +1,21 @@
+package pdt
+
+type template12 struct {
+	*template0 // 10-34
+	template2fields
+	template8fields // 37-
+}
+
+func (t template12) Export() *Template12 {
+	return &Template12{
+		Template2:    template2{template0: t.template0, template2fields: t.template2fields}.Export(),
+		TimeInterval: t.template8fields.Export(),
+	}
+}
+
+// Template12 is a derived forecast based on all ensemble members at a horizontal level or in a
+// horizontal layer in a continuous or non-continuous time interval.
+type Template12 struct {
+	*Template2
+	*TimeInterval
+}
@@ -0,0 +1,36 @@
+package pdt
+
+type template2 struct {
+	*template0 // 10-34
+	template2fields
+}
+
+type template2fields struct {
+	DerivedForecast             uint8 // 35
+	NumberOfForecastsInEnsemble uint8 // 36
+}
+
+func (t template2) Export() *Template2 {
+	return &Template2{
+		Template0:                   t.template0.Export(),
+		DerivedForecast:             t.DerivedForecast,
+		NumberOfForecastsInEnsemble: t.NumberOfForecastsInEnsemble,
+	}
+}
+
+// Template2 is a derived forecast based on all ensemble members at a horizontal level or in a
+// horizontal layer at a point in time.
+type Template2 struct {
+	*Template0
+	DerivedForecast             uint8 // https://codes.ecmwf.int/grib/format/grib2/ctables/4/7/
+	NumberOfForecastsInEnsemble uint8
+}
+
+// GetTypeOfEnsembleForecast returns -1, a derived forecast is not an ensemble member.
+func (t Template2) GetTypeOfEnsembleForecast() int { return -1 }
+
+// GetPerturbationNumber returns -1, a derived forecast is not an ensemble member.
+func (t Template2) GetPerturbationNumber() int { return -1 }
+
+func (t Template2) GetNumberOfForecastsInEnsemble() int { return octet(t.NumberOfForecastsInEnsemble) }
+func (t Template2) GetDerivedForecast() int             { return octet(t.DerivedForecast) }
@@ -0,0 +1,99 @@
+package pdt
+
+import (
+	"math"
+
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+/*
+Notes:
+( 1) Satellite products have no fixed surface, the types of the fixed surfaces of the exported Template0
+are missing and the level is 0.
+
+( 2) An observed satellite product has no forecast time, its values are valid at the reference time.
+*/
+type template31 struct {
+	template31header
+	// 15 - nn 11 octets for each of the nb contributing spectral bands, where nn = 14 + 11 x nb
+	SpectralBands []spectralBand
+}
+
+type template31header struct {
+	template0parameter                           // 10-11
+	TypeOfGeneratingProcess                uint8 // 12
+	ObservationGeneratingProcessIdentifier uint8 // 13
+	NumberOfContributingSpectralBands      uint8 // 14 nb
+}
+
+type spectralBand struct {
+	SatelliteSeries                uint16 // 15-16
+	SatelliteNumber                uint16 // 17-18
+	InstrumentType                 uint16 // 19-20
+	ScaleFactorOfCentralWaveNumber uint8  // 21
+	ScaledValueOfCentralWaveNumber uint32 // 22-25
+}
+
+func exportSpectralBands(bands []spectralBand) []SpectralBand {
+	sb := make([]SpectralBand, len(bands))
+	for i, b := range bands {
+		sb[i] = SpectralBand{
+			SatelliteSeries:                b.SatelliteSeries,
+			SatelliteNumber:                b.SatelliteNumber,
+			InstrumentType:                 b.InstrumentType,
+			ScaleFactorOfCentralWaveNumber: regulation.ToInt8(b.ScaleFactorOfCentralWaveNumber),
+			ScaledValueOfCentralWaveNumber: regulation.ToInt32(b.ScaledValueOfCentralWaveNumber),
+		}
+	}
+
+	return sb
+}
+
+func (t template31) Export() *Template31 {
+	t0 := template0{
+		template0parameter: t.template0parameter,
+		// see notes 1 and 2
+		template0fields: template0fields{
+			TypeOfGeneratingProcess:                       t.TypeOfGeneratingProcess,
+			BackgroundProcess:                             0xff,
+			AnalysisOrForecastGeneratingProcessIdentified: 0xff,
+			HoursAfterDataCutoff:                          0xffff,
+			MinutesAfterDataCutoff:                        0xff,
+			IndicatorOfUnitForForecastTime:                0xff,
+			ForecastTime:                                  math.MaxUint32,
+			TypeOfFirstFixedSurface:                       0xff,
+			TypeOfSecondFixedSurface:                      0xff,
+		},
+	}
+
+	return &Template31{
+		Template0:                              t0.Export(),
+		ObservationGeneratingProcessIdentifier: t.ObservationGeneratingProcessIdentifier,
+		SpectralBands:                          exportSpectralBands(t.SpectralBands),
+	}
+}
+
+// Template31 is a satellite product, see notes 1 and 2.
+type Template31 struct {
+	*Template0
+	ObservationGeneratingProcessIdentifier uint8
+	SpectralBands                          []SpectralBand
+}
+
+// SpectralBand is a spectral band contributing to a satellite product, templates 4.31 and 4.32.
+type SpectralBand struct {
+	SatelliteSeries                uint16
+	SatelliteNumber                uint16
+	InstrumentType                 uint16
+	ScaleFactorOfCentralWaveNumber int8
+	ScaledValueOfCentralWaveNumber int32 // m-1
+}
+
+// GetCentralWaveNumber returns the central wave number of the band in m-1.
+func (b SpectralBand) GetCentralWaveNumber() float64 {
+	return float64(b.ScaledValueOfCentralWaveNumber) * math.Pow10(-int(b.ScaleFactorOfCentralWaveNumber))
+}
+
+func (t Template31) GetSpectralBands() []SpectralBand {
+	return t.SpectralBands
+}
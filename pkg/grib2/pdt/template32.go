@@ -0,0 +1,53 @@
+package pdt
+
+type template32 struct {
+	template32header
+	// 24 - nn 11 octets for each of the nb contributing spectral bands, where nn = 23 + 11 x nb
+	SpectralBands []spectralBand
+}
+
+type template32header struct {
+	template0parameter                                   // 10-11
+	TypeOfGeneratingProcess                       uint8  // 12
+	BackgroundProcess                             uint8  // 13
+	AnalysisOrForecastGeneratingProcessIdentified uint8  // 14
+	HoursAfterDataCutoff                          uint16 // 15-16
+	MinutesAfterDataCutoff                        uint8  // 17
+	IndicatorOfUnitForForecastTime                uint8  // 18
+	ForecastTime                                  uint32 // 19-22
+	NumberOfContributingSpectralBands             uint8  // 23 nb
+}
+
+func (t template32) Export() *Template32 {
+	t0 := template0{
+		template0parameter: t.template0parameter,
+		// see note 1 of template 4.31
+		template0fields: template0fields{
+			TypeOfGeneratingProcess:                       t.TypeOfGeneratingProcess,
+			BackgroundProcess:                             t.BackgroundProcess,
+			AnalysisOrForecastGeneratingProcessIdentified: t.AnalysisOrForecastGeneratingProcessIdentified,
+			HoursAfterDataCutoff:                          t.HoursAfterDataCutoff,
+			MinutesAfterDataCutoff:                        t.MinutesAfterDataCutoff,
+			IndicatorOfUnitForForecastTime:                t.IndicatorOfUnitForForecastTime,
+			ForecastTime:                                  t.ForecastTime,
+			TypeOfFirstFixedSurface:                       0xff,
+			TypeOfSecondFixedSurface:                      0xff,
+		},
+	}
+
+	return &Template32{
+		Template0:     t0.Export(),
+		SpectralBands: exportSpectralBands(t.SpectralBands),
+	}
+}
+
+// Template32 is an analysis or forecast at a horizontal level or in a horizontal layer at a point in
+// time for simulated (synthetic) satellite data, see note 1 of template 4.31.
+type Template32 struct {
+	*Template0
+	SpectralBands []SpectralBand
+}
+
+func (t Template32) GetSpectralBands() []SpectralBand {
+	return t.SpectralBands
+}
@@ -0,0 +1,55 @@
+package pdt
+
+type template40 struct {
+	*template0 // 10-11, 14-36
+	template40fields
+}
+
+type template40fields struct {
+	ConstituentType uint16 // 12-13
+}
+
+func (fields template40fields) Export() ChemicalConstituent {
+	return ChemicalConstituent{
+		ConstituentType: fields.ConstituentType,
+	}
+}
+
+func (t template40) Export() *Template40 {
+	return &Template40{
+		Template0:           t.template0.Export(),
+		ChemicalConstituent: t.template40fields.Export(),
+	}
+}
+
+// Template40 is an analysis or forecast at a horizontal level or in a horizontal layer at a point in
+// time for atmospheric chemical constituents.
+type Template40 struct {
+	*Template0
+	ChemicalConstituent
+}
+
+// ChemicalConstituent is the atmospheric chemical constituent of templates 4.40 to 4.43.
+type ChemicalConstituent struct {
+	ConstituentType uint16 // https://codes.ecmwf.int/grib/format/grib2/ctables/4/230/
+}
+
+func (c ChemicalConstituent) GetConstituentType() int {
+	if c.ConstituentType == 0xffff {
+		return -1
+	}
+
+	return int(c.ConstituentType)
+}
+
+// The size and wavelength intervals only apply to aerosols, they are -1 for chemical constituents.
+func (c ChemicalConstituent) GetTypeOfSizeInterval() int            { return -1 }
+func (c ChemicalConstituent) GetScaleFactorOfFirstSize() int        { return -1 }
+func (c ChemicalConstituent) GetScaledValueOfFirstSize() int        { return -1 }
+func (c ChemicalConstituent) GetScaleFactorOfSecondSize() int       { return -1 }
+func (c ChemicalConstituent) GetScaledValueOfSecondSize() int       { return -1 }
+func (c ChemicalConstituent) GetTypeOfWavelengthInterval() int      { return -1 }
+func (c ChemicalConstituent) GetScaleFactorOfFirstWavelength() int  { return -1 }
+func (c ChemicalConstituent) GetScaledValueOfFirstWavelength() int  { return -1 }
+func (c ChemicalConstituent) GetScaleFactorOfSecondWavelength() int { return -1 }
+func (c ChemicalConstituent) GetScaledValueOfSecondWavelength() int { return -1 }
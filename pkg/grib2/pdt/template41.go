@@ -0,0 +1,23 @@
+package pdt
+
+type template41 struct {
+	*template0 // 10-11, 14-36
+	template40fields
+	template1fields // 37-39
+}
+
+func (t template41) Export() *Template41 {
+	return &Template41{
+		Template0:           t.template0.Export(),
+		ChemicalConstituent: t.template40fields.Export(),
+		EnsembleMember:      t.template1fields.Export(),
+	}
+}
+
+// Template41 is an individual ensemble forecast, control and perturbed, at a horizontal level or in a
+// horizontal layer at a point in time for atmospheric chemical constituents.
+type Template41 struct {
+	*Template0
+	ChemicalConstituent
+	EnsembleMember
+}
@@ -0,0 +1,24 @@
+package pdt
+
+type template42 struct {
+	*template0 // 10-11, 14-36
+	template40fields
+	template8fields // 37-
+}
+
+func (t template42) Export() *Template42 {
+	return &Template42{
+		Template0:           t.template0.Export(),
+		ChemicalConstituent: t.template40fields.Export(),
+		TimeInterval:        t.template8fields.Export(),
+	}
+}
+
+// Template42 is an average, accumulation and/or extreme values or other statistically processed values
+// at a horizontal level or in a horizontal layer in a continuous or non-continuous time interval for
+// atmospheric chemical constituents.
+type Template42 struct {
+	*Template0
+	ChemicalConstituent
+	*TimeInterval
+}
@@ -0,0 +1,26 @@
+package pdt
+
+type template43 struct {
+	*template0 // 10-11, 14-36
+	template40fields
+	template1fields // 37-39
+	template8fields // 40-
+}
+
+func (t template43) Export() *Template43 {
+	return &Template43{
+		Template0:           t.template0.Export(),
+		ChemicalConstituent: t.template40fields.Export(),
+		EnsembleMember:      t.template1fields.Export(),
+		TimeInterval:        t.template8fields.Export(),
+	}
+}
+
+// Template43 is an individual ensemble forecast, control and perturbed, at a horizontal level or in a
+// horizontal layer in a continuous or non-continuous time interval for atmospheric chemical constituents.
+type Template43 struct {
+	*Template0
+	ChemicalConstituent
+	EnsembleMember
+	*TimeInterval
+}
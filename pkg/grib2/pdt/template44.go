@@ -0,0 +1,131 @@
+package pdt
+
+import "github.com/scorix/grib-go/pkg/grib2/regulation"
+
+/*
+Notes:
+( 1) Template 4.44 is deprecated, its forecast time takes only 2 octets. Templates 4.45 to 4.47 have the
+forecast time of 4 octets of template 4.0, and template 4.48 should be used instead of template 4.44.
+*/
+type template44 struct {
+	*template0 // 10-11, 25-45
+	template44fields
+}
+
+type template44fields struct {
+	AerosolType             uint16 // 12-13
+	TypeOfSizeInterval      uint8  // 14
+	ScaleFactorOfFirstSize  uint8  // 15
+	ScaledValueOfFirstSize  uint32 // 16-19
+	ScaleFactorOfSecondSize uint8  // 20
+	ScaledValueOfSecondSize uint32 // 21-24
+}
+
+// template44tail is template 4.0 from the type of generating process, with the short forecast time, see
+// note 1.
+type template44tail struct {
+	TypeOfGeneratingProcess                       uint8  // 25
+	BackgroundProcess                             uint8  // 26
+	AnalysisOrForecastGeneratingProcessIdentified uint8  // 27
+	HoursAfterDataCutoff                          uint16 // 28-29
+	MinutesAfterDataCutoff                        uint8  // 30
+	IndicatorOfUnitForForecastTime                uint8  // 31
+	ForecastTime                                  uint16 // 32-33
+	TypeOfFirstFixedSurface                       uint8  // 34
+	ScaleFactorOfFirstFixedSurface                int8   // 35
+	ScaledValueOfFirstFixedSurface                int32  // 36-39
+	TypeOfSecondFixedSurface                      uint8  // 40
+	ScaleFactorOfSecondFixedSurface               int8   // 41
+	ScaledValueOfSecondFixedSurface               int32  // 42-45
+}
+
+func (tail template44tail) template0fields() template0fields {
+	forecastTime := uint32(tail.ForecastTime)
+	if tail.ForecastTime == 0xffff {
+		forecastTime = 0xffffffff
+	}
+
+	return template0fields{
+		TypeOfGeneratingProcess:                       tail.TypeOfGeneratingProcess,
+		BackgroundProcess:                             tail.BackgroundProcess,
+		AnalysisOrForecastGeneratingProcessIdentified: tail.AnalysisOrForecastGeneratingProcessIdentified,
+		HoursAfterDataCutoff:                          tail.HoursAfterDataCutoff,
+		MinutesAfterDataCutoff:                        tail.MinutesAfterDataCutoff,
+		IndicatorOfUnitForForecastTime:                tail.IndicatorOfUnitForForecastTime,
+		ForecastTime:                                  forecastTime,
+		TypeOfFirstFixedSurface:                       tail.TypeOfFirstFixedSurface,
+		ScaleFactorOfFirstFixedSurface:                tail.ScaleFactorOfFirstFixedSurface,
+		ScaledValueOfFirstFixedSurface:                tail.ScaledValueOfFirstFixedSurface,
+		TypeOfSecondFixedSurface:                      tail.TypeOfSecondFixedSurface,
+		ScaleFactorOfSecondFixedSurface:               tail.ScaleFactorOfSecondFixedSurface,
+		ScaledValueOfSecondFixedSurface:               tail.ScaledValueOfSecondFixedSurface,
+	}
+}
+
+func (fields template44fields) Export() Aerosol {
+	return Aerosol{
+		AerosolType:             fields.AerosolType,
+		TypeOfSizeInterval:      fields.TypeOfSizeInterval,
+		ScaleFactorOfFirstSize:  regulation.ToInt8(fields.ScaleFactorOfFirstSize),
+		ScaledValueOfFirstSize:  regulation.ToInt32(fields.ScaledValueOfFirstSize),
+		ScaleFactorOfSecondSize: regulation.ToInt8(fields.ScaleFactorOfSecondSize),
+		ScaledValueOfSecondSize: regulation.ToInt32(fields.ScaledValueOfSecondSize),
+		// only template 4.48 has a wavelength interval
+		TypeOfWavelengthInterval:      0xff,
+		ScaleFactorOfFirstWavelength:  -1,
+		ScaledValueOfFirstWavelength:  -1,
+		ScaleFactorOfSecondWavelength: -1,
+		ScaledValueOfSecondWavelength: -1,
+	}
+}
+
+func (t template44) Export() *Template44 {
+	return &Template44{
+		Template0: t.template0.Export(),
+		Aerosol:   t.template44fields.Export(),
+	}
+}
+
+// Template44 is an analysis or forecast at a horizontal level or in a horizontal layer at a point in
+// time for aerosol, see note 1.
+type Template44 struct {
+	*Template0
+	Aerosol
+}
+
+// Aerosol is the aerosol of templates 4.44 to 4.48, with its size and optical wavelength intervals in
+// metres. The type of an interval tells which of its limits are given, code table 4.91, so that PM2.5
+// and PM10 are told apart by the size interval of the same aerosol type.
+type Aerosol struct {
+	AerosolType             uint16 // https://codes.ecmwf.int/grib/format/grib2/ctables/4/233/
+	TypeOfSizeInterval      uint8  // https://codes.ecmwf.int/grib/format/grib2/ctables/4/91/
+	ScaleFactorOfFirstSize  int8
+	ScaledValueOfFirstSize  int32
+	ScaleFactorOfSecondSize int8
+	ScaledValueOfSecondSize int32
+
+	TypeOfWavelengthInterval      uint8 // https://codes.ecmwf.int/grib/format/grib2/ctables/4/91/
+	ScaleFactorOfFirstWavelength  int8
+	ScaledValueOfFirstWavelength  int32
+	ScaleFactorOfSecondWavelength int8
+	ScaledValueOfSecondWavelength int32
+}
+
+func (a Aerosol) GetConstituentType() int {
+	if a.AerosolType == 0xffff {
+		return -1
+	}
+
+	return int(a.AerosolType)
+}
+
+func (a Aerosol) GetTypeOfSizeInterval() int            { return octet(a.TypeOfSizeInterval) }
+func (a Aerosol) GetScaleFactorOfFirstSize() int        { return int(a.ScaleFactorOfFirstSize) }
+func (a Aerosol) GetScaledValueOfFirstSize() int        { return int(a.ScaledValueOfFirstSize) }
+func (a Aerosol) GetScaleFactorOfSecondSize() int       { return int(a.ScaleFactorOfSecondSize) }
+func (a Aerosol) GetScaledValueOfSecondSize() int       { return int(a.ScaledValueOfSecondSize) }
+func (a Aerosol) GetTypeOfWavelengthInterval() int      { return octet(a.TypeOfWavelengthInterval) }
+func (a Aerosol) GetScaleFactorOfFirstWavelength() int  { return int(a.ScaleFactorOfFirstWavelength) }
+func (a Aerosol) GetScaledValueOfFirstWavelength() int  { return int(a.ScaledValueOfFirstWavelength) }
+func (a Aerosol) GetScaleFactorOfSecondWavelength() int { return int(a.ScaleFactorOfSecondWavelength) }
+func (a Aerosol) GetScaledValueOfSecondWavelength() int { return int(a.ScaledValueOfSecondWavelength) }
@@ -0,0 +1,23 @@
+package pdt
+
+type template45 struct {
+	*template0 // 10-11, 25-47
+	template44fields
+	template1fields // 48-50
+}
+
+func (t template45) Export() *Template45 {
+	return &Template45{
+		Template0:      t.template0.Export(),
+		Aerosol:        t.template44fields.Export(),
+		EnsembleMember: t.template1fields.Export(),
+	}
+}
+
+// Template45 is an individual ensemble forecast, control and perturbed, at a horizontal level or in a
+// horizontal layer at a point in time for aerosol.
+type Template45 struct {
+	*Template0
+	Aerosol
+	EnsembleMember
+}
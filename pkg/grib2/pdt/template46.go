@@ -0,0 +1,24 @@
+package pdt
+
+type template46 struct {
+	*template0 // 10-11, 25-47
+	template44fields
+	template8fields // 48-
+}
+
+func (t template46) Export() *Template46 {
+	return &Template46{
+		Template0:    t.template0.Export(),
+		Aerosol:      t.template44fields.Export(),
+		TimeInterval: t.template8fields.Export(),
+	}
+}
+
+// Template46 is an average, accumulation and/or extreme values or other statistically processed values
+// at a horizontal level or in a horizontal layer in a continuous or non-continuous time interval for
+// aerosol.
+type Template46 struct {
+	*Template0
+	Aerosol
+	*TimeInterval
+}
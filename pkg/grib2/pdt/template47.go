@@ -0,0 +1,26 @@
+package pdt
+
+type template47 struct {
+	*template0 // 10-11, 25-47
+	template44fields
+	template1fields // 48-50
+	template8fields // 51-
+}
+
+func (t template47) Export() *Template47 {
+	return &Template47{
+		Template0:      t.template0.Export(),
+		Aerosol:        t.template44fields.Export(),
+		EnsembleMember: t.template1fields.Export(),
+		TimeInterval:   t.template8fields.Export(),
+	}
+}
+
+// Template47 is an individual ensemble forecast, control and perturbed, at a horizontal level or in a
+// horizontal layer in a continuous or non-continuous time interval for aerosol.
+type Template47 struct {
+	*Template0
+	Aerosol
+	EnsembleMember
+	*TimeInterval
+}
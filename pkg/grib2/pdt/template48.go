@@ -0,0 +1,38 @@
+package pdt
+
+import "github.com/scorix/grib-go/pkg/grib2/regulation"
+
+type template48 struct {
+	*template0 // 10-11, 36-58
+	template44fields
+	template48fields
+}
+
+type template48fields struct {
+	TypeOfWavelengthInterval      uint8  // 25
+	ScaleFactorOfFirstWavelength  uint8  // 26
+	ScaledValueOfFirstWavelength  uint32 // 27-30
+	ScaleFactorOfSecondWavelength uint8  // 31
+	ScaledValueOfSecondWavelength uint32 // 32-35
+}
+
+func (t template48) Export() *Template48 {
+	aerosol := t.template44fields.Export()
+	aerosol.TypeOfWavelengthInterval = t.TypeOfWavelengthInterval
+	aerosol.ScaleFactorOfFirstWavelength = regulation.ToInt8(t.ScaleFactorOfFirstWavelength)
+	aerosol.ScaledValueOfFirstWavelength = regulation.ToInt32(t.ScaledValueOfFirstWavelength)
+	aerosol.ScaleFactorOfSecondWavelength = regulation.ToInt8(t.ScaleFactorOfSecondWavelength)
+	aerosol.ScaledValueOfSecondWavelength = regulation.ToInt32(t.ScaledValueOfSecondWavelength)
+
+	return &Template48{
+		Template0: t.template0.Export(),
+		Aerosol:   aerosol,
+	}
+}
+
+// Template48 is an analysis or forecast at a horizontal level or in a horizontal layer at a point in
+// time for optical properties of aerosol.
+type Template48 struct {
+	*Template0
+	Aerosol
+}
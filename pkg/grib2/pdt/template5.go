@@ -0,0 +1,91 @@
+package pdt
+
+import (
+	"math"
+
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
+type template5 struct {
+	*template0 // 10-34
+	template5fields
+}
+
+type template5fields struct {
+	ForecastProbabilityNumber          uint8  // 35
+	TotalNumberOfForecastProbabilities uint8  // 36
+	ProbabilityType                    uint8  // 37
+	ScaleFactorOfLowerLimit            uint8  // 38
+	ScaledValueOfLowerLimit            uint32 // 39-42
+	ScaleFactorOfUpperLimit            uint8  // 43
+	ScaledValueOfUpperLimit            uint32 // 44-47
+}
+
+// exportLimit returns the limit of the scale factor and scaled value, nil if either is missing.
+func exportLimit(scaleFactor uint8, scaledValue uint32) *Limit {
+	if scaleFactor == 0xff || scaledValue == math.MaxUint32 {
+		return nil
+	}
+
+	return &Limit{
+		ScaleFactor: regulation.ToInt8(scaleFactor),
+		ScaledValue: regulation.ToInt32(scaledValue),
+	}
+}
+
+func (t template5) Export() *Template5 {
+	return &Template5{
+		Template0:                          t.template0.Export(),
+		ForecastProbabilityNumber:          t.ForecastProbabilityNumber,
+		TotalNumberOfForecastProbabilities: t.TotalNumberOfForecastProbabilities,
+		ProbabilityType:                    t.ProbabilityType,
+		LowerLimit:                         exportLimit(t.ScaleFactorOfLowerLimit, t.ScaledValueOfLowerLimit),
+		UpperLimit:                         exportLimit(t.ScaleFactorOfUpperLimit, t.ScaledValueOfUpperLimit),
+	}
+}
+
+// Template5 is a probability forecast at a horizontal level or in a horizontal layer at a point in time.
+type Template5 struct {
+	*Template0
+	ForecastProbabilityNumber          uint8
+	TotalNumberOfForecastProbabilities uint8
+	ProbabilityType                    uint8  // https://codes.ecmwf.int/grib/format/grib2/ctables/4/9/
+	LowerLimit                         *Limit // nil if missing
+	UpperLimit                         *Limit // nil if missing
+}
+
+// Limit is a limit of a probability, the value is ScaledValue * 10^-ScaleFactor.
+type Limit struct {
+	ScaleFactor int8
+	ScaledValue int32
+}
+
+// Value returns the value of the limit, the same for the encodings of a value with different scale
+// factors, such as 10 with 0 and 100 with 1.
+func (l Limit) Value() float64 {
+	if l.ScaleFactor >= 0 {
+		return float64(l.ScaledValue) / math.Pow10(int(l.ScaleFactor))
+	}
+
+	return float64(l.ScaledValue) * math.Pow10(-int(l.ScaleFactor))
+}
+
+func (t Template5) GetProbabilityType() int { return octet(t.ProbabilityType) }
+
+// GetLowerLimit returns the value of the lower limit, false if it is missing.
+func (t Template5) GetLowerLimit() (float64, bool) {
+	if t.LowerLimit == nil {
+		return 0, false
+	}
+
+	return t.LowerLimit.Value(), true
+}
+
+// GetUpperLimit returns the value of the upper limit, false if it is missing.
+func (t Template5) GetUpperLimit() (float64, bool) {
+	if t.UpperLimit == nil {
+		return 0, false
+	}
+
+	return t.UpperLimit.Value(), true
+}
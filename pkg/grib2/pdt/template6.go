@@ -0,0 +1,32 @@
+package pdt
+
+type template6 struct {
+	*template0 // 10-34
+	template6fields
+}
+
+type template6fields struct {
+	PercentileValue uint8 // 35
+}
+
+func (t template6) Export() *Template6 {
+	return &Template6{
+		Template0:       t.template0.Export(),
+		PercentileValue: t.PercentileValue,
+	}
+}
+
+// Template6 is a percentile forecast at a horizontal level or in a horizontal layer at a point in time.
+type Template6 struct {
+	*Template0
+	PercentileValue uint8 // from 0 to 100
+}
+
+// GetPercentileValue returns the percentile, false if it is missing.
+func (t Template6) GetPercentileValue() (int, bool) {
+	if t.PercentileValue == 0xff {
+		return 0, false
+	}
+
+	return int(t.PercentileValue), true
+}
@@ -1,11 +1,24 @@
 package pdt
 
+import (
+	"time"
+
+	"github.com/scorix/grib-go/pkg/grib2/regulation"
+)
+
 type template8 struct {
 	*template0 // 10-34
 	template8fields
 }
 
 type template8fields struct {
+	template8header
+	// 47 - 58 Specification of the outermost (or only) time range over which statistical processing is done
+	// 59 - nn These octets are included only if n>1, where nn = 46 + 12 x n
+	TimeRanges []timeRange
+}
+
+type template8header struct {
 	Year                                               uint16 // 35-36
 	Month                                              uint8  // 37
 	Day                                                uint8  // 38
@@ -14,33 +27,122 @@ type template8fields struct {
 	Second                                             uint8  // 41
 	NumberOfTimeRanges                                 uint8  // 42 n
 	TotalNumberOfDataValuesMissingInStatisticalProcess uint32 // 43-46
-	// 47 - 58 Specification of the outermost (or only) time range over which statistical processing is done
+}
+
+type timeRange struct {
 	StatisticalProcess                uint8  // 47
 	TypeOfTimeIncrement               uint8  // 48
 	IndicatorOfUnitOfTimeForTimeRange uint8  // 49
 	LengthOfTimeRange                 uint32 // 50-53
 	IndicatorOfUnitOfTimeForIncrement uint8  // 54
 	TimeIncrement                     uint32 // 55-58
-	// 59-70 As octets 47 to 58, next innermost step of processing
 }
 
-func (fields template8fields) GetAdditionalTimeRangeSpecifications() []byte {
-	if fields.NumberOfTimeRanges == 0 {
-		return nil
+func (fields template8fields) Export() *TimeInterval {
+	ti := &TimeInterval{
+		Year:                                fields.Year,
+		Month:                               fields.Month,
+		Day:                                 fields.Day,
+		Hour:                                fields.Hour,
+		Minute:                              fields.Minute,
+		Second:                              fields.Second,
+		NumberOfTimeRanges:                  fields.NumberOfTimeRanges,
+		NumberOfMissingInStatisticalProcess: regulation.ToInt32(fields.TotalNumberOfDataValuesMissingInStatisticalProcess),
+		TimeRanges:                          make([]TimeRange, len(fields.TimeRanges)),
 	}
 
-	// 59 - nn These octets are included only if n>1, where nn = 46 + 12 x n
-	var nn = 46 + 12*fields.NumberOfTimeRanges + 12
+	for i, r := range fields.TimeRanges {
+		ti.TimeRanges[i] = TimeRange{
+			StatisticalProcess:                r.StatisticalProcess,
+			TypeOfTimeIncrement:               r.TypeOfTimeIncrement,
+			IndicatorOfUnitOfTimeForTimeRange: IndicatorOfUnitForTime(r.IndicatorOfUnitOfTimeForTimeRange),
+			LengthOfTimeRange:                 regulation.ToInt32(r.LengthOfTimeRange),
+			IndicatorOfUnitOfTimeForIncrement: IndicatorOfUnitForTime(r.IndicatorOfUnitOfTimeForIncrement),
+			TimeIncrement:                     regulation.ToInt32(r.TimeIncrement),
+		}
+	}
 
-	return make([]byte, nn)
+	return ti
 }
 
 func (t template8) Export() *Template8 {
 	return &Template8{
-		Template0: t.template0.Export(),
+		Template0:    t.template0.Export(),
+		TimeInterval: t.template8fields.Export(),
 	}
 }
 
+// Template8 is an average, accumulation, extreme values or other statistically processed values at a
+// horizontal level or in a horizontal layer in a continuous or non-continuous time interval.
 type Template8 struct {
 	*Template0
+	*TimeInterval
+}
+
+// TimeInterval is the time interval of statistically processed values, as in templates 4.8 to 4.12,
+// 4.42, 4.43, 4.46 and 4.47.
+type TimeInterval struct {
+	// end of the overall time interval
+	Year   uint16
+	Month  uint8
+	Day    uint8
+	Hour   uint8
+	Minute uint8
+	Second uint8
+
+	NumberOfTimeRanges                  uint8
+	NumberOfMissingInStatisticalProcess int32
+	// the outermost (or only) time range first, then the next inner ones
+	TimeRanges []TimeRange
+}
+
+// StatisticalProcessing is implemented by the templates of statistically processed values, templates
+// 4.8 to 4.12, 4.42, 4.43, 4.46 and 4.47.
+type StatisticalProcessing interface {
+	GetStatisticalProcess() int
+	GetEndOfOverallTimeInterval(loc *time.Location) time.Time
+	GetTimeRanges() []TimeRange
+	GetNumberOfMissingInStatisticalProcess() int
+}
+
+// TimeRange is the specification of a time range over which statistical processing is done.
+type TimeRange struct {
+	StatisticalProcess                uint8 // https://codes.ecmwf.int/grib/format/grib2/ctables/4/10/
+	TypeOfTimeIncrement               uint8 // https://codes.ecmwf.int/grib/format/grib2/ctables/4/11/
+	IndicatorOfUnitOfTimeForTimeRange IndicatorOfUnitForTime
+	LengthOfTimeRange                 int32
+	IndicatorOfUnitOfTimeForIncrement IndicatorOfUnitForTime
+	TimeIncrement                     int32 // 0 for continuous processing
+}
+
+// GetLength returns the length of the time range.
+func (r TimeRange) GetLength() time.Duration {
+	return r.IndicatorOfUnitOfTimeForTimeRange.AsDuration(int(r.LengthOfTimeRange))
+}
+
+// GetIncrement returns the time increment between successive fields used in the statistical processing.
+func (r TimeRange) GetIncrement() time.Duration {
+	return r.IndicatorOfUnitOfTimeForIncrement.AsDuration(int(r.TimeIncrement))
+}
+
+// GetStatisticalProcess returns the statistical process of the outermost time range, -1 if there is none.
+func (t *TimeInterval) GetStatisticalProcess() int {
+	if len(t.TimeRanges) == 0 {
+		return -1
+	}
+
+	return octet(t.TimeRanges[0].StatisticalProcess)
+}
+
+// GetEndOfOverallTimeInterval returns the end of the overall time interval in loc.
+func (t *TimeInterval) GetEndOfOverallTimeInterval(loc *time.Location) time.Time {
+	return time.Date(int(t.Year), time.Month(t.Month), int(t.Day), int(t.Hour), int(t.Minute), int(t.Second), 0, loc)
+}
+
+func (t *TimeInterval) GetTimeRanges() []TimeRange {
+	return t.TimeRanges
+}
+
+func (t *TimeInterval) GetNumberOfMissingInStatisticalProcess() int {
+	return int(t.NumberOfMissingInStatisticalProcess)
 }
@@ -0,0 +1,21 @@
+package pdt
+
+type template9 struct {
+	*template0 // 10-34
+	template5fields
+	template8fields // 48-
+}
+
+func (t template9) Export() *Template9 {
+	return &Template9{
+		Template5:    template5{template0: t.template0, template5fields: t.template5fields}.Export(),
+		TimeInterval: t.template8fields.Export(),
+	}
+}
+
+// Template9 is a probability forecast at a horizontal level or in a horizontal layer in a continuous
+// or non-continuous time interval.
+type Template9 struct {
+	*Template5
+	*TimeInterval
+}
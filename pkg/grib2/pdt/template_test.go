@@ -0,0 +1,451 @@
+package pdt_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/scorix/grib-go/pkg/grib2"
+	"github.com/scorix/grib-go/pkg/grib2/pdt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func octets(vs ...any) []byte {
+	var buf bytes.Buffer
+
+	for _, v := range vs {
+		_ = binary.Write(&buf, binary.BigEndian, v)
+	}
+
+	return buf.Bytes()
+}
+
+var (
+	// octets 12-34 of template 4.0, a forecast 6 hours after the reference time at 2 m above ground
+	forecast = octets(
+		uint8(2), uint8(0xff), uint8(0xff), uint16(0xffff), uint8(0xff), uint8(1), uint32(6),
+		uint8(103), uint8(0), uint32(2), uint8(0xff), uint8(0), uint32(0),
+	)
+
+	// the end of the overall time interval and a 6 hours accumulation
+	interval = octets(
+		uint16(2024), uint8(8), uint8(20), uint8(18), uint8(0), uint8(0), uint8(1), uint32(0),
+		uint8(1), uint8(2), uint8(1), uint32(6), uint8(1), uint32(0),
+	)
+
+	// total aerosol smaller than or equal to 2.5e-6 m and 1e-5 m
+	pm25 = octets(uint16(62000), uint8(9), uint8(0xff), uint32(math.MaxUint32), uint8(7), uint32(25))
+	pm10 = octets(uint16(62000), uint8(9), uint8(0xff), uint32(math.MaxUint32), uint8(5), uint32(1))
+
+	// channels 13 and 8 of the ABI of GOES-16
+	bands = octets(
+		uint16(333), uint16(16), uint16(617), uint8(0), uint32(96805),
+		uint16(333), uint16(16), uint16(617), uint8(1), uint32(1615510),
+	)
+)
+
+// readTemplate reads the template n from the octets and checks the parameter and the forecast time.
+func readTemplate(t *testing.T, n uint16, data []byte, want pdt.Template, category, number int, duration time.Duration) pdt.Template {
+	t.Helper()
+
+	tpl, err := pdt.ReadTemplate(bytes.NewReader(data), n)
+	require.NoError(t, err)
+	require.IsType(t, want, tpl)
+
+	assert.Equal(t, category, tpl.GetParameterCategory())
+	assert.Equal(t, number, tpl.GetParameterNumber())
+	assert.Equal(t, duration, tpl.GetForecastDuration())
+
+	return tpl
+}
+
+func TestReadTemplate_Ensemble(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		n        uint16
+		data     []byte
+		want     pdt.Template
+		ensemble [4]int // type, perturbation number, number of forecasts, derived forecast
+	}{
+		{
+			name:     "individual ensemble forecast",
+			n:        1,
+			data:     octets(uint8(0), uint8(0), forecast, uint8(3), uint8(12), uint8(31)),
+			want:     &pdt.Template1{},
+			ensemble: [4]int{3, 12, 31, -1},
+		},
+		{
+			name:     "derived forecast",
+			n:        2,
+			data:     octets(uint8(0), uint8(0), forecast, uint8(0), uint8(51)),
+			want:     &pdt.Template2{},
+			ensemble: [4]int{-1, -1, 51, 0},
+		},
+		{
+			name:     "individual ensemble forecast in a time interval",
+			n:        11,
+			data:     octets(uint8(0), uint8(0), forecast, uint8(1), uint8(0), uint8(51), interval),
+			want:     &pdt.Template11{},
+			ensemble: [4]int{1, 0, 51, -1},
+		},
+		{
+			name:     "derived forecast in a time interval",
+			n:        12,
+			data:     octets(uint8(0), uint8(0), forecast, uint8(2), uint8(0xff), interval),
+			want:     &pdt.Template12{},
+			ensemble: [4]int{-1, -1, -1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tpl := readTemplate(t, tt.n, tt.data, tt.want, 0, 0, 6*time.Hour)
+			assert.Equal(t, 103, tpl.GetTypeOfFirstFixedSurface())
+			assert.Equal(t, 2, tpl.GetScaledValueOfFirstFixedSurface())
+
+			e, ok := tpl.(grib2.Ensemble)
+			require.True(t, ok)
+			assert.Equal(t, tt.ensemble, [4]int{
+				e.GetTypeOfEnsembleForecast(),
+				e.GetPerturbationNumber(),
+				e.GetNumberOfForecastsInEnsemble(),
+				e.GetDerivedForecast(),
+			})
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := pdt.ReadTemplate(bytes.NewReader(octets(uint8(0), uint8(0), forecast, uint8(3), uint8(12))), 1)
+		require.Error(t, err)
+	})
+}
+
+func TestReadTemplate_ProbabilityAndPercentile(t *testing.T) {
+	t.Parallel()
+
+	// a limit or a percentile, which is not ok when missing
+	type value struct {
+		v  float64
+		ok bool
+	}
+
+	tests := []struct {
+		name            string
+		n               uint16
+		data            []byte
+		want            pdt.Template
+		probabilityType int
+		lower, upper    value
+		percentile      value
+	}{
+		{
+			name:            "deterministic forecast",
+			n:               0,
+			data:            octets(uint8(0), uint8(0), forecast),
+			want:            &pdt.Template0{},
+			probabilityType: -1,
+		},
+		{
+			name:            "probability above the upper limit",
+			n:               5,
+			data:            octets(uint8(0), uint8(0), forecast, uint8(0), uint8(1), uint8(1), uint8(0xff), uint32(math.MaxUint32), uint8(3), uint32(10)),
+			want:            &pdt.Template5{},
+			probabilityType: 1,
+			upper:           value{0.01, true},
+		},
+		{
+			name:            "probability above 10 encoded with a scale factor",
+			n:               5,
+			data:            octets(uint8(0), uint8(0), forecast, uint8(0), uint8(1), uint8(1), uint8(0xff), uint32(math.MaxUint32), uint8(1), uint32(100)),
+			want:            &pdt.Template5{},
+			probabilityType: 1,
+			upper:           value{10, true},
+		},
+		{
+			name:            "probability below -1",
+			n:               5,
+			data:            octets(uint8(0), uint8(0), forecast, uint8(0), uint8(1), uint8(0), uint8(0), uint32(0x80000000|1), uint8(0xff), uint32(math.MaxUint32)),
+			want:            &pdt.Template5{},
+			probabilityType: 0,
+			lower:           value{-1, true},
+		},
+		{
+			name:            "percentile",
+			n:               6,
+			data:            octets(uint8(0), uint8(0), forecast, uint8(90)),
+			want:            &pdt.Template6{},
+			probabilityType: -1,
+			percentile:      value{90, true},
+		},
+		{
+			name:            "probability between the limits in a time interval",
+			n:               9,
+			data:            octets(uint8(0), uint8(0), forecast, uint8(2), uint8(3), uint8(2), uint8(1), uint32(0x80000000|25), uint8(1), uint32(25), interval),
+			want:            &pdt.Template9{},
+			probabilityType: 2,
+			lower:           value{-2.5, true},
+			upper:           value{2.5, true},
+		},
+		{
+			name:            "percentile in a time interval",
+			n:               10,
+			data:            octets(uint8(0), uint8(0), forecast, uint8(50), interval),
+			want:            &pdt.Template10{},
+			probabilityType: -1,
+			percentile:      value{50, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tpl := readTemplate(t, tt.n, tt.data, tt.want, 0, 0, 6*time.Hour)
+			assert.Equal(t, tt.probabilityType, tpl.GetProbabilityType())
+
+			lower, ok := tpl.GetLowerLimit()
+			assert.Equal(t, tt.lower, value{lower, ok})
+
+			upper, ok := tpl.GetUpperLimit()
+			assert.Equal(t, tt.upper, value{upper, ok})
+
+			percentile, ok := tpl.GetPercentileValue()
+			assert.Equal(t, tt.percentile, value{float64(percentile), ok})
+
+			_, ok = tpl.(grib2.Ensemble)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestReadTemplate_Constituent(t *testing.T) {
+	t.Parallel()
+
+	member := octets(uint8(3), uint8(2), uint8(10))
+	missing := [5]int{-1, -1, -1, -1, -1}
+
+	tests := []struct {
+		name          string
+		n             uint16
+		data          []byte
+		want          pdt.Template
+		ensemble      bool
+		statistically bool
+		// constituent type, the size interval and the wavelength interval: type, scale factor and scaled
+		// value of the first and second limits
+		constituentType int
+		size            [5]int
+		wavelength      [5]int
+	}{
+		{
+			name:            "ozone",
+			n:               40,
+			data:            octets(uint8(20), uint8(2), uint16(0), forecast),
+			want:            &pdt.Template40{},
+			constituentType: 0,
+			size:            missing,
+			wavelength:      missing,
+		},
+		{
+			name:            "nitrogen dioxide of an ensemble member",
+			n:               41,
+			data:            octets(uint8(20), uint8(2), uint16(5), forecast, member),
+			want:            &pdt.Template41{},
+			ensemble:        true,
+			constituentType: 5,
+			size:            missing,
+			wavelength:      missing,
+		},
+		{
+			name:            "accumulated ozone",
+			n:               42,
+			data:            octets(uint8(20), uint8(2), uint16(0), forecast, interval),
+			want:            &pdt.Template42{},
+			statistically:   true,
+			constituentType: 0,
+			size:            missing,
+			wavelength:      missing,
+		},
+		{
+			name:            "accumulated nitrogen dioxide of an ensemble member",
+			n:               43,
+			data:            octets(uint8(20), uint8(2), uint16(5), forecast, member, interval),
+			want:            &pdt.Template43{},
+			ensemble:        true,
+			statistically:   true,
+			constituentType: 5,
+			size:            missing,
+			wavelength:      missing,
+		},
+		{
+			name:            "pm2.5 of an ensemble member",
+			n:               45,
+			data:            octets(uint8(20), uint8(2), pm25, forecast, member),
+			want:            &pdt.Template45{},
+			ensemble:        true,
+			constituentType: 62000,
+			size:            [5]int{9, -1, -1, 7, 25},
+			wavelength:      missing,
+		},
+		{
+			name:            "accumulated pm10",
+			n:               46,
+			data:            octets(uint8(20), uint8(2), pm10, forecast, interval),
+			want:            &pdt.Template46{},
+			statistically:   true,
+			constituentType: 62000,
+			size:            [5]int{9, -1, -1, 5, 1},
+			wavelength:      missing,
+		},
+		{
+			name:            "accumulated pm10 of an ensemble member",
+			n:               47,
+			data:            octets(uint8(20), uint8(2), pm10, forecast, member, interval),
+			want:            &pdt.Template47{},
+			ensemble:        true,
+			statistically:   true,
+			constituentType: 62000,
+			size:            [5]int{9, -1, -1, 5, 1},
+			wavelength:      missing,
+		},
+		{
+			name: "aerosol optical depth at 550 nm",
+			n:    48,
+			data: octets(
+				uint8(20), uint8(2),
+				uint16(62000), uint8(0xff), uint8(0xff), uint32(math.MaxUint32), uint8(0xff), uint32(math.MaxUint32),
+				uint8(11), uint8(9), uint32(550), uint8(0xff), uint32(math.MaxUint32),
+				forecast,
+			),
+			want:            &pdt.Template48{},
+			constituentType: 62000,
+			size:            missing,
+			wavelength:      [5]int{11, 9, 550, -1, -1},
+		},
+		{
+			name: "deprecated aerosol template with a short forecast time",
+			n:    44,
+			data: octets(
+				uint8(20), uint8(2), pm25,
+				uint8(2), uint8(0xff), uint8(0xff), uint16(0xffff), uint8(0xff), uint8(1), uint16(6),
+				uint8(103), uint8(0), uint32(2), uint8(0xff), uint8(0), uint32(0),
+			),
+			want:            &pdt.Template44{},
+			constituentType: 62000,
+			size:            [5]int{9, -1, -1, 7, 25},
+			wavelength:      missing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tpl := readTemplate(t, tt.n, tt.data, tt.want, 20, 2, 6*time.Hour)
+			assert.Equal(t, 103, tpl.GetTypeOfFirstFixedSurface())
+
+			c, ok := tpl.(grib2.Constituent)
+			require.True(t, ok)
+			assert.Equal(t, tt.constituentType, c.GetConstituentType())
+			assert.Equal(t, tt.size, [5]int{
+				c.GetTypeOfSizeInterval(),
+				c.GetScaleFactorOfFirstSize(),
+				c.GetScaledValueOfFirstSize(),
+				c.GetScaleFactorOfSecondSize(),
+				c.GetScaledValueOfSecondSize(),
+			})
+			assert.Equal(t, tt.wavelength, [5]int{
+				c.GetTypeOfWavelengthInterval(),
+				c.GetScaleFactorOfFirstWavelength(),
+				c.GetScaledValueOfFirstWavelength(),
+				c.GetScaleFactorOfSecondWavelength(),
+				c.GetScaledValueOfSecondWavelength(),
+			})
+
+			_, ok = tpl.(grib2.Ensemble)
+			assert.Equal(t, tt.ensemble, ok)
+
+			_, ok = tpl.(pdt.StatisticalProcessing)
+			assert.Equal(t, tt.statistically, ok)
+		})
+	}
+
+	t.Run("truncated deprecated aerosol template", func(t *testing.T) {
+		t.Parallel()
+
+		tpl, err := pdt.ReadTemplate(bytes.NewReader(octets(
+			uint8(20), uint8(2), pm25,
+			uint8(2), uint8(0xff), uint8(0xff), uint16(0xffff), uint8(0xff), uint8(1), uint16(6),
+		)), 44)
+		require.Error(t, err)
+		require.Nil(t, tpl)
+	})
+}
+
+func TestReadTemplate_Satellite(t *testing.T) {
+	t.Parallel()
+
+	want := []pdt.SpectralBand{
+		{SatelliteSeries: 333, SatelliteNumber: 16, InstrumentType: 617, ScaleFactorOfCentralWaveNumber: 0, ScaledValueOfCentralWaveNumber: 96805},
+		{SatelliteSeries: 333, SatelliteNumber: 16, InstrumentType: 617, ScaleFactorOfCentralWaveNumber: 1, ScaledValueOfCentralWaveNumber: 1615510},
+	}
+
+	tests := []struct {
+		name     string
+		n        uint16
+		data     []byte
+		want     pdt.Template
+		forecast time.Duration
+	}{
+		{
+			name: "observed brightness temperature",
+			n:    31,
+			data: octets(uint8(4), uint8(4), uint8(8), uint8(0xff), uint8(2), bands),
+			want: &pdt.Template31{},
+		},
+		{
+			name: "simulated brightness temperature",
+			n:    32,
+			data: octets(
+				uint8(4), uint8(4), uint8(2), uint8(0xff), uint8(83), uint16(0), uint8(0), uint8(1), uint32(3), uint8(2),
+				bands,
+			),
+			want:     &pdt.Template32{},
+			forecast: 3 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tpl := readTemplate(t, tt.n, tt.data, tt.want, 4, 4, tt.forecast)
+
+			// no fixed surface
+			assert.Equal(t, 255, tpl.GetTypeOfFirstFixedSurface())
+			assert.Equal(t, 0, tpl.GetLevel())
+
+			s, ok := tpl.(grib2.Satellite)
+			require.True(t, ok)
+			require.Equal(t, want, s.GetSpectralBands())
+			assert.InDelta(t, 96805, s.GetSpectralBands()[0].GetCentralWaveNumber(), 1e-9)
+			assert.InDelta(t, 161551, s.GetSpectralBands()[1].GetCentralWaveNumber(), 1e-9)
+		})
+	}
+
+	t.Run("missing spectral bands", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := pdt.ReadTemplate(bytes.NewReader(octets(uint8(4), uint8(4), uint8(8), uint8(0xff), uint8(3), bands)), 31)
+		require.ErrorContains(t, err, "spectral bands")
+	})
+}
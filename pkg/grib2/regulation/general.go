@@ -49,6 +49,11 @@ func ToUint8(v int8) uint8 {
 	return uint8(ToUint(int(v), 8))
 }
 
+// FlagOctet returns an octet of a flag table, whose bit 1 is a flag and not the sign of 92.1.5.
+func FlagOctet(v uint8) int8 {
+	return int8(v)
+}
+
 func ToInt16(v uint16) int16 {
 	return int16(ToInt(int(v), 16))
 }
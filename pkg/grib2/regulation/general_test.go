@@ -34,6 +34,14 @@ func TestToInt8(t *testing.T) {
 	assert.Equal(t, int8(math.MaxInt8), regulation.ToInt8(math.MaxInt8))
 }
 
+func TestFlagOctet(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int8(-128), regulation.FlagOctet(0x80))
+	assert.Equal(t, uint8(0xd0), uint8(regulation.FlagOctet(0xd0)))
+	assert.Equal(t, int8(48), regulation.FlagOctet(48))
+}
+
 func TestToInt(t *testing.T) {
 	t.Parallel()
 
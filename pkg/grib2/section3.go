@@ -38,11 +38,21 @@ func (s *section3) readFrom(r io.ReaderAt, offset int64, length int64) error {
 		return fmt.Errorf("binary read: %w", err)
 	}
 
-	tpl, err := gdt.ReadTemplate(bytes.NewBuffer(p[n:]), s.Section3.GridDefinitionTemplateNumber)
+	buf := bytes.NewBuffer(p[n:])
+
+	tpl, err := gdt.ReadTemplate(buf, s.Section3.GridDefinitionTemplateNumber)
 	if err != nil {
 		return err
 	}
 
+	// quasi-regular grid, the number of points of each row follows the template
+	if s.Section3.NumberOfOctectsForNumberOfPoints > 0 {
+		tpl, err = gdt.ReadPointsPerRow(buf, tpl, s.Section3.NumberOfOctectsForNumberOfPoints, s.Section3.InterpretationOfNumberOfPoints)
+		if err != nil {
+			return fmt.Errorf("read list of number of points: %w", err)
+		}
+	}
+
 	s.Section3.GridDefinitionTemplate = tpl
 
 	return nil
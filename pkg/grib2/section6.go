@@ -10,6 +10,8 @@ import (
 
 type Section6 interface {
 	Section
+	GetBitMapIndicator() int
+	GetBitmap() []byte
 }
 
 type section6 struct {
@@ -30,10 +32,40 @@ func (s *section6) readFrom(r io.ReaderAt, offset int64, length int64) error {
 		return fmt.Errorf("read %d bytes at %d: %w", length, offset, err)
 	}
 
-	_, err := binary.Decode(p, binary.BigEndian, &s.Section6)
+	n, err := binary.Decode(p, binary.BigEndian, &s.Section6.Section6FixedPart)
 	if err != nil {
 		return fmt.Errorf("binary read: %w", err)
 	}
 
+	if s.Section6.BitMapIndicator == definition.BitMapIndicatorSpecified {
+		s.Section6.Bitmap = p[n:]
+	}
+
+	return nil
+}
+
+// Bit-map indicator (From [Table 6.0](https://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_doc/grib2_table6-0.shtml))
+func (s *section6) GetBitMapIndicator() int {
+	return int(s.Section6.BitMapIndicator)
+}
+
+// Bit-map bytes, one bit per grid point. It is also set when the bit-map indicator is 254 and
+// the bit-map is inherited from a previous section 6 of the same message.
+func (s *section6) GetBitmap() []byte {
+	return s.Section6.Bitmap
+}
+
+// inherit applies the previously defined bit-map to a section 6 with indicator 254.
+func (s *section6) inherit(prev *section6) error {
+	if s.Section6.BitMapIndicator != definition.BitMapIndicatorPreviouslyDefined {
+		return nil
+	}
+
+	if prev == nil || prev.Section6.Bitmap == nil {
+		return fmt.Errorf("no previously defined bit-map")
+	}
+
+	s.Section6.Bitmap = prev.Section6.Bitmap
+
 	return nil
 }
@@ -0,0 +1,127 @@
+package grib2_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+)
+
+// testField describes the sections 3 to 7 of a synthetic message. By default, the values are on a
+// regular 1 degree lat/lon grid starting at (lat: Nj-1, lon: 0), of a forecast of the parameter at
+// the ground, packed with 8 bits simple packing. A grid, product or data representation section
+// replaces the default one by the octets which follow its section number, the values are then the
+// octets of section 7.
+type testField struct {
+	ni, nj            int
+	parameterCategory uint8
+	parameterNumber   uint8
+	bitMapIndicator   uint8
+	bitmap            []byte
+	values            []uint8
+	omitSection3      bool
+
+	grid, product, representation []byte
+}
+
+// forecast is octets 12-34 of template 4.0, a forecast at the reference time at the ground.
+var forecast = fields(
+	uint8(2), uint8(0xff), uint8(0xff), uint16(0xffff), uint8(0xff), uint8(1), uint32(0),
+	uint8(1), uint8(0), uint32(0), uint8(0xff), uint8(0), uint32(0),
+)
+
+func section(number uint8, body []byte) []byte {
+	var buf bytes.Buffer
+
+	_ = binary.Write(&buf, binary.BigEndian, uint32(5+len(body)))
+	buf.WriteByte(number)
+	buf.Write(body)
+
+	return buf.Bytes()
+}
+
+func fields(vs ...any) []byte {
+	var buf bytes.Buffer
+
+	for _, v := range vs {
+		_ = binary.Write(&buf, binary.BigEndian, v)
+	}
+
+	return buf.Bytes()
+}
+
+// gridSection returns section 3 of the grid definition template n of points data points, without a
+// list of numbers of points.
+func gridSection(points uint32, n uint16, template ...any) []byte {
+	return fields(append([]any{uint8(0), points, uint8(0), uint8(0), n}, template...)...)
+}
+
+// productSection returns section 4 of the product definition template n, without coordinate values.
+func productSection(n uint16, template ...any) []byte {
+	return fields(append([]any{uint16(0), n}, template...)...)
+}
+
+// representationSection returns section 5 of the data representation template n of points values.
+func representationSection(points uint32, n uint16, template ...any) []byte {
+	return fields(append([]any{points, n}, template...)...)
+}
+
+func (f testField) sections() []byte {
+	var buf bytes.Buffer
+
+	switch {
+	case f.omitSection3:
+	case f.grid != nil:
+		buf.Write(section(3, f.grid))
+	default:
+		buf.Write(section(3, gridSection(
+			uint32(f.ni*f.nj), 0,
+			uint8(6), uint8(0xff), uint32(math.MaxUint32), uint8(0xff), uint32(math.MaxUint32), uint8(0xff), uint32(math.MaxUint32),
+			uint32(f.ni), uint32(f.nj), uint32(0), uint32(math.MaxUint32),
+			uint32((f.nj-1)*1e6), uint32(0), uint8(48), uint32(0), uint32((f.ni-1)*1e6),
+			uint32(1e6), uint32(1e6), uint8(0),
+		)))
+	}
+
+	if f.product != nil {
+		buf.Write(section(4, f.product))
+	} else {
+		buf.Write(section(4, productSection(0, f.parameterCategory, f.parameterNumber, forecast)))
+	}
+
+	if f.representation != nil {
+		buf.Write(section(5, f.representation))
+	} else {
+		buf.Write(section(5, representationSection(uint32(len(f.values)), 0, float32(0), uint16(0), uint16(0), uint8(8), uint8(0))))
+	}
+
+	buf.Write(section(6, append([]byte{f.bitMapIndicator}, f.bitmap...)))
+	buf.Write(section(7, f.values))
+
+	return buf.Bytes()
+}
+
+// testMessage assembles a GRIB2 message holding the given fields.
+func testMessage(fs ...testField) []byte {
+	var body bytes.Buffer
+
+	body.Write(section(1, fields(
+		uint16(7), uint16(0), uint8(2), uint8(1), uint8(1),
+		uint16(2024), uint8(8), uint8(20), uint8(12), uint8(0), uint8(0),
+		uint8(0), uint8(1),
+	)))
+
+	for _, f := range fs {
+		body.Write(f.sections())
+	}
+
+	body.WriteString("7777")
+
+	var buf bytes.Buffer
+
+	buf.WriteString("GRIB")
+	buf.Write([]byte{0, 0, 0, 2})
+	_ = binary.Write(&buf, binary.BigEndian, uint64(16+body.Len()))
+	buf.Write(body.Bytes())
+
+	return buf.Bytes()
+}